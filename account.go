@@ -5,6 +5,8 @@ import (
 	"net/http"
 )
 
+const accountBasePath = "v2/account"
+
 // AccountService is an interface for interfacing with the Account
 // endpoints of the DigitalOcean API
 // See: https://developers.digitalocean.com/documentation/v2/#account
@@ -43,7 +45,7 @@ func (r Account) String() string {
 // Get DigitalOcean account info
 func (s *AccountServiceOp) Get(ctx context.Context) (*Account, *Response, error) {
 
-	path := "v2/account"
+	path := accountBasePath
 
 	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
 	if err != nil {
@@ -14,6 +14,9 @@ const (
 
 	//ActionCompleted is a completed action status
 	ActionCompleted = "completed"
+
+	// ActionErrored is the status of an action that failed
+	ActionErrored = "errored"
 )
 
 // ActionsService handles communction with action related methods of the
@@ -6,6 +6,8 @@ import (
 	"time"
 )
 
+const balanceBasePath = "v2/customers/my/balance"
+
 // BalanceService is an interface for interfacing with the Balance
 // endpoints of the DigitalOcean API
 // See: https://developers.digitalocean.com/documentation/v2/#balance
@@ -35,7 +37,7 @@ func (r Balance) String() string {
 
 // Get DigitalOcean balance info
 func (s *BalanceServiceOp) Get(ctx context.Context) (*Balance, *Response, error) {
-	path := "v2/customers/my/balance"
+	path := balanceBasePath
 
 	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
 	if err != nil {
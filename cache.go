@@ -0,0 +1,306 @@
+package godo
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// HeaderCache is set on responses served by the cache installed with
+	// SetCache, to CacheHit when the response was served without a request,
+	// or to CacheRevalidated when the API confirmed with a 304 Not Modified
+	// that it had not changed.
+	HeaderCache = "X-Godo-Cache"
+
+	CacheHit         = "hit"
+	CacheRevalidated = "revalidated"
+
+	defaultCacheBypassAfterMutation = time.Minute
+)
+
+// DefaultCacheTTLs are the cache TTLs used when CacheOptions.TTLs is nil. They
+// cover catalogs that rarely change: Regions.List, Sizes.List,
+// Images.ListDistribution, Kubernetes.GetOptions and Account.Get.
+var DefaultCacheTTLs = map[string]time.Duration{
+	regionsBasePath:                      time.Hour,
+	sizesBasePath:                        time.Hour,
+	imageBasePath + "?type=distribution": time.Hour,
+	kubernetesOptionsPath:                time.Hour,
+	accountBasePath:                      5 * time.Minute,
+}
+
+// CacheOptions configures the response cache installed by SetCache.
+type CacheOptions struct {
+	// TTLs maps API paths, such as "v2/regions", to how long their responses
+	// are cached. A path may include query parameters, such as
+	// "v2/images?type=distribution", which requests must have to be cached.
+	// Requests to other paths are not cached. Defaults to DefaultCacheTTLs.
+	TTLs map[string]time.Duration
+
+	// BypassAfterMutation is how long the cache is bypassed for a resource
+	// family, such as "images" or "kubernetes", after a POST, PUT, PATCH or
+	// DELETE request to it, so that changes are seen despite the eventual
+	// consistency of the API. Defaults to one minute.
+	BypassAfterMutation time.Duration
+}
+
+// SetCache is a client option for caching the responses to GET requests to
+// the paths of opts.TTLs. Once a cached response expires, it is revalidated
+// with If-None-Match if the API returned an ETag with it. Use
+// Client.InvalidateCache to discard cached responses.
+func SetCache(opts CacheOptions) ClientOpt {
+	return func(c *Client) error {
+		if opts.TTLs == nil {
+			opts.TTLs = DefaultCacheTTLs
+		}
+		if opts.BypassAfterMutation <= 0 {
+			opts.BypassAfterMutation = defaultCacheBypassAfterMutation
+		}
+
+		cache := &responseCache{
+			bypassAfterMutation: opts.BypassAfterMutation,
+			entries:             make(map[string]*cacheEntry),
+			mutated:             make(map[string]time.Time),
+		}
+		for path, ttl := range opts.TTLs {
+			u, err := url.Parse(path)
+			if err != nil {
+				return NewArgError("TTLs", err.Error())
+			}
+			cache.rules = append(cache.rules, cacheRule{
+				path:  strings.Trim(u.Path, "/"),
+				query: u.Query(),
+				ttl:   ttl,
+			})
+		}
+
+		c.cache = cache
+		return nil
+	}
+}
+
+// InvalidateCache discards the cached responses to the given API paths, such
+// as "v2/regions", including the requests to them with any query string.
+// Without paths, all cached responses are discarded.
+func (c *Client) InvalidateCache(paths ...string) {
+	if c.cache != nil {
+		c.cache.invalidate(paths...)
+	}
+}
+
+type cacheRule struct {
+	path  string
+	query url.Values
+	ttl   time.Duration
+}
+
+type cacheEntry struct {
+	path    string
+	status  int
+	header  http.Header
+	body    []byte
+	expires time.Time
+}
+
+// responseCache caches the responses of the requests sent by a client.
+type responseCache struct {
+	rules               []cacheRule
+	bypassAfterMutation time.Duration
+
+	mu      sync.Mutex
+	entries map[string]*cacheEntry
+
+	// mutated holds the time of the last mutating request to each resource
+	// family.
+	mutated map[string]time.Time
+}
+
+// ttl returns how long the response to req may be cached, or 0 if it may not.
+func (rc *responseCache) ttl(req *http.Request) time.Duration {
+	path := apiPath(req)
+	query := req.URL.Query()
+
+	var match *cacheRule
+	for i, r := range rc.rules {
+		if r.path != path || !hasQuery(query, r.query) {
+			continue
+		}
+		// The rule with the most query parameters is the most specific.
+		if match == nil || len(r.query) > len(match.query) {
+			match = &rc.rules[i]
+		}
+	}
+	if match == nil {
+		return 0
+	}
+	return match.ttl
+}
+
+func hasQuery(query, required url.Values) bool {
+	for k := range required {
+		if query.Get(k) != required.Get(k) {
+			return false
+		}
+	}
+	return true
+}
+
+// family returns the resource family of an API path, such as "droplets" for
+// "v2/droplets/1/actions".
+func family(path string) string {
+	segments := strings.Split(path, "/")
+	if len(segments) > 1 && segments[0] == "v2" {
+		return segments[1]
+	}
+	return segments[0]
+}
+
+func (rc *responseCache) invalidate(paths ...string) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	if len(paths) == 0 {
+		rc.entries = make(map[string]*cacheEntry)
+		return
+	}
+	for key, e := range rc.entries {
+		for _, p := range paths {
+			if e.path == strings.Trim(p, "/") {
+				delete(rc.entries, key)
+			}
+		}
+	}
+}
+
+// middleware returns the RequestHandler serving the requests cached by rc
+// and sending the others with next.
+func (rc *responseCache) middleware(next RequestHandler) RequestHandler {
+	return func(ctx context.Context, req *http.Request) (*http.Response, error) {
+		path := apiPath(req)
+		fam := family(path)
+
+		if req.Method != http.MethodGet {
+			rc.mu.Lock()
+			rc.mutated[fam] = time.Now()
+			for key, e := range rc.entries {
+				if family(e.path) == fam {
+					delete(rc.entries, key)
+				}
+			}
+			rc.mu.Unlock()
+			return next(ctx, req)
+		}
+
+		ttl := rc.ttl(req)
+		if ttl <= 0 {
+			return next(ctx, req)
+		}
+
+		key := req.URL.String()
+		rc.mu.Lock()
+		if time.Since(rc.mutated[fam]) < rc.bypassAfterMutation {
+			rc.mu.Unlock()
+			return next(ctx, req)
+		}
+		entry := rc.entries[key]
+		var etag string
+		if entry != nil {
+			if time.Now().Before(entry.expires) {
+				resp := entry.response(req, CacheHit)
+				rc.mu.Unlock()
+				return resp, nil
+			}
+			etag = entry.header.Get("ETag")
+		}
+		rc.mu.Unlock()
+
+		if etag != "" {
+			req.Header.Set("If-None-Match", etag)
+			defer req.Header.Del("If-None-Match")
+		}
+
+		resp, err := next(ctx, req)
+		if err != nil {
+			return resp, err
+		}
+
+		if resp.StatusCode == http.StatusNotModified && etag != "" {
+			_, _ = io.Copy(ioutil.Discard, resp.Body)
+			resp.Body.Close()
+
+			rc.mu.Lock()
+			if rc.entries[key] == entry {
+				entry.expires = time.Now().Add(ttl)
+				if etag := resp.Header.Get("ETag"); etag != "" {
+					entry.header.Set("ETag", etag)
+				}
+				resp := entry.response(req, CacheRevalidated)
+				rc.mu.Unlock()
+				return resp, nil
+			}
+			rc.mu.Unlock()
+
+			// The entry was invalidated while it was revalidated, so its body
+			// may be stale: fetch the resource again.
+			req.Header.Del("If-None-Match")
+			resp, err = next(ctx, req)
+			if err != nil {
+				return resp, err
+			}
+		}
+
+		if resp.StatusCode == http.StatusOK {
+			body, err := ioutil.ReadAll(resp.Body)
+			resp.Body.Close()
+			if err != nil {
+				return nil, err
+			}
+			resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+			rc.mu.Lock()
+			if time.Since(rc.mutated[fam]) >= rc.bypassAfterMutation {
+				// The rate limit of a cached response is not current.
+				header := resp.Header.Clone()
+				header.Del(headerRateLimit)
+				header.Del(headerRateRemaining)
+				header.Del(headerRateReset)
+
+				rc.entries[key] = &cacheEntry{
+					path:    path,
+					status:  resp.StatusCode,
+					header:  header,
+					body:    body,
+					expires: time.Now().Add(ttl),
+				}
+			}
+			rc.mu.Unlock()
+		}
+		return resp, nil
+	}
+}
+
+// response returns a new response to req with the content of e. It must be
+// called with the lock of the cache held.
+func (e *cacheEntry) response(req *http.Request, state string) *http.Response {
+	header := e.header.Clone()
+	header.Set(HeaderCache, state)
+	return &http.Response{
+		Status:        strconv.Itoa(e.status) + " " + http.StatusText(e.status),
+		StatusCode:    e.status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          ioutil.NopCloser(bytes.NewReader(e.body)),
+		ContentLength: int64(len(e.body)),
+		Request:       req,
+	}
+}
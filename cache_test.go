@@ -0,0 +1,248 @@
+package godo
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// countRequests registers a handler counting the GET requests to pattern and
+// answering them with body.
+func countRequests(pattern, body string) *int {
+	n := new(int)
+	mux.HandleFunc(pattern, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			*n++
+		}
+		fmt.Fprint(w, body)
+	})
+	return n
+}
+
+func TestCache_hit(t *testing.T) {
+	setup()
+	defer teardown()
+
+	SetCache(CacheOptions{})(client)
+	requests := countRequests("/v2/regions", `{"regions": [{"slug": "nyc3"}]}`)
+
+	for i := 0; i < 3; i++ {
+		regions, resp, err := client.Regions.List(ctx, nil)
+		if err != nil {
+			t.Fatalf("Regions.List() error = %v", err)
+		}
+		if len(regions) != 1 || regions[0].Slug != "nyc3" {
+			t.Errorf("Regions.List() = %+v", regions)
+		}
+
+		expected := ""
+		if i > 0 {
+			expected = CacheHit
+		}
+		if got := resp.Header.Get(HeaderCache); got != expected {
+			t.Errorf("request %d: %s = %q, expected %q", i, HeaderCache, got, expected)
+		}
+	}
+
+	if *requests != 1 {
+		t.Errorf("sent %d requests, expected 1", *requests)
+	}
+}
+
+func TestCache_revalidation(t *testing.T) {
+	setup()
+	defer teardown()
+
+	SetCache(CacheOptions{TTLs: map[string]time.Duration{"v2/sizes": time.Millisecond}})(client)
+
+	var requests, notModified int
+	mux.HandleFunc("/v2/sizes", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("ETag", `"v1"`)
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			notModified++
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		fmt.Fprint(w, `{"sizes": [{"slug": "s-1vcpu-1gb"}]}`)
+	})
+
+	if _, _, err := client.Sizes.List(ctx, nil); err != nil {
+		t.Fatalf("Sizes.List() error = %v", err)
+	}
+	time.Sleep(5 * time.Millisecond)
+
+	sizes, resp, err := client.Sizes.List(ctx, nil)
+	if err != nil {
+		t.Fatalf("Sizes.List() error = %v", err)
+	}
+	if len(sizes) != 1 || sizes[0].Slug != "s-1vcpu-1gb" {
+		t.Errorf("Sizes.List() = %+v", sizes)
+	}
+	if resp.StatusCode != http.StatusOK || resp.Header.Get(HeaderCache) != CacheRevalidated {
+		t.Errorf("status = %d, %s = %q", resp.StatusCode, HeaderCache, resp.Header.Get(HeaderCache))
+	}
+	if requests != 2 || notModified != 1 {
+		t.Errorf("sent %d requests with %d not modified, expected 2 and 1", requests, notModified)
+	}
+}
+
+func TestCache_queryRules(t *testing.T) {
+	setup()
+	defer teardown()
+
+	SetCache(CacheOptions{})(client)
+
+	var distribution, private int
+	mux.HandleFunc("/v2/images", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("type") == "distribution" {
+			distribution++
+		} else {
+			private++
+		}
+		fmt.Fprint(w, `{"images": [{"id": 1}]}`)
+	})
+
+	for i := 0; i < 2; i++ {
+		if _, _, err := client.Images.ListDistribution(ctx, nil); err != nil {
+			t.Fatalf("Images.ListDistribution() error = %v", err)
+		}
+		if _, _, err := client.Images.ListUser(ctx, nil); err != nil {
+			t.Fatalf("Images.ListUser() error = %v", err)
+		}
+	}
+
+	if distribution != 1 || private != 2 {
+		t.Errorf("sent %d distribution and %d private requests, expected 1 and 2", distribution, private)
+	}
+}
+
+func TestCache_bypassAfterMutation(t *testing.T) {
+	setup()
+	defer teardown()
+
+	SetCache(CacheOptions{BypassAfterMutation: 50 * time.Millisecond})(client)
+	requests := countRequests("/v2/account", `{"account": {"droplet_limit": 25}}`)
+	mux.HandleFunc("/v2/account/keys", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"ssh_key": {"id": 1}}`)
+	})
+
+	get := func() {
+		t.Helper()
+		if _, _, err := client.Account.Get(ctx); err != nil {
+			t.Fatalf("Account.Get() error = %v", err)
+		}
+	}
+
+	get()
+	get()
+	if *requests != 1 {
+		t.Fatalf("sent %d requests before mutation, expected 1", *requests)
+	}
+
+	if _, _, err := client.Keys.Create(ctx, &KeyCreateRequest{Name: "key"}); err != nil {
+		t.Fatalf("Keys.Create() error = %v", err)
+	}
+	get()
+	get()
+	if *requests != 3 {
+		t.Errorf("sent %d requests after mutation, expected 3", *requests)
+	}
+
+	time.Sleep(60 * time.Millisecond)
+	get()
+	get()
+	if *requests != 4 {
+		t.Errorf("sent %d requests after bypass, expected 4", *requests)
+	}
+}
+
+func TestCache_invalidatedDuringRevalidation(t *testing.T) {
+	setup()
+	defer teardown()
+
+	SetCache(CacheOptions{TTLs: map[string]time.Duration{"v2/sizes": time.Millisecond}})(client)
+
+	var requests, conditional int
+	mux.HandleFunc("/v2/sizes", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("ETag", `"v1"`)
+		if r.Header.Get("If-None-Match") != "" {
+			conditional++
+			// The sizes change while the request is in flight.
+			client.InvalidateCache("v2/sizes")
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		fmt.Fprintf(w, `{"sizes": [{"slug": "size-%d"}]}`, requests)
+	})
+
+	if _, _, err := client.Sizes.List(ctx, nil); err != nil {
+		t.Fatalf("Sizes.List() error = %v", err)
+	}
+	time.Sleep(5 * time.Millisecond)
+
+	sizes, resp, err := client.Sizes.List(ctx, nil)
+	if err != nil {
+		t.Fatalf("Sizes.List() error = %v", err)
+	}
+	if len(sizes) != 1 || sizes[0].Slug != "size-3" {
+		t.Errorf("Sizes.List() = %+v, expected the sizes to be fetched again", sizes)
+	}
+	if resp.Header.Get(HeaderCache) != "" {
+		t.Errorf("%s = %q, expected a response from the API", HeaderCache, resp.Header.Get(HeaderCache))
+	}
+	if requests != 3 || conditional != 1 {
+		t.Errorf("sent %d requests with %d conditional, expected 3 and 1", requests, conditional)
+	}
+}
+
+func TestCache_invalidate(t *testing.T) {
+	setup()
+	defer teardown()
+
+	SetCache(CacheOptions{})(client)
+	regions := countRequests("/v2/regions", `{"regions": []}`)
+	sizes := countRequests("/v2/sizes", `{"sizes": []}`)
+
+	list := func() {
+		t.Helper()
+		if _, _, err := client.Regions.List(ctx, nil); err != nil {
+			t.Fatalf("Regions.List() error = %v", err)
+		}
+		if _, _, err := client.Sizes.List(ctx, nil); err != nil {
+			t.Fatalf("Sizes.List() error = %v", err)
+		}
+	}
+
+	list()
+	client.InvalidateCache("v2/regions")
+	list()
+	if *regions != 2 || *sizes != 1 {
+		t.Errorf("sent %d regions and %d sizes requests, expected 2 and 1", *regions, *sizes)
+	}
+
+	client.InvalidateCache()
+	list()
+	if *regions != 3 || *sizes != 2 {
+		t.Errorf("sent %d regions and %d sizes requests, expected 3 and 2", *regions, *sizes)
+	}
+}
+
+func TestCache_uncachedPaths(t *testing.T) {
+	setup()
+	defer teardown()
+
+	SetCache(CacheOptions{})(client)
+	requests := countRequests("/v2/droplets", `{"droplets": []}`)
+
+	for i := 0; i < 2; i++ {
+		if _, _, err := client.Droplets.List(ctx, nil); err != nil {
+			t.Fatalf("Droplets.List() error = %v", err)
+		}
+	}
+	if *requests != 2 {
+		t.Errorf("sent %d requests, expected 2", *requests)
+	}
+}
@@ -8,6 +8,13 @@ import (
 
 const certificatesBasePath = "/v2/certificates"
 
+// Certificate states
+const (
+	CertificateStatePending  = "pending"
+	CertificateStateVerified = "verified"
+	CertificateStateError    = "error"
+)
+
 // CertificatesService is an interface for managing certificates with the DigitalOcean API.
 // See: https://developers.digitalocean.com/documentation/v2/#certificates
 type CertificatesService interface {
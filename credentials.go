@@ -0,0 +1,215 @@
+package godo
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"golang.org/x/oauth2"
+	"gopkg.in/yaml.v2"
+)
+
+// Environment variables read by ResolveCredentials. They are the ones read by
+// doctl.
+const (
+	EnvAccessToken = "DIGITALOCEAN_ACCESS_TOKEN"
+	EnvToken       = "DIGITALOCEAN_TOKEN"
+	EnvContext     = "DIGITALOCEAN_CONTEXT"
+	EnvAPIURL      = "DIGITALOCEAN_API_URL"
+)
+
+// defaultAuthContext is the doctl auth context using the top-level
+// access-token of the config file.
+const defaultAuthContext = "default"
+
+// CredentialsOptions configures how ResolveCredentials looks up credentials.
+type CredentialsOptions struct {
+	// Context is the doctl auth context to use. Defaults to the
+	// DIGITALOCEAN_CONTEXT environment variable, then to the current context
+	// of the config file.
+	Context string
+
+	// ConfigPath is the path of the doctl config file. Defaults to
+	// config.yaml in the doctl directory of os.UserConfigDir, such as
+	// ~/.config/doctl/config.yaml on Linux.
+	ConfigPath string
+}
+
+// Credentials are the token and API URL used to access an account.
+type Credentials struct {
+	Token string
+
+	// APIURL is the base URL of the API, or "" for the default one.
+	APIURL string
+
+	// Source describes where the token was found, such as
+	// "environment variable DIGITALOCEAN_ACCESS_TOKEN".
+	Source string
+}
+
+// CredentialsError is returned when no credentials are found. It lists the
+// sources that were tried and why each of them failed.
+type CredentialsError struct {
+	Tried []string
+}
+
+func (e *CredentialsError) Error() string {
+	return "godo: no DigitalOcean credentials found, tried " + strings.Join(e.Tried, "; ")
+}
+
+// doctlConfig is the part of the doctl config file holding credentials.
+type doctlConfig struct {
+	AccessToken  string                 `yaml:"access-token"`
+	APIURL       string                 `yaml:"api-url"`
+	Context      string                 `yaml:"context"`
+	AuthContexts map[string]interface{} `yaml:"auth-contexts"`
+}
+
+// ResolveCredentials looks up credentials the way doctl does. The token is
+// read from the DIGITALOCEAN_ACCESS_TOKEN or DIGITALOCEAN_TOKEN environment
+// variables if set, otherwise from an auth context of the doctl config file.
+// The "default" context uses the top-level access-token of the file, and
+// other contexts the token of the same name in auth-contexts. A context may
+// also be a mapping with access-token and api-url keys, to use a different
+// API URL than the top-level api-url:
+//
+//	access-token: dop_v1_...
+//	context: staging
+//	auth-contexts:
+//	  production: dop_v1_...
+//	  staging:
+//	    access-token: dop_v1_...
+//	    api-url: https://api.staging.example.com
+//
+// The DIGITALOCEAN_API_URL environment variable overrides the API URL of any
+// source.
+func ResolveCredentials(opts CredentialsOptions) (*Credentials, error) {
+	var tried []string
+
+	for _, env := range []string{EnvAccessToken, EnvToken} {
+		if token := os.Getenv(env); token != "" {
+			return &Credentials{
+				Token:  token,
+				APIURL: os.Getenv(EnvAPIURL),
+				Source: "environment variable " + env,
+			}, nil
+		}
+		tried = append(tried, "environment variable "+env+" (not set)")
+	}
+
+	creds, err := doctlCredentials(opts)
+	if err != nil {
+		tried = append(tried, err.Error())
+		return nil, &CredentialsError{Tried: tried}
+	}
+	if apiURL := os.Getenv(EnvAPIURL); apiURL != "" {
+		creds.APIURL = apiURL
+	}
+	return creds, nil
+}
+
+// doctlCredentials reads credentials from the doctl config file. Its errors
+// describe the file and why no credentials were found in it.
+func doctlCredentials(opts CredentialsOptions) (*Credentials, error) {
+	path := opts.ConfigPath
+	if path == "" {
+		dir, err := os.UserConfigDir()
+		if err != nil {
+			return nil, fmt.Errorf("doctl config (%v)", err)
+		}
+		path = filepath.Join(dir, "doctl", "config.yaml")
+	}
+
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf("doctl config %s (file not found)", path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("doctl config %s (%v)", path, err)
+	}
+
+	var config doctlConfig
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("doctl config %s (invalid YAML: %v)", path, err)
+	}
+
+	name := opts.Context
+	if name == "" {
+		name = os.Getenv(EnvContext)
+	}
+	if name == "" {
+		name = config.Context
+	}
+	if name == "" {
+		name = defaultAuthContext
+	}
+
+	creds := &Credentials{
+		APIURL: config.APIURL,
+		Source: fmt.Sprintf("doctl config %s, context %q", path, name),
+	}
+
+	if name == defaultAuthContext {
+		creds.Token = config.AccessToken
+	} else {
+		switch ctx := config.AuthContexts[name].(type) {
+		case string:
+			creds.Token = ctx
+		case map[interface{}]interface{}:
+			creds.Token, _ = ctx["access-token"].(string)
+			if apiURL, _ := ctx["api-url"].(string); apiURL != "" {
+				creds.APIURL = apiURL
+			}
+		case nil:
+			var names []string
+			for n := range config.AuthContexts {
+				names = append(names, n)
+			}
+			sort.Strings(names)
+			return nil, fmt.Errorf("%s (no such context, available contexts: %s)", creds.Source, strings.Join(append([]string{defaultAuthContext}, names...), ", "))
+		default:
+			return nil, fmt.Errorf("%s (invalid context)", creds.Source)
+		}
+	}
+
+	if creds.Token == "" {
+		return nil, fmt.Errorf("%s (no access token)", creds.Source)
+	}
+	return creds, nil
+}
+
+// NewFromEnvironment returns a new DigitalOcean API client using the
+// credentials found by ResolveCredentials. The options are applied after the
+// API URL of the credentials is set.
+func NewFromEnvironment(opts CredentialsOptions, clientOpts ...ClientOpt) (*Client, error) {
+	creds, err := ResolveCredentials(opts)
+	if err != nil {
+		return nil, err
+	}
+	return NewFromCredentials(creds, clientOpts...)
+}
+
+// NewFromCredentials returns a new DigitalOcean API client with the given
+// credentials.
+func NewFromCredentials(creds *Credentials, opts ...ClientOpt) (*Client, error) {
+	if creds == nil || creds.Token == "" {
+		return nil, NewArgError("creds", "must have a token")
+	}
+
+	ctx := context.Background()
+	config := &oauth2.Config{}
+	ts := config.TokenSource(ctx, &oauth2.Token{AccessToken: creds.Token})
+
+	if creds.APIURL != "" {
+		apiURL := creds.APIURL
+		if !strings.HasSuffix(apiURL, "/") {
+			apiURL += "/"
+		}
+		opts = append([]ClientOpt{SetBaseURL(apiURL)}, opts...)
+	}
+	return New(oauth2.NewClient(ctx, ts), opts...)
+}
@@ -0,0 +1,229 @@
+package godo
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testDoctlConfig = `
+access-token: default-token
+api-url: https://api.example.com
+context: staging
+auth-contexts:
+  production: production-token
+  staging:
+    access-token: staging-token
+    api-url: https://staging.example.com/api
+  empty: ""
+`
+
+// setCredentialsEnv sets the credential environment variables for the
+// duration of the test, unsetting those that are not given. The returned
+// function restores them.
+func setCredentialsEnv(env map[string]string) func() {
+	old := make(map[string]*string)
+	for _, k := range []string{EnvAccessToken, EnvToken, EnvContext, EnvAPIURL} {
+		if v, ok := os.LookupEnv(k); ok {
+			old[k] = &v
+		} else {
+			old[k] = nil
+		}
+		if v, set := env[k]; set {
+			os.Setenv(k, v)
+		} else {
+			os.Unsetenv(k)
+		}
+	}
+
+	return func() {
+		for k, v := range old {
+			if v != nil {
+				os.Setenv(k, *v)
+			} else {
+				os.Unsetenv(k)
+			}
+		}
+	}
+}
+
+// tempDir creates a temporary directory, returning it along with a function
+// removing it.
+func tempDir(t *testing.T) (string, func()) {
+	dir, err := ioutil.TempDir("", "godo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return dir, func() { os.RemoveAll(dir) }
+}
+
+func writeDoctlConfig(t *testing.T, dir, name, content string) string {
+	path := filepath.Join(dir, name)
+	if err := ioutil.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestResolveCredentials(t *testing.T) {
+	dir, remove := tempDir(t)
+	defer remove()
+	config := writeDoctlConfig(t, dir, "config.yaml", testDoctlConfig)
+
+	tests := []struct {
+		name       string
+		env        map[string]string
+		opts       CredentialsOptions
+		wantToken  string
+		wantAPIURL string
+		wantSource string
+	}{
+		{
+			name:       "access token variable",
+			env:        map[string]string{EnvAccessToken: "env-token", EnvToken: "other"},
+			opts:       CredentialsOptions{ConfigPath: config},
+			wantToken:  "env-token",
+			wantSource: "environment variable " + EnvAccessToken,
+		},
+		{
+			name:       "token variable",
+			env:        map[string]string{EnvToken: "env-token", EnvAPIURL: "https://env.example.com"},
+			opts:       CredentialsOptions{ConfigPath: config},
+			wantToken:  "env-token",
+			wantAPIURL: "https://env.example.com",
+			wantSource: "environment variable " + EnvToken,
+		},
+		{
+			name:       "current context",
+			opts:       CredentialsOptions{ConfigPath: config},
+			wantToken:  "staging-token",
+			wantAPIURL: "https://staging.example.com/api",
+			wantSource: `context "staging"`,
+		},
+		{
+			name:       "named context",
+			opts:       CredentialsOptions{ConfigPath: config, Context: "production"},
+			wantToken:  "production-token",
+			wantAPIURL: "https://api.example.com",
+			wantSource: `context "production"`,
+		},
+		{
+			name:       "context variable",
+			env:        map[string]string{EnvContext: "default"},
+			opts:       CredentialsOptions{ConfigPath: config},
+			wantToken:  "default-token",
+			wantAPIURL: "https://api.example.com",
+			wantSource: `context "default"`,
+		},
+		{
+			name:       "API URL variable",
+			env:        map[string]string{EnvAPIURL: "https://env.example.com"},
+			opts:       CredentialsOptions{ConfigPath: config},
+			wantToken:  "staging-token",
+			wantAPIURL: "https://env.example.com",
+			wantSource: `context "staging"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer setCredentialsEnv(tt.env)()
+
+			creds, err := ResolveCredentials(tt.opts)
+			if err != nil {
+				t.Fatalf("ResolveCredentials() error = %v", err)
+			}
+			if creds.Token != tt.wantToken || creds.APIURL != tt.wantAPIURL {
+				t.Errorf("ResolveCredentials() = %+v, expected token %q and API URL %q", creds, tt.wantToken, tt.wantAPIURL)
+			}
+			if !strings.Contains(creds.Source, tt.wantSource) {
+				t.Errorf("source = %q, expected it to contain %q", creds.Source, tt.wantSource)
+			}
+		})
+	}
+}
+
+func TestResolveCredentials_errors(t *testing.T) {
+	dir, remove := tempDir(t)
+	defer remove()
+	config := writeDoctlConfig(t, dir, "config.yaml", testDoctlConfig)
+	missing := filepath.Join(dir, "missing.yaml")
+	invalid := writeDoctlConfig(t, dir, "invalid.yaml", "auth-contexts: [")
+
+	tests := []struct {
+		name string
+		opts CredentialsOptions
+		want string
+	}{
+		{
+			name: "missing file",
+			opts: CredentialsOptions{ConfigPath: missing},
+			want: "doctl config " + missing + " (file not found)",
+		},
+		{
+			name: "invalid file",
+			opts: CredentialsOptions{ConfigPath: invalid},
+			want: "invalid YAML",
+		},
+		{
+			name: "unknown context",
+			opts: CredentialsOptions{ConfigPath: config, Context: "dev"},
+			want: `context "dev" (no such context`,
+		},
+		{
+			name: "empty context",
+			opts: CredentialsOptions{ConfigPath: config, Context: "empty"},
+			want: `context "empty" (no access token)`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer setCredentialsEnv(nil)()
+
+			_, err := ResolveCredentials(tt.opts)
+			var credsErr *CredentialsError
+			if !errors.As(err, &credsErr) {
+				t.Fatalf("error = %v, expected a *CredentialsError", err)
+			}
+			if len(credsErr.Tried) != 3 {
+				t.Errorf("tried = %q, expected 3 sources", credsErr.Tried)
+			}
+			msg := err.Error()
+			for _, s := range []string{EnvAccessToken + " (not set)", EnvToken + " (not set)", tt.want} {
+				if !strings.Contains(msg, s) {
+					t.Errorf("error = %q, expected it to contain %q", msg, s)
+				}
+			}
+		})
+	}
+}
+
+func TestNewFromEnvironment(t *testing.T) {
+	defer setCredentialsEnv(nil)()
+	dir, remove := tempDir(t)
+	defer remove()
+	config := writeDoctlConfig(t, dir, "config.yaml", testDoctlConfig)
+
+	c, err := NewFromEnvironment(CredentialsOptions{ConfigPath: config}, SetUserAgent("test"))
+	if err != nil {
+		t.Fatalf("NewFromEnvironment() error = %v", err)
+	}
+	if got := c.BaseURL.String(); got != "https://staging.example.com/api/" {
+		t.Errorf("BaseURL = %q", got)
+	}
+	if !strings.HasPrefix(c.UserAgent, "test") {
+		t.Errorf("UserAgent = %q, expected options to be applied", c.UserAgent)
+	}
+
+	req, err := c.NewRequest(ctx, "GET", "v2/account", nil)
+	if err != nil {
+		t.Fatalf("NewRequest() error = %v", err)
+	}
+	if got := req.URL.String(); got != "https://staging.example.com/api/v2/account" {
+		t.Errorf("request URL = %q", got)
+	}
+}
@@ -69,6 +69,9 @@ const (
 	SQLAuthPluginCachingSHA2 = "caching_sha2_password"
 )
 
+// DatabaseStatusOnline is the status of a database cluster ready for use.
+const DatabaseStatusOnline = "online"
+
 // Redis eviction policies supported by the managed Redis product.
 const (
 	EvictionPolicyNoEviction     = "noeviction"
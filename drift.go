@@ -0,0 +1,226 @@
+package godo
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// StrictDecodingOptions configures the strict decoding mode enabled by
+// SetStrictDecoding.
+type StrictDecodingOptions struct {
+	// Fail makes Client.Do return a *SchemaDriftError when a response has
+	// fields unknown to godo, instead of only reporting them. The result is
+	// decoded nonetheless.
+	Fail bool
+
+	// OnDrift, if set, is called with the unknown fields of every response
+	// that has some. DriftReport.Record can be used to collect them.
+	OnDrift func(SchemaDrift)
+}
+
+// SchemaDrift describes the fields of an API response unknown to godo.
+type SchemaDrift struct {
+	// Operation is the service method that made the request, as returned by
+	// RequestOperation.
+	Operation string
+
+	Method string
+	Path   string
+
+	// Fields are the paths of the unknown fields in the response, such as
+	// "droplet.gpu_info" or "droplets[].networks.v4[].mtu", sorted.
+	Fields []string
+}
+
+// SchemaDriftError is returned by Client.Do in strict decoding mode when a
+// response has fields unknown to godo.
+type SchemaDriftError struct {
+	Response *http.Response
+	Drift    SchemaDrift
+}
+
+func (e *SchemaDriftError) Error() string {
+	return fmt.Sprintf("godo: response to %s (%s %s) has fields unknown to godo: %s",
+		e.Drift.Operation, e.Drift.Method, e.Drift.Path, strings.Join(e.Drift.Fields, ", "))
+}
+
+// SetStrictDecoding is a client option for detecting the fields of API
+// responses that the types of godo do not have, to learn when godo is behind
+// the API. Unknown fields are reported to opts.OnDrift, and fail requests if
+// opts.Fail is set, as is useful in tests.
+func SetStrictDecoding(opts StrictDecodingOptions) ClientOpt {
+	return func(c *Client) error {
+		c.strict = &opts
+		return nil
+	}
+}
+
+// reportDrift reports the fields of the response to req unknown to v,
+// returning a *SchemaDriftError if the client fails on drift.
+func (c *Client) reportDrift(req *http.Request, resp *http.Response, body []byte, v interface{}) error {
+	drift := checkDrift(req, body, v)
+	if drift == nil {
+		return nil
+	}
+
+	if c.strict.OnDrift != nil {
+		c.strict.OnDrift(*drift)
+	}
+	if c.strict.Fail {
+		return &SchemaDriftError{Response: resp, Drift: *drift}
+	}
+	return nil
+}
+
+// checkDrift returns the drift of the response to req with the given body,
+// decoded into v, or nil if it has no unknown fields.
+func checkDrift(req *http.Request, body []byte, v interface{}) *SchemaDrift {
+	var fields []string
+	unknownFields(body, reflect.TypeOf(v), "", &fields)
+	if len(fields) == 0 {
+		return nil
+	}
+
+	sort.Strings(fields)
+	return &SchemaDrift{
+		Operation: RequestOperation(req).Name,
+		Method:    req.Method,
+		Path:      req.URL.Path,
+		Fields:    dedupe(fields),
+	}
+}
+
+// unknownFields appends to fields the paths of the fields of data that type t
+// does not have.
+func unknownFields(data json.RawMessage, t reflect.Type, path string, fields *[]string) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		var object map[string]json.RawMessage
+		if json.Unmarshal(data, &object) != nil {
+			// Types such as Timestamp are decoded from strings.
+			return
+		}
+
+		known := jsonFields(t)
+		for k, v := range object {
+			ft, ok := known[strings.ToLower(k)]
+			if !ok {
+				*fields = append(*fields, fieldPath(path, k))
+				continue
+			}
+			unknownFields(v, ft, fieldPath(path, k), fields)
+		}
+
+	case reflect.Slice, reflect.Array:
+		var elems []json.RawMessage
+		if json.Unmarshal(data, &elems) != nil {
+			return
+		}
+		for _, e := range elems {
+			unknownFields(e, t.Elem(), path+"[]", fields)
+		}
+
+	case reflect.Map:
+		var object map[string]json.RawMessage
+		if json.Unmarshal(data, &object) != nil {
+			return
+		}
+		for _, v := range object {
+			unknownFields(v, t.Elem(), fieldPath(path, "*"), fields)
+		}
+	}
+}
+
+func fieldPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+func dedupe(sorted []string) []string {
+	out := sorted[:0]
+	for i, s := range sorted {
+		if i == 0 || s != sorted[i-1] {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+// DriftReport collects the schema drift reported in strict decoding mode,
+// per endpoint. It is safe for concurrent use.
+//
+//	report := godo.NewDriftReport()
+//	client, err := godo.New(httpClient, godo.SetStrictDecoding(godo.StrictDecodingOptions{
+//		OnDrift: report.Record,
+//	}))
+type DriftReport struct {
+	mu sync.Mutex
+
+	// fields holds the set of unknown fields of each operation.
+	fields map[string]map[string]bool
+}
+
+// NewDriftReport returns an empty DriftReport.
+func NewDriftReport() *DriftReport {
+	return &DriftReport{fields: make(map[string]map[string]bool)}
+}
+
+// Record adds drift to the report.
+func (r *DriftReport) Record(drift SchemaDrift) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	fields, ok := r.fields[drift.Operation]
+	if !ok {
+		fields = make(map[string]bool)
+		r.fields[drift.Operation] = fields
+	}
+	for _, f := range drift.Fields {
+		fields[f] = true
+	}
+}
+
+// Endpoints returns the sorted unknown fields of each operation, such as
+// "Droplets.List".
+func (r *DriftReport) Endpoints() map[string][]string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	endpoints := make(map[string][]string, len(r.fields))
+	for op, set := range r.fields {
+		fields := make([]string, 0, len(set))
+		for f := range set {
+			fields = append(fields, f)
+		}
+		sort.Strings(fields)
+		endpoints[op] = fields
+	}
+	return endpoints
+}
+
+// String formats the report with a line per operation.
+func (r *DriftReport) String() string {
+	endpoints := r.Endpoints()
+	ops := make([]string, 0, len(endpoints))
+	for op := range endpoints {
+		ops = append(ops, op)
+	}
+	sort.Strings(ops)
+
+	var b strings.Builder
+	for _, op := range ops {
+		fmt.Fprintf(&b, "%s: %s\n", op, strings.Join(endpoints[op], ", "))
+	}
+	return b.String()
+}
@@ -0,0 +1,108 @@
+package godo
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestStrictDecoding_report(t *testing.T) {
+	setup()
+	defer teardown()
+
+	report := NewDriftReport()
+	SetStrictDecoding(StrictDecodingOptions{OnDrift: report.Record})(client)
+
+	mux.HandleFunc("/v2/droplets", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{
+			"droplets": [
+				{"id": 1, "gpu_info": {}, "networks": {"v4": [{"ip_address": "10.0.0.1", "mtu": 1500}]}},
+				{"id": 2, "gpu_info": {}, "created_at": "2020-01-01T00:00:00Z"}
+			],
+			"meta": {"total": 2, "next_cursor": "abc"}
+		}`)
+	})
+	mux.HandleFunc("/v2/droplets/1", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"droplet": {"id": 1, "name": "web", "region": {"slug": "nyc3"}}}`)
+	})
+
+	droplets, _, err := client.Droplets.List(ctx, nil)
+	if err != nil {
+		t.Fatalf("Droplets.List() error = %v", err)
+	}
+	if len(droplets) != 2 {
+		t.Errorf("Droplets.List() = %+v", droplets)
+	}
+	if _, _, err := client.Droplets.Get(ctx, 1); err != nil {
+		t.Fatalf("Droplets.Get() error = %v", err)
+	}
+
+	expected := map[string][]string{
+		"Droplets.List": {"droplets[].gpu_info", "droplets[].networks.v4[].mtu", "meta.next_cursor"},
+	}
+	if got := report.Endpoints(); !reflect.DeepEqual(got, expected) {
+		t.Errorf("Endpoints() = %v, expected %v", got, expected)
+	}
+
+	want := "Droplets.List: droplets[].gpu_info, droplets[].networks.v4[].mtu, meta.next_cursor\n"
+	if got := report.String(); got != want {
+		t.Errorf("String() = %q, expected %q", got, want)
+	}
+}
+
+func TestStrictDecoding_fail(t *testing.T) {
+	setup()
+	defer teardown()
+
+	SetStrictDecoding(StrictDecodingOptions{Fail: true})(client)
+
+	mux.HandleFunc("/v2/kubernetes/clusters/k8s", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"kubernetes_cluster": {"id": "k8s", "surge_upgrade": true, "node_pools": [{"id": "pool", "labels": {"a": "b"}, "taints": []}]}}`)
+	})
+
+	cluster, resp, err := client.Kubernetes.Get(ctx, "k8s")
+
+	var driftErr *SchemaDriftError
+	if !errors.As(err, &driftErr) {
+		t.Fatalf("error = %v, expected a *SchemaDriftError", err)
+	}
+	expected := SchemaDrift{
+		Operation: "Kubernetes.Get",
+		Method:    http.MethodGet,
+		Path:      "/v2/kubernetes/clusters/k8s",
+		Fields:    []string{"kubernetes_cluster.node_pools[].taints", "kubernetes_cluster.surge_upgrade"},
+	}
+	if !reflect.DeepEqual(driftErr.Drift, expected) {
+		t.Errorf("drift = %+v, expected %+v", driftErr.Drift, expected)
+	}
+	if resp == nil || resp.StatusCode != http.StatusOK {
+		t.Errorf("response = %v, expected the response to be returned", resp)
+	}
+	if cluster != nil {
+		t.Errorf("cluster = %+v, expected none on error", cluster)
+	}
+}
+
+func TestStrictDecoding_noDrift(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var drifts []SchemaDrift
+	SetStrictDecoding(StrictDecodingOptions{
+		Fail:    true,
+		OnDrift: func(d SchemaDrift) { drifts = append(drifts, d) },
+	})(client)
+
+	mux.HandleFunc("/v2/account", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"account": {"droplet_limit": 25, "EMAIL": "a@example.com", "status": "active"}}`)
+	})
+
+	if _, _, err := client.Account.Get(ctx); err != nil {
+		t.Errorf("Account.Get() error = %v", err)
+	}
+	if len(drifts) != 0 {
+		t.Errorf("drifts = %+v, expected none", drifts)
+	}
+}
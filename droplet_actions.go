@@ -7,6 +7,11 @@ import (
 	"net/url"
 )
 
+const (
+	dropletActionsPath      = dropletBasePath + "/%d/actions"
+	dropletActionsByTagPath = dropletBasePath + "/actions"
+)
+
 // ActionRequest reprents DigitalOcean Action Request
 type ActionRequest map[string]interface{}
 
@@ -321,9 +326,9 @@ func (s *DropletActionsServiceOp) get(ctx context.Context, path string) (*Action
 }
 
 func dropletActionPath(dropletID int) string {
-	return fmt.Sprintf("v2/droplets/%d/actions", dropletID)
+	return fmt.Sprintf(dropletActionsPath, dropletID)
 }
 
 func dropletActionPathByTag(tag string) string {
-	return fmt.Sprintf("v2/droplets/actions?tag_name=%s", tag)
+	return fmt.Sprintf("%s?tag_name=%s", dropletActionsByTagPath, tag)
 }
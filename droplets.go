@@ -61,6 +61,19 @@ type Droplet struct {
 	Tags             []string      `json:"tags,omitempty"`
 	VolumeIDs        []string      `json:"volume_ids"`
 	VPCUUID          string        `json:"vpc_uuid,omitempty"`
+
+	// Extra holds the fields returned by the API that Droplet does not
+	// have yet.
+	Extra ExtraFields `json:"-"`
+}
+
+// UnmarshalJSON decodes a Droplet, keeping the fields it does not have in
+// Extra.
+func (d *Droplet) UnmarshalJSON(data []byte) error {
+	type droplet Droplet
+	extra, err := unmarshalWithExtra(data, (*droplet)(d))
+	d.Extra = extra
+	return err
 }
 
 // PublicIPv4 returns the public IPv4 address for the Droplet.
@@ -469,7 +482,7 @@ func (s *DropletsServiceOp) Actions(ctx context.Context, dropletID int, opt *Lis
 		return nil, nil, NewArgError("dropletID", "cannot be less than 1")
 	}
 
-	path := fmt.Sprintf("%s/%d/actions", dropletBasePath, dropletID)
+	path := fmt.Sprintf(dropletActionsPath, dropletID)
 	path, err := addOptions(path, opt)
 	if err != nil {
 		return nil, nil, err
@@ -0,0 +1,278 @@
+package godo
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DryRunOptions configures the dry-run mode enabled by SetDryRun.
+type DryRunOptions struct {
+	// Logger, if set, logs every intercepted request at info level.
+	Logger Logger
+
+	// OnRequest, if set, is called with every intercepted request, for
+	// instance to build the plan of a deployment.
+	OnRequest func(PlannedRequest)
+
+	// RedactKeys lists JSON keys whose values are redacted in the bodies of
+	// planned requests, in addition to the keys of known secrets.
+	RedactKeys []string
+}
+
+// PlannedRequest is a mutating request intercepted in dry-run mode.
+type PlannedRequest struct {
+	// Operation is the service method issuing the request, as returned by
+	// RequestOperation.
+	Operation Operation
+
+	Method string
+	Path   string
+
+	// Body is the JSON body of the request, with secrets redacted.
+	Body string
+}
+
+// dryRun intercepts the mutating requests of a client in dry-run mode.
+type dryRun struct {
+	opts     DryRunOptions
+	redactor *Redactor
+}
+
+// SetDryRun is a client option for a dry-run mode, where GET requests are
+// sent to the API while POST, PUT, PATCH and DELETE requests are not. Those
+// are reported to opts.Logger and opts.OnRequest instead, and answered with a
+// plausible result synthesized from the request: a created resource echoes
+// the fields of its create request, and an action is returned as completed.
+// Synthesized resources have no ID.
+func SetDryRun(opts DryRunOptions) ClientOpt {
+	return func(c *Client) error {
+		c.dryRun = &dryRun{opts: opts, redactor: NewRedactor(opts.RedactKeys...)}
+		return nil
+	}
+}
+
+// intercepts returns whether req is not to be sent in dry-run mode.
+func (d *dryRun) intercepts(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// do reports req and decodes a synthesized result into v.
+func (d *dryRun) do(req *http.Request, v interface{}) (*Response, error) {
+	var body []byte
+	if req.Body != nil {
+		data, err := ioutil.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		body = data
+		req.Body = ioutil.NopCloser(bytes.NewReader(data))
+	}
+
+	planned := PlannedRequest{
+		Operation: RequestOperation(req),
+		Method:    req.Method,
+		Path:      req.URL.RequestURI(),
+		Body:      redactBody(req.URL.Path, body, d.redactor),
+	}
+	if d.opts.Logger != nil {
+		d.opts.Logger.Info("godo dry run",
+			"operation", planned.Operation.Name,
+			"method", planned.Method,
+			"path", planned.Path,
+			"body", planned.Body,
+		)
+	}
+	if d.opts.OnRequest != nil {
+		d.opts.OnRequest(planned)
+	}
+
+	status := http.StatusOK
+	switch req.Method {
+	case http.MethodPost:
+		status = http.StatusCreated
+	case http.MethodDelete:
+		status = http.StatusNoContent
+	}
+	resp := &http.Response{
+		Status:     strconv.Itoa(status) + " " + http.StatusText(status),
+		StatusCode: status,
+		Proto:      "HTTP/1.1",
+		ProtoMajor: 1,
+		ProtoMinor: 1,
+		Header:     http.Header{},
+		Body:       http.NoBody,
+		Request:    req,
+	}
+
+	if v != nil && req.Method != http.MethodDelete {
+		fillDryRunResult(v, synthesize(req, body))
+	}
+	return newResponse(resp), nil
+}
+
+// synthesize returns the fields of the resource resulting from req: a
+// completed action for requests to action endpoints, and the fields of the
+// request body for others.
+func synthesize(req *http.Request, body []byte) map[string]interface{} {
+	fields := make(map[string]interface{})
+	json.Unmarshal(body, &fields)
+
+	now := time.Now().UTC().Format(time.RFC3339)
+	segments := strings.Split(strings.Trim(req.URL.Path, "/"), "/")
+	if n := len(segments); n >= 2 && segments[n-1] == "actions" {
+		action := map[string]interface{}{
+			"status":       "completed",
+			"type":         fields["type"],
+			"started_at":   now,
+			"completed_at": now,
+			"region_slug":  fields["region"],
+		}
+		switch {
+		case n >= 4:
+			action["resource_type"] = strings.TrimSuffix(segments[n-3], "s")
+			if id, err := strconv.Atoi(segments[n-2]); err == nil {
+				action["resource_id"] = id
+			}
+		case n == 3:
+			// Actions on all the resources of a tag, such as
+			// v2/droplets/actions?tag_name=web.
+			action["resource_type"] = strings.TrimSuffix(segments[n-2], "s")
+		}
+		return action
+	}
+
+	if req.Method == http.MethodPost {
+		fields["created_at"] = now
+	}
+	return fields
+}
+
+// fillDryRunResult sets the fields of the result v decoded by a service
+// method from fields. Results decoded into a root struct, such as
+// dropletRoot, are filled in its first field holding a resource. Fields that
+// do not decode into the result are skipped, except strings and numbers set
+// to resources with a slug or ID, such as the region of a DropletCreateRequest.
+func fillDryRunResult(v interface{}, fields map[string]interface{}) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.Elem().Kind() != reflect.Struct {
+		return
+	}
+	rv = rv.Elem()
+
+	if !strings.HasSuffix(strings.ToLower(rv.Type().Name()), "root") {
+		fillStruct(rv, fields)
+		return
+	}
+
+	for i := 0; i < rv.NumField(); i++ {
+		name := jsonName(rv.Type().Field(i))
+		if name == "" || name == "links" || name == "meta" {
+			continue
+		}
+
+		f := rv.Field(i)
+		switch f.Kind() {
+		case reflect.Ptr:
+			if f.Type().Elem().Kind() != reflect.Struct {
+				continue
+			}
+			f.Set(reflect.New(f.Type().Elem()))
+			fillStruct(f.Elem(), fields)
+		case reflect.Struct:
+			fillStruct(f, fields)
+		case reflect.Slice:
+			// Requests creating several resources, such as
+			// DropletMultiCreateRequest, list their names. Others, such as
+			// actions on all the Droplets of a tag, result in one element.
+			elems := []map[string]interface{}{fields}
+			if names, ok := fields["names"].([]interface{}); ok {
+				elems = make([]map[string]interface{}, len(names))
+				for i, name := range names {
+					single := make(map[string]interface{}, len(fields))
+					for k, v := range fields {
+						single[k] = v
+					}
+					delete(single, "names")
+					single["name"] = name
+					elems[i] = single
+				}
+			}
+
+			s := reflect.MakeSlice(f.Type(), 0, len(elems))
+			for _, e := range elems {
+				elem := reflect.New(f.Type().Elem()).Elem()
+				if elem.Kind() == reflect.Struct {
+					fillStruct(elem, e)
+				}
+				s = reflect.Append(s, elem)
+			}
+			f.Set(s)
+		default:
+			continue
+		}
+		return
+	}
+}
+
+func fillStruct(rv reflect.Value, fields map[string]interface{}) {
+	for i := 0; i < rv.NumField(); i++ {
+		field := rv.Type().Field(i)
+		value, ok := fields[jsonName(field)]
+		if !ok || value == nil || field.PkgPath != "" {
+			continue
+		}
+
+		data, err := json.Marshal(value)
+		if err != nil {
+			continue
+		}
+		f := rv.Field(i)
+		target := reflect.New(f.Type())
+		if err := json.Unmarshal(data, target.Interface()); err == nil {
+			f.Set(target.Elem())
+			continue
+		}
+
+		// A slug or ID referencing a resource, such as a region or an image.
+		var ref map[string]interface{}
+		switch value.(type) {
+		case string:
+			ref = map[string]interface{}{"slug": value}
+		case float64:
+			ref = map[string]interface{}{"id": value}
+		default:
+			continue
+		}
+		if f.Kind() == reflect.Ptr && f.Type().Elem().Kind() == reflect.Struct {
+			target := reflect.New(f.Type().Elem())
+			fillStruct(target.Elem(), ref)
+			f.Set(target)
+		} else if f.Kind() == reflect.Struct {
+			fillStruct(f, ref)
+		}
+	}
+}
+
+// jsonName returns the JSON key of a struct field, or "" if it is not
+// encoded.
+func jsonName(f reflect.StructField) string {
+	tag := f.Tag.Get("json")
+	if tag == "-" {
+		return ""
+	}
+	if name := strings.Split(tag, ",")[0]; name != "" {
+		return name
+	}
+	return f.Name
+}
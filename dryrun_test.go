@@ -0,0 +1,186 @@
+package godo
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestDryRun_interceptsMutatingRequests(t *testing.T) {
+	setup()
+	defer teardown()
+
+	logger := &testLogger{}
+	var planned []PlannedRequest
+	SetDryRun(DryRunOptions{
+		Logger:    logger,
+		OnRequest: func(r PlannedRequest) { planned = append(planned, r) },
+	})(client)
+
+	mux.HandleFunc("/v2/droplets/12345", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Errorf("unexpected %s request sent in dry-run mode", r.Method)
+		}
+		fmt.Fprint(w, `{"droplet": {"id": 12345, "name": "web"}}`)
+	})
+
+	d, _, err := client.Droplets.Get(ctx, 12345)
+	if err != nil || d.Name != "web" {
+		t.Fatalf("Droplets.Get() = %+v, %v", d, err)
+	}
+
+	resp, err := client.Droplets.Delete(ctx, 12345)
+	if err != nil {
+		t.Fatalf("Droplets.Delete() error = %v", err)
+	}
+	if resp.StatusCode != http.StatusNoContent {
+		t.Errorf("Droplets.Delete() status = %d, expected %d", resp.StatusCode, http.StatusNoContent)
+	}
+
+	if len(planned) != 1 {
+		t.Fatalf("planned %d requests, expected 1", len(planned))
+	}
+	if p := planned[0]; p.Operation.Name != "Droplets.Delete" || p.Method != http.MethodDelete || p.Path != "/v2/droplets/12345" {
+		t.Errorf("planned request = %+v", p)
+	}
+
+	entry := logger.find("info", "godo dry run")
+	if entry == nil {
+		t.Fatal("dry-run request not logged")
+	}
+	if entry.attrs["operation"] != "Droplets.Delete" {
+		t.Errorf("logged operation = %v, expected Droplets.Delete", entry.attrs["operation"])
+	}
+}
+
+func TestDryRun_createDroplet(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var planned []PlannedRequest
+	SetDryRun(DryRunOptions{OnRequest: func(r PlannedRequest) { planned = append(planned, r) }})(client)
+
+	d, resp, err := client.Droplets.Create(ctx, &DropletCreateRequest{
+		Name:     "web",
+		Region:   "nyc3",
+		Size:     "s-1vcpu-1gb",
+		Image:    DropletCreateImage{Slug: "ubuntu-20-04-x64"},
+		Tags:     []string{"frontend"},
+		UserData: "secret script",
+	})
+	if err != nil {
+		t.Fatalf("Droplets.Create() error = %v", err)
+	}
+	if resp.StatusCode != http.StatusCreated {
+		t.Errorf("status = %d, expected %d", resp.StatusCode, http.StatusCreated)
+	}
+
+	if d.Name != "web" || d.Region == nil || d.Region.Slug != "nyc3" || d.Size == nil || d.Size.Slug != "s-1vcpu-1gb" ||
+		d.Image == nil || d.Image.Slug != "ubuntu-20-04-x64" || len(d.Tags) != 1 || d.Tags[0] != "frontend" || d.Created == "" {
+		t.Errorf("synthesized droplet = %+v", d)
+	}
+
+	if len(planned) != 1 || planned[0].Operation.Name != "Droplets.Create" {
+		t.Fatalf("planned = %+v", planned)
+	}
+	if body := planned[0].Body; !strings.Contains(body, `"name":"web"`) || strings.Contains(body, "secret script") {
+		t.Errorf("planned body = %s", body)
+	}
+}
+
+func TestDryRun_createMultipleDroplets(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var planned []PlannedRequest
+	SetDryRun(DryRunOptions{OnRequest: func(r PlannedRequest) { planned = append(planned, r) }})(client)
+
+	droplets, _, err := client.Droplets.CreateMultiple(ctx, &DropletMultiCreateRequest{
+		Names:  []string{"web-1", "web-2"},
+		Region: "nyc3",
+		Size:   "s-1vcpu-1gb",
+		Image:  DropletCreateImage{ID: 42},
+	})
+	if err != nil {
+		t.Fatalf("Droplets.CreateMultiple() error = %v", err)
+	}
+	if len(droplets) != 2 || droplets[0].Name != "web-1" || droplets[1].Name != "web-2" {
+		t.Fatalf("synthesized droplets = %+v", droplets)
+	}
+	if img := droplets[1].Image; img == nil || img.ID != 42 {
+		t.Errorf("synthesized image = %+v", img)
+	}
+	if len(planned) != 1 || planned[0].Operation.Name != "Droplets.CreateMultiple" {
+		t.Errorf("planned = %+v", planned)
+	}
+}
+
+func TestDryRun_action(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var planned []PlannedRequest
+	SetDryRun(DryRunOptions{OnRequest: func(r PlannedRequest) { planned = append(planned, r) }})(client)
+
+	action, _, err := client.DropletActions.Resize(ctx, 12345, "s-2vcpu-2gb", true)
+	if err != nil {
+		t.Fatalf("DropletActions.Resize() error = %v", err)
+	}
+
+	if action.Status != ActionCompleted || action.Type != "resize" || action.ResourceID != 12345 ||
+		action.ResourceType != "droplet" || action.StartedAt == nil || action.CompletedAt == nil {
+		t.Errorf("synthesized action = %+v", action)
+	}
+	if len(planned) != 1 || planned[0].Operation.Name != "DropletActions.Resize" {
+		t.Errorf("planned = %+v", planned)
+	}
+}
+
+func TestDryRun_actionByTag(t *testing.T) {
+	setup()
+	defer teardown()
+
+	SetDryRun(DryRunOptions{})(client)
+
+	actions, _, err := client.DropletActions.PowerOffByTag(ctx, "frontend")
+	if err != nil {
+		t.Fatalf("DropletActions.PowerOffByTag() error = %v", err)
+	}
+	if len(actions) != 1 {
+		t.Fatalf("synthesized actions = %+v, expected one", actions)
+	}
+	if a := actions[0]; a.Status != ActionCompleted || a.Type != "power_off" || a.ResourceType != "droplet" || a.CompletedAt == nil {
+		t.Errorf("synthesized action = %+v", a)
+	}
+
+	actions, _, err = client.DropletActions.SnapshotByTag(ctx, "frontend", "nightly")
+	if err != nil {
+		t.Fatalf("DropletActions.SnapshotByTag() error = %v", err)
+	}
+	if len(actions) != 1 || actions[0].Type != "snapshot" || actions[0].Status != ActionCompleted {
+		t.Errorf("synthesized actions = %+v", actions)
+	}
+}
+
+func TestDryRun_update(t *testing.T) {
+	setup()
+	defer teardown()
+
+	SetDryRun(DryRunOptions{})(client)
+
+	lb, resp, err := client.LoadBalancers.Update(ctx, "lb-id", &LoadBalancerRequest{
+		Name:       "lb",
+		Region:     "nyc3",
+		DropletIDs: []int{1, 2},
+	})
+	if err != nil {
+		t.Fatalf("LoadBalancers.Update() error = %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("status = %d, expected %d", resp.StatusCode, http.StatusOK)
+	}
+	if lb.Name != "lb" || lb.Region == nil || lb.Region.Slug != "nyc3" || len(lb.DropletIDs) != 2 {
+		t.Errorf("synthesized load balancer = %+v", lb)
+	}
+}
@@ -1,6 +1,42 @@
 package godo
 
-import "fmt"
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// Sentinel errors matched by an *ErrorResponse with errors.Is according to the
+// status code of the API response.
+var (
+	// ErrUnauthorized matches 401 Unauthorized responses.
+	ErrUnauthorized = errors.New("unauthorized")
+
+	// ErrForbidden matches 403 Forbidden responses.
+	ErrForbidden = errors.New("forbidden")
+
+	// ErrNotFound matches 404 Not Found responses.
+	ErrNotFound = errors.New("not found")
+
+	// ErrConflict matches 409 Conflict responses.
+	ErrConflict = errors.New("conflict")
+
+	// ErrUnprocessableEntity matches 422 Unprocessable Entity responses.
+	ErrUnprocessableEntity = errors.New("unprocessable entity")
+
+	// ErrRateLimited matches 429 Too Many Requests responses. Use
+	// ErrorResponse.RetryAfter to learn when the request may be retried.
+	ErrRateLimited = errors.New("rate limited")
+
+	// ErrServerError matches 5xx responses.
+	ErrServerError = errors.New("server error")
+)
 
 // ArgError is an error that represents an error with an input to godo. It
 // identifies the argument and the cause (if possible).
@@ -22,3 +58,138 @@ func NewArgError(arg, reason string) *ArgError {
 func (e *ArgError) Error() string {
 	return fmt.Sprintf("%s is invalid because %s", e.arg, e.reason)
 }
+
+// DecodeError is returned when the body of a successful API response cannot
+// be decoded.
+type DecodeError struct {
+	// HTTP response whose body could not be decoded
+	Response *http.Response
+
+	// Err is the underlying decoding error.
+	Err error
+}
+
+var _ error = &DecodeError{}
+
+func (e *DecodeError) Error() string {
+	if e.Response != nil && e.Response.Request != nil {
+		return fmt.Sprintf("%v %v: decoding response: %v", e.Response.Request.Method, e.Response.Request.URL, e.Err)
+	}
+	return fmt.Sprintf("decoding response: %v", e.Err)
+}
+
+// Unwrap returns the underlying decoding error.
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether the status code of the response matches one of the
+// sentinel errors, such as ErrNotFound.
+func (r *ErrorResponse) Is(target error) bool {
+	if r.Response == nil {
+		return false
+	}
+
+	switch code := r.Response.StatusCode; target {
+	case ErrUnauthorized:
+		return code == http.StatusUnauthorized
+	case ErrForbidden:
+		return code == http.StatusForbidden
+	case ErrNotFound:
+		return code == http.StatusNotFound
+	case ErrConflict:
+		return code == http.StatusConflict
+	case ErrUnprocessableEntity:
+		return code == http.StatusUnprocessableEntity
+	case ErrRateLimited:
+		return code == http.StatusTooManyRequests
+	case ErrServerError:
+		return code >= 500
+	}
+	return false
+}
+
+// RetryAfter returns how long to wait before retrying a rate limited request,
+// as indicated by the Retry-After or RateLimit-Reset headers of the response.
+// It returns zero if the response carries neither.
+func (r *ErrorResponse) RetryAfter() time.Duration {
+	if r.Response == nil {
+		return 0
+	}
+
+	if after := r.Response.Header.Get("Retry-After"); after != "" {
+		if secs, err := strconv.Atoi(after); err == nil && secs > 0 {
+			return time.Duration(secs) * time.Second
+		}
+		if t, err := http.ParseTime(after); err == nil {
+			if d := time.Until(t); d > 0 {
+				return d
+			}
+		}
+	}
+
+	if reset := newResponse(r.Response).Rate.Reset; !reset.IsZero() {
+		if d := time.Until(reset.Time); d > 0 {
+			return d
+		}
+	}
+	return 0
+}
+
+// IsRetryable reports whether a request that failed with err may succeed if
+// sent again. Rate limited and 5xx API errors, as well as timeouts and
+// connection errors, are retryable. Other API errors, other transport errors,
+// context cancellation, decoding errors and argument errors are not.
+func IsRetryable(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	var errResp *ErrorResponse
+	if errors.As(err, &errResp) {
+		return errResp.Response != nil && isRetryableStatus(errResp.Response.StatusCode)
+	}
+
+	var decodeErr *DecodeError
+	var argErr *ArgError
+	if errors.As(err, &decodeErr) || errors.As(err, &argErr) {
+		return false
+	}
+
+	return isRetryableTransportError(err)
+}
+
+func isRetryableStatus(code int) bool {
+	return code == http.StatusTooManyRequests || code >= 500
+}
+
+// isRetryableTransportError reports whether err, returned by an http.Client,
+// is a timeout or a failure to connect to, read from or write to the API.
+// Errors that sending the request again cannot fix, such as an unsupported
+// URL scheme, a certificate that cannot be verified or a redirect refused by
+// the client's CheckRedirect, are not retryable.
+func isRetryableTransportError(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	// *url.Error implements net.Error whatever its cause, so look past it.
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		err = urlErr.Err
+	}
+
+	if netErr, ok := err.(net.Error); ok && netErr.Timeout() {
+		return true
+	}
+	var opErr *net.OpError
+	if errors.As(err, &opErr) {
+		switch opErr.Op {
+		case "dial", "read", "write", "proxyconnect":
+			return true
+		}
+		return false
+	}
+	// The connection was closed before the response was received.
+	return err == io.EOF || err == io.ErrUnexpectedEOF
+}
@@ -1,6 +1,21 @@
 package godo
 
-import "testing"
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+)
 
 func TestArgError(t *testing.T) {
 	expected := "foo is invalid because bar"
@@ -9,3 +24,156 @@ func TestArgError(t *testing.T) {
 		t.Errorf("ArgError().Error() = %q; expected %q", got, expected)
 	}
 }
+
+func TestErrorResponse_Is(t *testing.T) {
+	sentinels := []error{
+		ErrUnauthorized,
+		ErrForbidden,
+		ErrNotFound,
+		ErrConflict,
+		ErrUnprocessableEntity,
+		ErrRateLimited,
+		ErrServerError,
+	}
+
+	cases := []struct {
+		status   int
+		expected error
+	}{
+		{http.StatusBadRequest, nil},
+		{http.StatusUnauthorized, ErrUnauthorized},
+		{http.StatusForbidden, ErrForbidden},
+		{http.StatusNotFound, ErrNotFound},
+		{http.StatusConflict, ErrConflict},
+		{http.StatusUnprocessableEntity, ErrUnprocessableEntity},
+		{http.StatusTooManyRequests, ErrRateLimited},
+		{http.StatusInternalServerError, ErrServerError},
+		{http.StatusServiceUnavailable, ErrServerError},
+	}
+
+	for _, c := range cases {
+		err := fmt.Errorf("wrapped: %w", &ErrorResponse{Response: &http.Response{StatusCode: c.status}})
+		for _, sentinel := range sentinels {
+			if got, expected := errors.Is(err, sentinel), sentinel == c.expected; got != expected {
+				t.Errorf("errors.Is(%d, %v) = %v, expected %v", c.status, sentinel, got, expected)
+			}
+		}
+	}
+}
+
+func TestCheckResponse_classified(t *testing.T) {
+	res := &http.Response{
+		Request:    &http.Request{},
+		StatusCode: http.StatusNotFound,
+		Body: ioutil.NopCloser(strings.NewReader(
+			`{"id":"not_found","message":"The resource you were accessing could not be found.","request_id":"r"}`)),
+	}
+	err := CheckResponse(res)
+
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("errors.Is(%v, ErrNotFound) = false, expected true", err)
+	}
+
+	var errResp *ErrorResponse
+	if !errors.As(err, &errResp) {
+		t.Fatalf("errors.As(%v, *ErrorResponse) = false, expected true", err)
+	}
+	if errResp.ID != "not_found" {
+		t.Errorf("ErrorResponse.ID = %q, expected %q", errResp.ID, "not_found")
+	}
+	if errResp.RequestID != "r" {
+		t.Errorf("ErrorResponse.RequestID = %q, expected %q", errResp.RequestID, "r")
+	}
+}
+
+func TestErrorResponse_RetryAfter(t *testing.T) {
+	header := func(k, v string) http.Header {
+		h := http.Header{}
+		h.Set(k, v)
+		return h
+	}
+
+	reset := strconv.FormatInt(time.Now().Add(time.Minute).Unix(), 10)
+	cases := []struct {
+		name   string
+		header http.Header
+		min    time.Duration
+		max    time.Duration
+	}{
+		{"no headers", http.Header{}, 0, 0},
+		{"retry after seconds", header("Retry-After", "30"), 30 * time.Second, 30 * time.Second},
+		{"rate limit reset", header(headerRateReset, reset), 58 * time.Second, time.Minute},
+	}
+
+	for _, c := range cases {
+		err := &ErrorResponse{Response: &http.Response{StatusCode: http.StatusTooManyRequests, Header: c.header}}
+		if got := err.RetryAfter(); got < c.min || got > c.max {
+			t.Errorf("%s: RetryAfter() = %v, expected between %v and %v", c.name, got, c.min, c.max)
+		}
+	}
+}
+
+func TestIsRetryable(t *testing.T) {
+	errResp := func(status int) error {
+		return &ErrorResponse{Response: &http.Response{StatusCode: status}}
+	}
+
+	cases := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{"nil", nil, false},
+		{"not found", errResp(http.StatusNotFound), false},
+		{"unprocessable", errResp(http.StatusUnprocessableEntity), false},
+		{"rate limited", errResp(http.StatusTooManyRequests), true},
+		{"server error", fmt.Errorf("wrapped: %w", errResp(http.StatusBadGateway)), true},
+		{"decode error", &DecodeError{Err: errors.New("unexpected EOF")}, false},
+		{"arg error", NewArgError("id", "cannot be less than 1"), false},
+		{"connection reset", &url.Error{Op: "Get", URL: "/", Err: &net.OpError{Op: "read", Net: "tcp", Err: syscall.ECONNRESET}}, true},
+		{"connection refused", &url.Error{Op: "Get", URL: "/", Err: &net.OpError{Op: "dial", Net: "tcp", Err: syscall.ECONNREFUSED}}, true},
+		{"connection closed", &url.Error{Op: "Get", URL: "/", Err: io.EOF}, true},
+		{"timeout", &url.Error{Op: "Get", URL: "/", Err: timeoutError{}}, true},
+		{"unsupported scheme", &url.Error{Op: "Get", URL: "ftp://example.com", Err: errors.New(`unsupported protocol scheme "ftp"`)}, false},
+		{"invalid URL", &url.Error{Op: "parse", URL: "http://[::1", Err: errors.New("missing ']' in host")}, false},
+		{"unknown authority", &url.Error{Op: "Get", URL: "/", Err: x509.UnknownAuthorityError{}}, false},
+		{"hostname mismatch", &url.Error{Op: "Get", URL: "/", Err: x509.HostnameError{Host: "example.com"}}, false},
+		{"TLS alert", &url.Error{Op: "Get", URL: "/", Err: &net.OpError{Op: "remote error", Err: errors.New("tls: bad certificate")}}, false},
+		{"redirect refused", &url.Error{Op: "Get", URL: "/", Err: errors.New("stopped after 10 redirects")}, false},
+		{"canceled", &url.Error{Op: "Get", URL: "/", Err: context.Canceled}, false},
+		{"deadline", context.DeadlineExceeded, false},
+	}
+
+	for _, c := range cases {
+		if got := IsRetryable(c.err); got != c.expected {
+			t.Errorf("%s: IsRetryable() = %v, expected %v", c.name, got, c.expected)
+		}
+	}
+}
+
+// timeoutError is a net.Error reporting a timeout.
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "i/o timeout" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return true }
+
+func TestDo_decodeError(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"A":`)
+	})
+
+	req, _ := client.NewRequest(ctx, http.MethodGet, "/", nil)
+	_, err := client.Do(ctx, req, new(struct{ A string }))
+
+	var decodeErr *DecodeError
+	if !errors.As(err, &decodeErr) {
+		t.Fatalf("Do() error = %#v, expected *DecodeError", err)
+	}
+	if decodeErr.Response == nil || decodeErr.Response.StatusCode != http.StatusOK {
+		t.Errorf("DecodeError.Response = %v, expected the 200 response", decodeErr.Response)
+	}
+}
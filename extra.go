@@ -0,0 +1,82 @@
+package godo
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// ExtraFields holds the fields of an API object that its godo type does not
+// have, keyed by JSON name, so that fields added to the API can be used before
+// godo supports them.
+type ExtraFields map[string]json.RawMessage
+
+// Decode decodes the field named key into v. It reports whether the field is
+// present.
+func (e ExtraFields) Decode(key string, v interface{}) (bool, error) {
+	data, ok := e[key]
+	if !ok {
+		return false, nil
+	}
+	return true, json.Unmarshal(data, v)
+}
+
+// knownFields caches the fields of struct types, as returned by jsonFields.
+var knownFields sync.Map
+
+// unmarshalWithExtra decodes data into v, a pointer to a struct, and returns
+// the fields of data that v does not have, or nil if there are none.
+func unmarshalWithExtra(data []byte, v interface{}) (ExtraFields, error) {
+	if err := json.Unmarshal(data, v); err != nil {
+		return nil, err
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		// null or not an object: there are no fields.
+		return nil, nil
+	}
+
+	known := jsonFields(reflect.TypeOf(v).Elem())
+	var extra ExtraFields
+	for k, raw := range fields {
+		// Like encoding/json, match field names case-insensitively.
+		if _, ok := known[strings.ToLower(k)]; ok {
+			continue
+		}
+		if extra == nil {
+			extra = make(ExtraFields)
+		}
+		extra[k] = raw
+	}
+	return extra, nil
+}
+
+// jsonFields returns the types of the fields of the struct type t, keyed by
+// lowercased JSON name. The fields of embedded structs are included.
+func jsonFields(t reflect.Type) map[string]reflect.Type {
+	if known, ok := knownFields.Load(t); ok {
+		return known.(map[string]reflect.Type)
+	}
+
+	known := make(map[string]reflect.Type)
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.Anonymous && f.Type.Kind() == reflect.Struct && f.Tag.Get("json") == "" {
+			for name, ft := range jsonFields(f.Type) {
+				known[name] = ft
+			}
+			continue
+		}
+		if f.PkgPath != "" {
+			continue
+		}
+		if name := jsonName(f); name != "" {
+			known[strings.ToLower(name)] = f.Type
+		}
+	}
+
+	knownFields.Store(t, known)
+	return known
+}
@@ -0,0 +1,147 @@
+package godo
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestDroplet_extraFields(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v2/droplets/12345", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"droplet": {"id": 12345, "Name": "web", "gpu_info": {"count": 1}, "ipv7": true}}`)
+	})
+
+	d, _, err := client.Droplets.Get(ctx, 12345)
+	if err != nil {
+		t.Fatalf("Droplets.Get() error = %v", err)
+	}
+	if d.ID != 12345 || d.Name != "web" {
+		t.Errorf("droplet = %+v", d)
+	}
+	if len(d.Extra) != 2 {
+		t.Fatalf("Extra = %v, expected gpu_info and ipv7", d.Extra)
+	}
+
+	var gpu struct {
+		Count int `json:"count"`
+	}
+	if ok, err := d.Extra.Decode("gpu_info", &gpu); !ok || err != nil || gpu.Count != 1 {
+		t.Errorf("Decode(gpu_info) = %v, %v, %+v", ok, err, gpu)
+	}
+	if ok, _ := d.Extra.Decode("missing", &gpu); ok {
+		t.Error("Decode(missing) reported the field as present")
+	}
+}
+
+func TestExtraFields_onlyUnknownFields(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		v    json.Unmarshaler
+
+		// noExtra is set if data has no unknown field.
+		noExtra bool
+	}{
+		{
+			name:    "droplet",
+			data:    `{"id": 1, "volume_ids": [], "networks": {"v4": []}}`,
+			v:       &Droplet{},
+			noExtra: true,
+		},
+		{
+			name: "load balancer",
+			data: `{"id": "lb", "size": "lb-small", "enable_backend_keepalive": true}`,
+			v:    &LoadBalancer{},
+		},
+		{
+			name: "kubernetes cluster",
+			data: `{"id": "k8s", "status": {"state": "running"}, "surge_upgrade": true}`,
+			v:    &KubernetesCluster{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := json.Unmarshal([]byte(tt.data), tt.v); err != nil {
+				t.Fatalf("Unmarshal() error = %v", err)
+			}
+
+			var extra ExtraFields
+			switch v := tt.v.(type) {
+			case *Droplet:
+				extra = v.Extra
+			case *LoadBalancer:
+				extra = v.Extra
+				if !v.EnableBackendKeepalive {
+					t.Error("known field not decoded")
+				}
+			case *KubernetesCluster:
+				extra = v.Extra
+				if v.Status == nil || v.Status.State != KubernetesClusterStatusRunning {
+					t.Error("known field not decoded")
+				}
+			}
+
+			if tt.noExtra {
+				if extra != nil {
+					t.Errorf("Extra = %v, expected none", extra)
+				}
+				return
+			}
+			if len(extra) != 1 {
+				t.Errorf("Extra = %v, expected a single field", extra)
+			}
+		})
+	}
+}
+
+func TestExtraFields_null(t *testing.T) {
+	d := &Droplet{Name: "web"}
+	if err := json.Unmarshal([]byte(`null`), d); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if d.Name != "web" || d.Extra != nil {
+		t.Errorf("droplet = %+v", d)
+	}
+}
+
+func TestDroplet_StringWithoutExtra(t *testing.T) {
+	d := Droplet{ID: 1, Name: "web"}
+	if got, expected := d.String(), `godo.Droplet{ID:1, Name:"web", Memory:0, Vcpus:0, Disk:0, SizeSlug:"", Locked:false, Status:"", Created:"", VPCUUID:""}`; got != expected {
+		t.Errorf("String() = %s, expected %s", got, expected)
+	}
+}
+
+func TestSetRetainRawBody(t *testing.T) {
+	setup()
+	defer teardown()
+
+	body := `{"droplet": {"id": 12345, "gpu_info": {"count": 1}}}`
+	mux.HandleFunc("/v2/droplets/12345", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, body)
+	})
+
+	_, resp, err := client.Droplets.Get(ctx, 12345)
+	if err != nil {
+		t.Fatalf("Droplets.Get() error = %v", err)
+	}
+	if resp.RawBody != nil {
+		t.Errorf("RawBody = %s, expected none by default", resp.RawBody)
+	}
+
+	SetRetainRawBody(true)(client)
+	d, resp, err := client.Droplets.Get(ctx, 12345)
+	if err != nil {
+		t.Fatalf("Droplets.Get() error = %v", err)
+	}
+	if string(resp.RawBody) != body {
+		t.Errorf("RawBody = %s, expected %s", resp.RawBody, body)
+	}
+	if d.ID != 12345 {
+		t.Errorf("droplet = %+v, expected the body to be decoded too", d)
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"net/url"
 	"reflect"
 	"strconv"
+	"sync"
 	"time"
 
 	"github.com/google/go-querystring/query"
@@ -40,7 +41,8 @@ type Client struct {
 	UserAgent string
 
 	// Rate contains the current rate limit for the client as determined by the most recent
-	// API call.
+	// API call. It is not safe to read while other goroutines make requests, use
+	// GetRate instead.
 	Rate Rate
 
 	// Services used for communicating with the API
@@ -75,11 +77,47 @@ type Client struct {
 
 	// Optional function called after every successful request made to the DO APIs
 	onRequestCompleted RequestCompletionCallback
+
+	// Optional retry policy applied to every request made to the DO APIs
+	retryPolicy *RetryPolicy
+
+	// Optional limiter pacing requests according to the API rate limit
+	rateLimiter *RateLimiter
+
+	// Middleware wrapping every request made to the DO APIs
+	middleware []Middleware
+
+	// Optional sink receiving metrics about every request made to the DO APIs
+	metrics MetricsSink
+
+	// Optional tracer starting a span for every request made to the DO APIs
+	tracer Tracer
+
+	// Optional dry-run mode intercepting mutating requests
+	dryRun *dryRun
+
+	// Optional cache of the responses to GET requests
+	cache *responseCache
+
+	// Whether to retain the bodies of responses in Response.RawBody
+	retainRawBody bool
+
+	// Optional strict decoding mode reporting unknown response fields
+	strict *StrictDecodingOptions
+
+	// Guards Rate and the rate observers
+	ratemtx       sync.Mutex
+	rateObservers map[int]RateObserver
+	nextObserver  int
 }
 
 // RequestCompletionCallback defines the type of the request callback function
 type RequestCompletionCallback func(*http.Request, *http.Response)
 
+// RateObserver defines the type of the function called with the rate limit
+// returned by each API response
+type RateObserver func(Rate)
+
 // ListOptions specifies the optional parameters to various List methods that
 // support pagination.
 type ListOptions struct {
@@ -104,6 +142,14 @@ type Response struct {
 	// Monitoring URI
 	Monitor string
 
+	// Attempts is the number of times the request was sent before this
+	// response was received. It is greater than 1 when the request was retried.
+	Attempts int
+
+	// RawBody is the body of the response, retained when the client was
+	// created with SetRetainRawBody.
+	RawBody []byte
+
 	Rate
 }
 
@@ -117,6 +163,10 @@ type ErrorResponse struct {
 
 	// RequestID returned from the API, useful to contact support.
 	RequestID string `json:"request_id"`
+
+	// ID is a short machine readable identifier of the error, such as
+	// "not_found" or "unprocessable_entity".
+	ID string `json:"id"`
 }
 
 // Rate contains the rate limit for the current client.
@@ -243,6 +293,16 @@ func SetBaseURL(bu string) ClientOpt {
 	}
 }
 
+// SetRetainRawBody is a client option for retaining the body of every
+// response in Response.RawBody, including the fields that the types of godo
+// do not decode.
+func SetRetainRawBody(retain bool) ClientOpt {
+	return func(c *Client) error {
+		c.retainRawBody = retain
+		return nil
+	}
+}
+
 // SetUserAgent is a client option for setting the user agent.
 func SetUserAgent(ua string) ClientOpt {
 	return func(c *Client) error {
@@ -253,9 +313,14 @@ func SetUserAgent(ua string) ClientOpt {
 
 // NewRequest creates an API request. A relative URL can be provided in urlStr, which will be resolved to the
 // BaseURL of the Client. Relative URLS should always be specified without a preceding slash. If specified, the
-// value pointed to by body is JSON encoded and included in as the request body.
+// value pointed to by body is JSON encoded and included in as the request body. The base URL and headers set
+// on ctx with WithRequestOptions are applied.
 func (c *Client) NewRequest(ctx context.Context, method, urlStr string, body interface{}) (*http.Request, error) {
-	u, err := c.BaseURL.Parse(urlStr)
+	base, err := c.baseURL(ctx)
+	if err != nil {
+		return nil, err
+	}
+	u, err := base.Parse(urlStr)
 	if err != nil {
 		return nil, err
 	}
@@ -276,14 +341,70 @@ func (c *Client) NewRequest(ctx context.Context, method, urlStr string, body int
 	req.Header.Add("Content-Type", mediaType)
 	req.Header.Add("Accept", mediaType)
 	req.Header.Add("User-Agent", c.UserAgent)
+	if o := requestOptionsFrom(ctx); o != nil {
+		for k, v := range o.header {
+			req.Header[k] = append([]string(nil), v...)
+		}
+	}
 	return req, nil
 }
 
-// OnRequestCompleted sets the DO API request completion callback
+// OnRequestCompleted sets the DO API request completion callback. It is called once per request made with Do, with
+// the response of its last attempt, whether sent to the API or served from the cache. It replaces any callback set
+// previously; use Client.Use with AfterResponse to register several, or to be called for every attempt.
 func (c *Client) OnRequestCompleted(rc RequestCompletionCallback) {
 	c.onRequestCompleted = rc
 }
 
+// GetRate returns the current rate limit for the client as determined by the
+// most recent API call. It is safe to call concurrently with requests.
+func (c *Client) GetRate() Rate {
+	c.ratemtx.Lock()
+	defer c.ratemtx.Unlock()
+	return c.Rate
+}
+
+// OnRateChange registers an observer called with the rate limit returned by
+// every API response, including retried attempts. It returns a function that
+// removes the observer. Observers are called synchronously from the goroutine
+// making the request and must not block.
+func (c *Client) OnRateChange(o RateObserver) (remove func()) {
+	c.ratemtx.Lock()
+	defer c.ratemtx.Unlock()
+
+	if c.rateObservers == nil {
+		c.rateObservers = make(map[int]RateObserver)
+	}
+	id := c.nextObserver
+	c.nextObserver++
+	c.rateObservers[id] = o
+
+	return func() {
+		c.ratemtx.Lock()
+		defer c.ratemtx.Unlock()
+		delete(c.rateObservers, id)
+	}
+}
+
+// updateRate records the rate limit returned with the latest response and
+// notifies the rate limiter and observers.
+func (c *Client) updateRate(r Rate) {
+	c.ratemtx.Lock()
+	c.Rate = r
+	observers := make([]RateObserver, 0, len(c.rateObservers))
+	for _, o := range c.rateObservers {
+		observers = append(observers, o)
+	}
+	c.ratemtx.Unlock()
+
+	if c.rateLimiter != nil {
+		c.rateLimiter.Update(r)
+	}
+	for _, o := range observers {
+		o(r)
+	}
+}
+
 // newResponse creates a new Response for the provided http.Response
 func newResponse(r *http.Response) *Response {
 	response := Response{Response: r}
@@ -309,9 +430,33 @@ func (r *Response) populateRate() {
 
 // Do sends an API request and returns the API response. The API response is JSON decoded and stored in the value
 // pointed to by v, or returned as an error if an API error has occurred. If v implements the io.Writer interface,
-// the raw response will be written to v, without attempting to decode it.
+// the raw response will be written to v, without attempting to decode it. The timeout set on ctx with
+// WithRequestOptions bounds the request.
 func (c *Client) Do(ctx context.Context, req *http.Request, v interface{}) (*Response, error) {
-	resp, err := DoRequestWithClient(ctx, c.client, req)
+	if o := requestOptionsFrom(ctx); o != nil && o.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, o.timeout)
+		defer cancel()
+	}
+
+	if c.tracer == nil {
+		return c.do(ctx, req, v)
+	}
+
+	ctx, span := c.startSpan(ctx, req)
+	response, err := c.do(ctx, req.WithContext(ctx), v)
+	finishSpan(span, response, err)
+	return response, err
+}
+
+func (c *Client) do(ctx context.Context, req *http.Request, v interface{}) (*Response, error) {
+	if c.dryRun != nil && c.dryRun.intercepts(req) {
+		return c.dryRun.do(req, v)
+	}
+
+	start := time.Now()
+	resp, attempts, err := c.send(ctx, req)
+	c.observeRequest(ctx, req, resp, attempts, time.Since(start), err)
 	if err != nil {
 		return nil, err
 	}
@@ -326,7 +471,22 @@ func (c *Client) Do(ctx context.Context, req *http.Request, v interface{}) (*Res
 	}()
 
 	response := newResponse(resp)
-	c.Rate = response.Rate
+	response.Attempts = attempts
+
+	var raw []byte
+	if c.retainRawBody || c.strict != nil {
+		body := resp.Body
+		raw, err = ioutil.ReadAll(body)
+		body.Close()
+		if err != nil {
+			return response, err
+		}
+		resp.Body = ioutil.NopCloser(bytes.NewReader(raw))
+
+		if c.retainRawBody {
+			response.RawBody = raw
+		}
+	}
 
 	err = CheckResponse(resp)
 	if err != nil {
@@ -342,7 +502,13 @@ func (c *Client) Do(ctx context.Context, req *http.Request, v interface{}) (*Res
 		} else {
 			err = json.NewDecoder(resp.Body).Decode(v)
 			if err != nil {
-				return nil, err
+				return nil, &DecodeError{Response: resp, Err: err}
+			}
+
+			if c.strict != nil {
+				if err := c.reportDrift(req, resp, raw, v); err != nil {
+					return response, err
+				}
 			}
 		}
 	}
@@ -376,6 +542,7 @@ func (r *ErrorResponse) Error() string {
 // CheckResponse checks the API response for errors, and returns them if present. A response is considered an
 // error if it has a status code outside the 200 range. API error responses are expected to have either no response
 // body, or a JSON response body that maps to ErrorResponse. Any other response body will be silently ignored.
+// The returned *ErrorResponse can be classified with errors.Is and sentinel errors such as ErrNotFound.
 func CheckResponse(r *http.Response) error {
 	if c := r.StatusCode; c >= 200 && c <= 299 {
 		return nil
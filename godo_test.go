@@ -9,7 +9,9 @@ import (
 	"net/http/httputil"
 	"net/url"
 	"reflect"
+	"strconv"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 )
@@ -401,6 +403,68 @@ func TestDo_rateLimit_errorResponse(t *testing.T) {
 	}
 }
 
+func TestGetRate_concurrent(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add(headerRateLimit, "60")
+		w.Header().Add(headerRateRemaining, "59")
+		w.Header().Add(headerRateReset, "1372700873")
+	})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			req, _ := client.NewRequest(ctx, http.MethodGet, "/", nil)
+			if _, err := client.Do(ctx, req, nil); err != nil {
+				t.Errorf("Do(): %v", err)
+			}
+			_ = client.GetRate()
+		}()
+	}
+	wg.Wait()
+
+	if expected := 59; client.GetRate().Remaining != expected {
+		t.Errorf("Client rate remaining = %v, expected %v", client.GetRate().Remaining, expected)
+	}
+}
+
+func TestOnRateChange(t *testing.T) {
+	setup()
+	defer teardown()
+
+	remaining := 60
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		remaining--
+		w.Header().Add(headerRateLimit, "60")
+		w.Header().Add(headerRateRemaining, strconv.Itoa(remaining))
+	})
+
+	var first, second []int
+	removeFirst := client.OnRateChange(func(r Rate) { first = append(first, r.Remaining) })
+	client.OnRateChange(func(r Rate) { second = append(second, r.Remaining) })
+
+	for i := 0; i < 3; i++ {
+		if i == 2 {
+			removeFirst()
+		}
+		req, _ := client.NewRequest(ctx, http.MethodGet, "/", nil)
+		if _, err := client.Do(ctx, req, nil); err != nil {
+			t.Fatalf("Do(): %v", err)
+		}
+	}
+
+	if expected := []int{59, 58}; !reflect.DeepEqual(first, expected) {
+		t.Errorf("first observer = %v, expected %v", first, expected)
+	}
+	if expected := []int{59, 58, 57}; !reflect.DeepEqual(second, expected) {
+		t.Errorf("second observer = %v, expected %v", second, expected)
+	}
+}
+
 func checkCurrentPage(t *testing.T, resp *Response, expectedPage int) {
 	links := resp.Links
 	p, err := links.CurrentPage()
@@ -453,6 +517,39 @@ func TestDo_completion_callback(t *testing.T) {
 	}
 }
 
+func TestDo_completionCallbackOncePerDo(t *testing.T) {
+	setup()
+	defer teardown()
+
+	SetRetryPolicy(RetryPolicy{MaxAttempts: 3, WaitMin: time.Millisecond, WaitMax: time.Millisecond})(client)
+
+	var attempts int
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		fmt.Fprint(w, `{"A":"a"}`)
+	})
+
+	var completed []int
+	client.OnRequestCompleted(func(req *http.Request, resp *http.Response) {
+		completed = append(completed, resp.StatusCode)
+	})
+
+	req, _ := client.NewRequest(ctx, http.MethodGet, "/", nil)
+	if _, err := client.Do(ctx, req, nil); err != nil {
+		t.Fatalf("Do(): %v", err)
+	}
+	if attempts != 2 {
+		t.Errorf("sent %d attempts, expected 2", attempts)
+	}
+	if len(completed) != 1 || completed[0] != http.StatusOK {
+		t.Errorf("completion callback called with %v, expected once with %d", completed, http.StatusOK)
+	}
+}
+
 func TestAddOptions(t *testing.T) {
 	cases := []struct {
 		name     string
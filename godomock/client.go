@@ -0,0 +1,15 @@
+package godomock
+
+// AssertExpectations reports an error to t for each expectation of the
+// mocks that was not met. It returns whether all expectations were met.
+func (m *Mocks) AssertExpectations(t TestingT) bool {
+	t.Helper()
+
+	ok := true
+	for _, mock := range m.all() {
+		if !mock.AssertExpectations(t) {
+			ok = false
+		}
+	}
+	return ok
+}
@@ -0,0 +1,306 @@
+// Command mockgen generates the mocks of the godomock package from the
+// service interfaces of the godo package.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"log"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+func main() {
+	src := flag.String("src", "..", "directory of the godo package")
+	out := flag.String("out", "mocks.go", "output file")
+	flag.Parse()
+
+	code, err := generate(*src)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := ioutil.WriteFile(*out, code, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// service is a service field of godo.Client.
+type service struct {
+	field string
+	iface string
+	decl  *ast.InterfaceType
+	file  *ast.File
+}
+
+type generator struct {
+	buf     bytes.Buffer
+	types   map[string]bool
+	imports map[string]string
+}
+
+func generate(dir string) ([]byte, error) {
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, dir, func(fi os.FileInfo) bool {
+		return !strings.HasSuffix(fi.Name(), "_test.go")
+	}, 0)
+	if err != nil {
+		return nil, err
+	}
+	pkg, ok := pkgs["godo"]
+	if !ok {
+		return nil, fmt.Errorf("no godo package in %s", dir)
+	}
+
+	g := &generator{types: make(map[string]bool), imports: make(map[string]string)}
+
+	var files []string
+	for name := range pkg.Files {
+		files = append(files, name)
+	}
+	sort.Strings(files)
+
+	// Collect the exported types and the service interfaces.
+	interfaces := make(map[string]service)
+	var client *ast.StructType
+	for _, name := range files {
+		f := pkg.Files[name]
+		for _, decl := range f.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range gen.Specs {
+				ts := spec.(*ast.TypeSpec)
+				g.types[ts.Name.Name] = true
+				switch t := ts.Type.(type) {
+				case *ast.InterfaceType:
+					interfaces[ts.Name.Name] = service{iface: ts.Name.Name, decl: t, file: f}
+				case *ast.StructType:
+					if ts.Name.Name == "Client" {
+						client = t
+					}
+				}
+			}
+		}
+	}
+	if client == nil {
+		return nil, fmt.Errorf("no Client type in %s", dir)
+	}
+
+	// The services are the exported interface fields of Client, in order.
+	var services []service
+	for _, field := range client.Fields.List {
+		ident, ok := field.Type.(*ast.Ident)
+		if !ok || len(field.Names) == 0 || !field.Names[0].IsExported() {
+			continue
+		}
+		s, ok := interfaces[ident.Name]
+		if !ok {
+			continue
+		}
+		s.field = field.Names[0].Name
+		services = append(services, s)
+	}
+
+	var body bytes.Buffer
+	for _, s := range services {
+		if err := g.mock(&body, s); err != nil {
+			return nil, err
+		}
+	}
+	g.mocks(&body, services)
+
+	g.buf.WriteString("// Code generated by mockgen from the godo service interfaces. DO NOT EDIT.\n\n")
+	g.buf.WriteString("package godomock\n\n")
+	g.buf.WriteString("import (\n")
+	var paths []string
+	for _, path := range g.imports {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	for _, path := range paths {
+		fmt.Fprintf(&g.buf, "\t%s\n", path)
+	}
+	g.buf.WriteString("\n\t\"github.com/digitalocean/godo\"\n")
+	g.buf.WriteString(")\n\n")
+	g.buf.Write(body.Bytes())
+
+	return format.Source(g.buf.Bytes())
+}
+
+// mock writes the mock of a service interface.
+func (g *generator) mock(w *bytes.Buffer, s service) error {
+	fmt.Fprintf(w, "// %s is a mock of godo.%s.\n", s.iface, s.iface)
+	fmt.Fprintf(w, "type %s struct {\n\tMock\n}\n\n", s.iface)
+	fmt.Fprintf(w, "var _ godo.%s = (*%s)(nil)\n\n", s.iface, s.iface)
+
+	for _, m := range s.decl.Methods.List {
+		ft, ok := m.Type.(*ast.FuncType)
+		if !ok || len(m.Names) == 0 {
+			return fmt.Errorf("%s: embedded interfaces are not supported", s.iface)
+		}
+		name := m.Names[0].Name
+
+		var params, args []string
+		n := 0
+		for _, p := range ft.Params.List {
+			typ, err := g.expr(p.Type, s.file)
+			if err != nil {
+				return err
+			}
+			count := len(p.Names)
+			if count == 0 {
+				count = 1
+			}
+			for i := 0; i < count; i++ {
+				arg := "a" + strconv.Itoa(n)
+				n++
+				params = append(params, arg+" "+typ)
+				args = append(args, arg)
+			}
+		}
+
+		var results []string
+		if ft.Results != nil {
+			for _, r := range ft.Results.List {
+				typ, err := g.expr(r.Type, s.file)
+				if err != nil {
+					return err
+				}
+				count := len(r.Names)
+				if count == 0 {
+					count = 1
+				}
+				for i := 0; i < count; i++ {
+					results = append(results, typ)
+				}
+			}
+		}
+
+		fmt.Fprintf(w, "// %s mocks godo.%s.%s.\n", name, s.iface, name)
+		fmt.Fprintf(w, "func (m *%s) %s(%s) ", s.iface, name, strings.Join(params, ", "))
+		if len(results) > 0 {
+			fmt.Fprintf(w, "(%s) ", strings.Join(results, ", "))
+		}
+		w.WriteString("{\n")
+
+		callArgs := strings.Join(append([]string{strconv.Quote(name)}, args...), ", ")
+		if len(results) == 0 {
+			fmt.Fprintf(w, "\tm.Called(%s)\n}\n\n", callArgs)
+			continue
+		}
+
+		fmt.Fprintf(w, "\tret := m.Called(%s)\n", callArgs)
+		var rets []string
+		for i, typ := range results {
+			r := "r" + strconv.Itoa(i)
+			if typ == "error" {
+				fmt.Fprintf(w, "\t%s := ret.Error(%d)\n", r, i)
+			} else {
+				fmt.Fprintf(w, "\t%s, _ := ret.Get(%d).(%s)\n", r, i, typ)
+			}
+			rets = append(rets, r)
+		}
+		fmt.Fprintf(w, "\treturn %s\n}\n\n", strings.Join(rets, ", "))
+	}
+	return nil
+}
+
+// mocks writes the Mocks type and NewClient.
+func (g *generator) mocks(w *bytes.Buffer, services []service) {
+	w.WriteString("// Mocks holds the mocks of the services of a client built by NewClient.\n")
+	w.WriteString("type Mocks struct {\n")
+	for _, s := range services {
+		fmt.Fprintf(w, "\t%s *%s\n", s.field, s.iface)
+	}
+	w.WriteString("}\n\n")
+
+	w.WriteString("// NewClient returns a client whose services are all mocks, along with the\n")
+	w.WriteString("// mocks.\n")
+	w.WriteString("func NewClient() (*godo.Client, *Mocks) {\n")
+	w.WriteString("\tm := &Mocks{\n")
+	for _, s := range services {
+		fmt.Fprintf(w, "\t\t%s: &%s{},\n", s.field, s.iface)
+	}
+	w.WriteString("\t}\n\n")
+	w.WriteString("\tc := godo.NewClient(nil)\n")
+	for _, s := range services {
+		fmt.Fprintf(w, "\tc.%s = m.%s\n", s.field, s.field)
+	}
+	w.WriteString("\treturn c, m\n}\n\n")
+
+	w.WriteString("// all returns the mocks of all services.\n")
+	w.WriteString("func (m *Mocks) all() []*Mock {\n")
+	w.WriteString("\treturn []*Mock{\n")
+	for _, s := range services {
+		fmt.Fprintf(w, "\t\t&m.%s.Mock,\n", s.field)
+	}
+	w.WriteString("\t}\n}\n")
+}
+
+// expr returns the source of a type expression of file f, qualifying the
+// types of the godo package and recording the imports it needs.
+func (g *generator) expr(e ast.Expr, f *ast.File) (string, error) {
+	switch t := e.(type) {
+	case *ast.Ident:
+		if g.types[t.Name] {
+			return "godo." + t.Name, nil
+		}
+		return t.Name, nil
+	case *ast.StarExpr:
+		s, err := g.expr(t.X, f)
+		return "*" + s, err
+	case *ast.ArrayType:
+		s, err := g.expr(t.Elt, f)
+		return "[]" + s, err
+	case *ast.Ellipsis:
+		s, err := g.expr(t.Elt, f)
+		return "..." + s, err
+	case *ast.MapType:
+		k, err := g.expr(t.Key, f)
+		if err != nil {
+			return "", err
+		}
+		v, err := g.expr(t.Value, f)
+		return "map[" + k + "]" + v, err
+	case *ast.InterfaceType:
+		if len(t.Methods.List) > 0 {
+			return "", fmt.Errorf("unsupported inline interface")
+		}
+		return "interface{}", nil
+	case *ast.SelectorExpr:
+		pkg, ok := t.X.(*ast.Ident)
+		if !ok {
+			return "", fmt.Errorf("unsupported selector %T", t.X)
+		}
+		path, err := importPath(f, pkg.Name)
+		if err != nil {
+			return "", err
+		}
+		g.imports[pkg.Name] = path
+		return pkg.Name + "." + t.Sel.Name, nil
+	}
+	return "", fmt.Errorf("unsupported type expression %T", e)
+}
+
+// importPath returns the quoted import path of the package named name in f.
+func importPath(f *ast.File, name string) (string, error) {
+	for _, imp := range f.Imports {
+		path, _ := strconv.Unquote(imp.Path.Value)
+		if imp.Name != nil && imp.Name.Name == name {
+			return imp.Path.Value, nil
+		}
+		if path == name || strings.HasSuffix(path, "/"+name) {
+			return imp.Path.Value, nil
+		}
+	}
+	return "", fmt.Errorf("no import for package %s", name)
+}
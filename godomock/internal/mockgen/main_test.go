@@ -0,0 +1,22 @@
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"testing"
+)
+
+func TestGenerate_upToDate(t *testing.T) {
+	code, err := generate("../../..")
+	if err != nil {
+		t.Fatalf("generate(): %v", err)
+	}
+
+	existing, err := ioutil.ReadFile("../../mocks.go")
+	if err != nil {
+		t.Fatalf("ReadFile(): %v", err)
+	}
+	if !bytes.Equal(code, existing) {
+		t.Error("godomock/mocks.go is out of date, run go generate ./godomock")
+	}
+}
@@ -0,0 +1,256 @@
+// Package godomock provides programmable mocks of the godo service
+// interfaces, for testing code using godo without an API server.
+//
+// Each mock records the calls made to it and answers them with the values
+// programmed with On:
+//
+//	client, mocks := godomock.NewClient()
+//	mocks.Droplets.On("Get", godomock.Anything, 123).Return(&godo.Droplet{ID: 123}, nil, nil)
+//
+//	d, _, err := client.Droplets.Get(ctx, 123)
+//
+//	mocks.AssertExpectations(t)
+//
+// The mocks are generated from the interfaces of the godo package by
+// go generate, which keeps them in sync with it.
+//
+// The mocks are not built on github.com/stretchr/testify/mock, which godo only
+// uses in its own tests: testify/mock depends on github.com/stretchr/objx,
+// which godo pins past v0.1.1 with a replace directive that does not apply to
+// the modules importing godo. A small matching engine keeps godomock free of
+// dependencies.
+package godomock
+
+//go:generate go run ./internal/mockgen -src .. -out mocks.go
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// Anything matches any argument in an expectation.
+const Anything = "godomock.Anything"
+
+// Matcher matches an argument in an expectation with a function. Functions of
+// the unnamed type func(interface{}) bool are matchers too, without a
+// conversion to Matcher.
+type Matcher func(arg interface{}) bool
+
+// TestingT is the subset of testing.TB used to report unmet expectations.
+type TestingT interface {
+	Helper()
+	Errorf(format string, args ...interface{})
+}
+
+// Mock records the calls made to a mocked service and answers them with the
+// values of the matching expectation. It is embedded in every generated mock
+// and is safe for concurrent use.
+type Mock struct {
+	mu           sync.Mutex
+	expectations []*Call
+	calls        []Call
+}
+
+// Call is an expected call, or a call made to a mock.
+type Call struct {
+	// Method is the name of the method called.
+	Method string
+
+	// Args are the arguments of the call. Variadic arguments are passed as
+	// a single slice.
+	Args []interface{}
+
+	mock    *Mock
+	anyArgs bool
+	returns []interface{}
+	fn      func(args []interface{}) []interface{}
+	times   int
+	calls   int
+}
+
+// On adds an expectation for calls to method with the given arguments, and
+// returns it so that its return values can be programmed. Arguments are
+// compared with reflect.DeepEqual, unless they are Anything or a Matcher, such
+// as func(arg interface{}) bool { ... }.
+// Without arguments, the expectation matches calls with any arguments.
+//
+// Expectations are matched in the order they were added, skipping those
+// that were called as many times as set with Times.
+func (m *Mock) On(method string, args ...interface{}) *Call {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	c := &Call{Method: method, Args: args, mock: m, anyArgs: len(args) == 0}
+	m.expectations = append(m.expectations, c)
+	return c
+}
+
+// Return sets the values returned by the call, one per result of the method.
+// Missing values are returned as zero values.
+func (c *Call) Return(values ...interface{}) *Call {
+	c.mock.mu.Lock()
+	defer c.mock.mu.Unlock()
+
+	c.returns = values
+	return c
+}
+
+// ReturnFunc sets a function computing the values returned by the call from
+// its arguments.
+func (c *Call) ReturnFunc(fn func(args []interface{}) []interface{}) *Call {
+	c.mock.mu.Lock()
+	defer c.mock.mu.Unlock()
+
+	c.fn = fn
+	return c
+}
+
+// Times limits the expectation to n calls. By default an expectation matches
+// any number of calls.
+func (c *Call) Times(n int) *Call {
+	c.mock.mu.Lock()
+	defer c.mock.mu.Unlock()
+
+	c.times = n
+	return c
+}
+
+// Once limits the expectation to a single call.
+func (c *Call) Once() *Call {
+	return c.Times(1)
+}
+
+func (c *Call) matches(method string, args []interface{}) bool {
+	if c.Method != method {
+		return false
+	}
+	if c.times > 0 && c.calls >= c.times {
+		return false
+	}
+	if c.anyArgs {
+		return true
+	}
+	if len(c.Args) != len(args) {
+		return false
+	}
+
+	for i, expected := range c.Args {
+		switch e := expected.(type) {
+		case Matcher:
+			if !e(args[i]) {
+				return false
+			}
+		case func(interface{}) bool:
+			if !e(args[i]) {
+				return false
+			}
+		case string:
+			if e != Anything && !reflect.DeepEqual(e, args[i]) {
+				return false
+			}
+		default:
+			if !reflect.DeepEqual(expected, args[i]) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Called records a call to method and returns the values of the matching
+// expectation. It panics if no expectation matches, so that unexpected calls
+// fail the test. It is called by the generated mocks.
+func (m *Mock) Called(method string, args ...interface{}) Results {
+	m.mu.Lock()
+	m.calls = append(m.calls, Call{Method: method, Args: args})
+
+	var match *Call
+	for _, c := range m.expectations {
+		if c.matches(method, args) {
+			match = c
+			break
+		}
+	}
+	if match == nil {
+		m.mu.Unlock()
+		panic(fmt.Sprintf("godomock: unexpected call %s(%s)", method, formatArgs(args)))
+	}
+
+	match.calls++
+	returns, fn := match.returns, match.fn
+	m.mu.Unlock()
+
+	if fn != nil {
+		returns = fn(args)
+	}
+	return Results(returns)
+}
+
+// Calls returns the calls made to method, in order.
+func (m *Mock) Calls(method string) []Call {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var calls []Call
+	for _, c := range m.calls {
+		if c.Method == method {
+			calls = append(calls, c)
+		}
+	}
+	return calls
+}
+
+// CallCount returns the number of calls made to method.
+func (m *Mock) CallCount(method string) int {
+	return len(m.Calls(method))
+}
+
+// AssertExpectations reports an error to t for each expectation that was not
+// called, or called fewer times than set with Times. It returns whether all
+// expectations were met.
+func (m *Mock) AssertExpectations(t TestingT) bool {
+	t.Helper()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	ok := true
+	for _, c := range m.expectations {
+		switch {
+		case c.calls == 0:
+			t.Errorf("godomock: expected call %s(%s) was not made", c.Method, formatArgs(c.Args))
+			ok = false
+		case c.times > 0 && c.calls < c.times:
+			t.Errorf("godomock: expected call %s(%s) was made %d times, expected %d", c.Method, formatArgs(c.Args), c.calls, c.times)
+			ok = false
+		}
+	}
+	return ok
+}
+
+// Results are the values returned by an expectation.
+type Results []interface{}
+
+// Get returns the i-th value, or nil if there is none.
+func (r Results) Get(i int) interface{} {
+	if i >= len(r) {
+		return nil
+	}
+	return r[i]
+}
+
+// Error returns the i-th value as an error.
+func (r Results) Error(i int) error {
+	err, _ := r.Get(i).(error)
+	return err
+}
+
+func formatArgs(args []interface{}) string {
+	s := make([]string, len(args))
+	for i, a := range args {
+		s[i] = fmt.Sprintf("%#v", a)
+	}
+	return strings.Join(s, ", ")
+}
@@ -0,0 +1,144 @@
+package godomock
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/digitalocean/godo"
+)
+
+var ctx = context.TODO()
+
+type recordingT struct {
+	errors []string
+}
+
+func (t *recordingT) Helper() {}
+
+func (t *recordingT) Errorf(format string, args ...interface{}) {
+	t.errors = append(t.errors, fmt.Sprintf(format, args...))
+}
+
+func TestNewClient(t *testing.T) {
+	client, mocks := NewClient()
+
+	mocks.Droplets.On("Get", Anything, 123).Return(&godo.Droplet{ID: 123, Name: "web"}, &godo.Response{}, nil)
+	mocks.Droplets.On("Get", Anything, Anything).Return(nil, nil, godo.ErrNotFound)
+
+	d, _, err := client.Droplets.Get(ctx, 123)
+	if err != nil || d.Name != "web" {
+		t.Errorf("Droplets.Get(123) = %+v, %v", d, err)
+	}
+	if _, _, err := client.Droplets.Get(ctx, 1); err != godo.ErrNotFound {
+		t.Errorf("Droplets.Get(1) error = %v, expected %v", err, godo.ErrNotFound)
+	}
+
+	if n := mocks.Droplets.CallCount("Get"); n != 2 {
+		t.Errorf("Get calls = %d, expected 2", n)
+	}
+	if calls := mocks.Droplets.Calls("Get"); calls[1].Args[1] != 1 {
+		t.Errorf("second call args = %v", calls[1].Args)
+	}
+
+	if !mocks.AssertExpectations(t) {
+		t.Error("expected expectations to be met")
+	}
+}
+
+func TestNewClient_allServicesMocked(t *testing.T) {
+	client, mocks := NewClient()
+
+	c := reflect.ValueOf(client).Elem()
+	m := reflect.ValueOf(mocks).Elem()
+	for i := 0; i < c.NumField(); i++ {
+		f := c.Type().Field(i)
+		if f.PkgPath != "" || f.Type.Kind() != reflect.Interface {
+			continue
+		}
+
+		mock := m.FieldByName(f.Name)
+		if !mock.IsValid() {
+			t.Errorf("no mock for godo.Client.%s, run go generate ./godomock", f.Name)
+			continue
+		}
+		if c.Field(i).Interface() != mock.Interface() {
+			t.Errorf("godo.Client.%s is not its mock", f.Name)
+		}
+	}
+}
+
+func TestMock_times(t *testing.T) {
+	m := &KubernetesService{}
+	m.On("Delete", Anything, "k8s").Return(nil, errors.New("first")).Once()
+	m.On("Delete", Anything, "k8s").Return(nil, nil)
+
+	if _, err := m.Delete(ctx, "k8s"); err == nil || err.Error() != "first" {
+		t.Errorf("first Delete() error = %v", err)
+	}
+	if _, err := m.Delete(ctx, "k8s"); err != nil {
+		t.Errorf("second Delete() error = %v", err)
+	}
+
+	rt := &recordingT{}
+	m.On("Get", Anything, "k8s").Times(2)
+	if m.AssertExpectations(rt) || len(rt.errors) != 1 || !strings.Contains(rt.errors[0], `Get(`) {
+		t.Errorf("AssertExpectations errors = %v, expected Get not called", rt.errors)
+	}
+}
+
+func TestMock_matcherAndReturnFunc(t *testing.T) {
+	m := &StorageService{}
+	m.On("CreateVolume", Anything, Matcher(func(arg interface{}) bool {
+		return arg.(*godo.VolumeCreateRequest).SizeGigaBytes > 0
+	})).ReturnFunc(func(args []interface{}) []interface{} {
+		req := args[1].(*godo.VolumeCreateRequest)
+		return []interface{}{&godo.Volume{Name: req.Name}, nil, nil}
+	})
+
+	vol, _, err := m.CreateVolume(ctx, &godo.VolumeCreateRequest{Name: "data", SizeGigaBytes: 10})
+	if err != nil || vol.Name != "data" {
+		t.Errorf("CreateVolume() = %+v, %v", vol, err)
+	}
+
+	defer func() {
+		r := recover()
+		if r == nil || !strings.Contains(fmt.Sprint(r), "unexpected call CreateVolume") {
+			t.Errorf("recovered %v, expected unexpected call panic", r)
+		}
+	}()
+	m.CreateVolume(ctx, &godo.VolumeCreateRequest{Name: "empty"})
+}
+
+func TestMock_funcMatcher(t *testing.T) {
+	m := &DropletsService{}
+	m.On("Get", Anything, func(arg interface{}) bool { return arg.(int) > 100 }).Return(&godo.Droplet{ID: 123}, nil, nil)
+
+	d, _, err := m.Get(ctx, 123)
+	if err != nil || d.ID != 123 {
+		t.Errorf("Get() = %+v, %v", d, err)
+	}
+}
+
+func TestMock_variadic(t *testing.T) {
+	m := &VPCsService{}
+	m.On("Set", Anything, "vpc", []godo.VPCSetField{godo.VPCSetName("net")}).Return(&godo.VPC{Name: "net"}, nil, nil)
+
+	vpc, _, err := m.Set(ctx, "vpc", godo.VPCSetName("net"))
+	if err != nil || vpc.Name != "net" {
+		t.Errorf("Set() = %+v, %v", vpc, err)
+	}
+}
+
+func TestMock_anyArgs(t *testing.T) {
+	m := &RegionsService{}
+	m.On("List").Return([]godo.Region{{Slug: "nyc3"}}, nil, nil)
+
+	regions, _, _ := m.List(ctx, &godo.ListOptions{Page: 2})
+	if len(regions) != 1 {
+		t.Errorf("List() = %v", regions)
+	}
+}
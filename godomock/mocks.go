@@ -0,0 +1,2240 @@
+// Code generated by mockgen from the godo service interfaces. DO NOT EDIT.
+
+package godomock
+
+import (
+	"context"
+
+	"github.com/digitalocean/godo"
+)
+
+// AccountService is a mock of godo.AccountService.
+type AccountService struct {
+	Mock
+}
+
+var _ godo.AccountService = (*AccountService)(nil)
+
+// Get mocks godo.AccountService.Get.
+func (m *AccountService) Get(a0 context.Context) (*godo.Account, *godo.Response, error) {
+	ret := m.Called("Get", a0)
+	r0, _ := ret.Get(0).(*godo.Account)
+	r1, _ := ret.Get(1).(*godo.Response)
+	r2 := ret.Error(2)
+	return r0, r1, r2
+}
+
+// ActionsService is a mock of godo.ActionsService.
+type ActionsService struct {
+	Mock
+}
+
+var _ godo.ActionsService = (*ActionsService)(nil)
+
+// List mocks godo.ActionsService.List.
+func (m *ActionsService) List(a0 context.Context, a1 *godo.ListOptions) ([]godo.Action, *godo.Response, error) {
+	ret := m.Called("List", a0, a1)
+	r0, _ := ret.Get(0).([]godo.Action)
+	r1, _ := ret.Get(1).(*godo.Response)
+	r2 := ret.Error(2)
+	return r0, r1, r2
+}
+
+// Get mocks godo.ActionsService.Get.
+func (m *ActionsService) Get(a0 context.Context, a1 int) (*godo.Action, *godo.Response, error) {
+	ret := m.Called("Get", a0, a1)
+	r0, _ := ret.Get(0).(*godo.Action)
+	r1, _ := ret.Get(1).(*godo.Response)
+	r2 := ret.Error(2)
+	return r0, r1, r2
+}
+
+// BalanceService is a mock of godo.BalanceService.
+type BalanceService struct {
+	Mock
+}
+
+var _ godo.BalanceService = (*BalanceService)(nil)
+
+// Get mocks godo.BalanceService.Get.
+func (m *BalanceService) Get(a0 context.Context) (*godo.Balance, *godo.Response, error) {
+	ret := m.Called("Get", a0)
+	r0, _ := ret.Get(0).(*godo.Balance)
+	r1, _ := ret.Get(1).(*godo.Response)
+	r2 := ret.Error(2)
+	return r0, r1, r2
+}
+
+// BillingHistoryService is a mock of godo.BillingHistoryService.
+type BillingHistoryService struct {
+	Mock
+}
+
+var _ godo.BillingHistoryService = (*BillingHistoryService)(nil)
+
+// List mocks godo.BillingHistoryService.List.
+func (m *BillingHistoryService) List(a0 context.Context, a1 *godo.ListOptions) (*godo.BillingHistory, *godo.Response, error) {
+	ret := m.Called("List", a0, a1)
+	r0, _ := ret.Get(0).(*godo.BillingHistory)
+	r1, _ := ret.Get(1).(*godo.Response)
+	r2 := ret.Error(2)
+	return r0, r1, r2
+}
+
+// CDNService is a mock of godo.CDNService.
+type CDNService struct {
+	Mock
+}
+
+var _ godo.CDNService = (*CDNService)(nil)
+
+// List mocks godo.CDNService.List.
+func (m *CDNService) List(a0 context.Context, a1 *godo.ListOptions) ([]godo.CDN, *godo.Response, error) {
+	ret := m.Called("List", a0, a1)
+	r0, _ := ret.Get(0).([]godo.CDN)
+	r1, _ := ret.Get(1).(*godo.Response)
+	r2 := ret.Error(2)
+	return r0, r1, r2
+}
+
+// Get mocks godo.CDNService.Get.
+func (m *CDNService) Get(a0 context.Context, a1 string) (*godo.CDN, *godo.Response, error) {
+	ret := m.Called("Get", a0, a1)
+	r0, _ := ret.Get(0).(*godo.CDN)
+	r1, _ := ret.Get(1).(*godo.Response)
+	r2 := ret.Error(2)
+	return r0, r1, r2
+}
+
+// Create mocks godo.CDNService.Create.
+func (m *CDNService) Create(a0 context.Context, a1 *godo.CDNCreateRequest) (*godo.CDN, *godo.Response, error) {
+	ret := m.Called("Create", a0, a1)
+	r0, _ := ret.Get(0).(*godo.CDN)
+	r1, _ := ret.Get(1).(*godo.Response)
+	r2 := ret.Error(2)
+	return r0, r1, r2
+}
+
+// UpdateTTL mocks godo.CDNService.UpdateTTL.
+func (m *CDNService) UpdateTTL(a0 context.Context, a1 string, a2 *godo.CDNUpdateTTLRequest) (*godo.CDN, *godo.Response, error) {
+	ret := m.Called("UpdateTTL", a0, a1, a2)
+	r0, _ := ret.Get(0).(*godo.CDN)
+	r1, _ := ret.Get(1).(*godo.Response)
+	r2 := ret.Error(2)
+	return r0, r1, r2
+}
+
+// UpdateCustomDomain mocks godo.CDNService.UpdateCustomDomain.
+func (m *CDNService) UpdateCustomDomain(a0 context.Context, a1 string, a2 *godo.CDNUpdateCustomDomainRequest) (*godo.CDN, *godo.Response, error) {
+	ret := m.Called("UpdateCustomDomain", a0, a1, a2)
+	r0, _ := ret.Get(0).(*godo.CDN)
+	r1, _ := ret.Get(1).(*godo.Response)
+	r2 := ret.Error(2)
+	return r0, r1, r2
+}
+
+// FlushCache mocks godo.CDNService.FlushCache.
+func (m *CDNService) FlushCache(a0 context.Context, a1 string, a2 *godo.CDNFlushCacheRequest) (*godo.Response, error) {
+	ret := m.Called("FlushCache", a0, a1, a2)
+	r0, _ := ret.Get(0).(*godo.Response)
+	r1 := ret.Error(1)
+	return r0, r1
+}
+
+// Delete mocks godo.CDNService.Delete.
+func (m *CDNService) Delete(a0 context.Context, a1 string) (*godo.Response, error) {
+	ret := m.Called("Delete", a0, a1)
+	r0, _ := ret.Get(0).(*godo.Response)
+	r1 := ret.Error(1)
+	return r0, r1
+}
+
+// DomainsService is a mock of godo.DomainsService.
+type DomainsService struct {
+	Mock
+}
+
+var _ godo.DomainsService = (*DomainsService)(nil)
+
+// List mocks godo.DomainsService.List.
+func (m *DomainsService) List(a0 context.Context, a1 *godo.ListOptions) ([]godo.Domain, *godo.Response, error) {
+	ret := m.Called("List", a0, a1)
+	r0, _ := ret.Get(0).([]godo.Domain)
+	r1, _ := ret.Get(1).(*godo.Response)
+	r2 := ret.Error(2)
+	return r0, r1, r2
+}
+
+// Get mocks godo.DomainsService.Get.
+func (m *DomainsService) Get(a0 context.Context, a1 string) (*godo.Domain, *godo.Response, error) {
+	ret := m.Called("Get", a0, a1)
+	r0, _ := ret.Get(0).(*godo.Domain)
+	r1, _ := ret.Get(1).(*godo.Response)
+	r2 := ret.Error(2)
+	return r0, r1, r2
+}
+
+// Create mocks godo.DomainsService.Create.
+func (m *DomainsService) Create(a0 context.Context, a1 *godo.DomainCreateRequest) (*godo.Domain, *godo.Response, error) {
+	ret := m.Called("Create", a0, a1)
+	r0, _ := ret.Get(0).(*godo.Domain)
+	r1, _ := ret.Get(1).(*godo.Response)
+	r2 := ret.Error(2)
+	return r0, r1, r2
+}
+
+// Delete mocks godo.DomainsService.Delete.
+func (m *DomainsService) Delete(a0 context.Context, a1 string) (*godo.Response, error) {
+	ret := m.Called("Delete", a0, a1)
+	r0, _ := ret.Get(0).(*godo.Response)
+	r1 := ret.Error(1)
+	return r0, r1
+}
+
+// Records mocks godo.DomainsService.Records.
+func (m *DomainsService) Records(a0 context.Context, a1 string, a2 *godo.ListOptions) ([]godo.DomainRecord, *godo.Response, error) {
+	ret := m.Called("Records", a0, a1, a2)
+	r0, _ := ret.Get(0).([]godo.DomainRecord)
+	r1, _ := ret.Get(1).(*godo.Response)
+	r2 := ret.Error(2)
+	return r0, r1, r2
+}
+
+// Record mocks godo.DomainsService.Record.
+func (m *DomainsService) Record(a0 context.Context, a1 string, a2 int) (*godo.DomainRecord, *godo.Response, error) {
+	ret := m.Called("Record", a0, a1, a2)
+	r0, _ := ret.Get(0).(*godo.DomainRecord)
+	r1, _ := ret.Get(1).(*godo.Response)
+	r2 := ret.Error(2)
+	return r0, r1, r2
+}
+
+// DeleteRecord mocks godo.DomainsService.DeleteRecord.
+func (m *DomainsService) DeleteRecord(a0 context.Context, a1 string, a2 int) (*godo.Response, error) {
+	ret := m.Called("DeleteRecord", a0, a1, a2)
+	r0, _ := ret.Get(0).(*godo.Response)
+	r1 := ret.Error(1)
+	return r0, r1
+}
+
+// EditRecord mocks godo.DomainsService.EditRecord.
+func (m *DomainsService) EditRecord(a0 context.Context, a1 string, a2 int, a3 *godo.DomainRecordEditRequest) (*godo.DomainRecord, *godo.Response, error) {
+	ret := m.Called("EditRecord", a0, a1, a2, a3)
+	r0, _ := ret.Get(0).(*godo.DomainRecord)
+	r1, _ := ret.Get(1).(*godo.Response)
+	r2 := ret.Error(2)
+	return r0, r1, r2
+}
+
+// CreateRecord mocks godo.DomainsService.CreateRecord.
+func (m *DomainsService) CreateRecord(a0 context.Context, a1 string, a2 *godo.DomainRecordEditRequest) (*godo.DomainRecord, *godo.Response, error) {
+	ret := m.Called("CreateRecord", a0, a1, a2)
+	r0, _ := ret.Get(0).(*godo.DomainRecord)
+	r1, _ := ret.Get(1).(*godo.Response)
+	r2 := ret.Error(2)
+	return r0, r1, r2
+}
+
+// DropletsService is a mock of godo.DropletsService.
+type DropletsService struct {
+	Mock
+}
+
+var _ godo.DropletsService = (*DropletsService)(nil)
+
+// List mocks godo.DropletsService.List.
+func (m *DropletsService) List(a0 context.Context, a1 *godo.ListOptions) ([]godo.Droplet, *godo.Response, error) {
+	ret := m.Called("List", a0, a1)
+	r0, _ := ret.Get(0).([]godo.Droplet)
+	r1, _ := ret.Get(1).(*godo.Response)
+	r2 := ret.Error(2)
+	return r0, r1, r2
+}
+
+// ListByTag mocks godo.DropletsService.ListByTag.
+func (m *DropletsService) ListByTag(a0 context.Context, a1 string, a2 *godo.ListOptions) ([]godo.Droplet, *godo.Response, error) {
+	ret := m.Called("ListByTag", a0, a1, a2)
+	r0, _ := ret.Get(0).([]godo.Droplet)
+	r1, _ := ret.Get(1).(*godo.Response)
+	r2 := ret.Error(2)
+	return r0, r1, r2
+}
+
+// Get mocks godo.DropletsService.Get.
+func (m *DropletsService) Get(a0 context.Context, a1 int) (*godo.Droplet, *godo.Response, error) {
+	ret := m.Called("Get", a0, a1)
+	r0, _ := ret.Get(0).(*godo.Droplet)
+	r1, _ := ret.Get(1).(*godo.Response)
+	r2 := ret.Error(2)
+	return r0, r1, r2
+}
+
+// Create mocks godo.DropletsService.Create.
+func (m *DropletsService) Create(a0 context.Context, a1 *godo.DropletCreateRequest) (*godo.Droplet, *godo.Response, error) {
+	ret := m.Called("Create", a0, a1)
+	r0, _ := ret.Get(0).(*godo.Droplet)
+	r1, _ := ret.Get(1).(*godo.Response)
+	r2 := ret.Error(2)
+	return r0, r1, r2
+}
+
+// CreateMultiple mocks godo.DropletsService.CreateMultiple.
+func (m *DropletsService) CreateMultiple(a0 context.Context, a1 *godo.DropletMultiCreateRequest) ([]godo.Droplet, *godo.Response, error) {
+	ret := m.Called("CreateMultiple", a0, a1)
+	r0, _ := ret.Get(0).([]godo.Droplet)
+	r1, _ := ret.Get(1).(*godo.Response)
+	r2 := ret.Error(2)
+	return r0, r1, r2
+}
+
+// Delete mocks godo.DropletsService.Delete.
+func (m *DropletsService) Delete(a0 context.Context, a1 int) (*godo.Response, error) {
+	ret := m.Called("Delete", a0, a1)
+	r0, _ := ret.Get(0).(*godo.Response)
+	r1 := ret.Error(1)
+	return r0, r1
+}
+
+// DeleteByTag mocks godo.DropletsService.DeleteByTag.
+func (m *DropletsService) DeleteByTag(a0 context.Context, a1 string) (*godo.Response, error) {
+	ret := m.Called("DeleteByTag", a0, a1)
+	r0, _ := ret.Get(0).(*godo.Response)
+	r1 := ret.Error(1)
+	return r0, r1
+}
+
+// Kernels mocks godo.DropletsService.Kernels.
+func (m *DropletsService) Kernels(a0 context.Context, a1 int, a2 *godo.ListOptions) ([]godo.Kernel, *godo.Response, error) {
+	ret := m.Called("Kernels", a0, a1, a2)
+	r0, _ := ret.Get(0).([]godo.Kernel)
+	r1, _ := ret.Get(1).(*godo.Response)
+	r2 := ret.Error(2)
+	return r0, r1, r2
+}
+
+// Snapshots mocks godo.DropletsService.Snapshots.
+func (m *DropletsService) Snapshots(a0 context.Context, a1 int, a2 *godo.ListOptions) ([]godo.Image, *godo.Response, error) {
+	ret := m.Called("Snapshots", a0, a1, a2)
+	r0, _ := ret.Get(0).([]godo.Image)
+	r1, _ := ret.Get(1).(*godo.Response)
+	r2 := ret.Error(2)
+	return r0, r1, r2
+}
+
+// Backups mocks godo.DropletsService.Backups.
+func (m *DropletsService) Backups(a0 context.Context, a1 int, a2 *godo.ListOptions) ([]godo.Image, *godo.Response, error) {
+	ret := m.Called("Backups", a0, a1, a2)
+	r0, _ := ret.Get(0).([]godo.Image)
+	r1, _ := ret.Get(1).(*godo.Response)
+	r2 := ret.Error(2)
+	return r0, r1, r2
+}
+
+// Actions mocks godo.DropletsService.Actions.
+func (m *DropletsService) Actions(a0 context.Context, a1 int, a2 *godo.ListOptions) ([]godo.Action, *godo.Response, error) {
+	ret := m.Called("Actions", a0, a1, a2)
+	r0, _ := ret.Get(0).([]godo.Action)
+	r1, _ := ret.Get(1).(*godo.Response)
+	r2 := ret.Error(2)
+	return r0, r1, r2
+}
+
+// Neighbors mocks godo.DropletsService.Neighbors.
+func (m *DropletsService) Neighbors(a0 context.Context, a1 int) ([]godo.Droplet, *godo.Response, error) {
+	ret := m.Called("Neighbors", a0, a1)
+	r0, _ := ret.Get(0).([]godo.Droplet)
+	r1, _ := ret.Get(1).(*godo.Response)
+	r2 := ret.Error(2)
+	return r0, r1, r2
+}
+
+// DropletActionsService is a mock of godo.DropletActionsService.
+type DropletActionsService struct {
+	Mock
+}
+
+var _ godo.DropletActionsService = (*DropletActionsService)(nil)
+
+// Shutdown mocks godo.DropletActionsService.Shutdown.
+func (m *DropletActionsService) Shutdown(a0 context.Context, a1 int) (*godo.Action, *godo.Response, error) {
+	ret := m.Called("Shutdown", a0, a1)
+	r0, _ := ret.Get(0).(*godo.Action)
+	r1, _ := ret.Get(1).(*godo.Response)
+	r2 := ret.Error(2)
+	return r0, r1, r2
+}
+
+// ShutdownByTag mocks godo.DropletActionsService.ShutdownByTag.
+func (m *DropletActionsService) ShutdownByTag(a0 context.Context, a1 string) ([]godo.Action, *godo.Response, error) {
+	ret := m.Called("ShutdownByTag", a0, a1)
+	r0, _ := ret.Get(0).([]godo.Action)
+	r1, _ := ret.Get(1).(*godo.Response)
+	r2 := ret.Error(2)
+	return r0, r1, r2
+}
+
+// PowerOff mocks godo.DropletActionsService.PowerOff.
+func (m *DropletActionsService) PowerOff(a0 context.Context, a1 int) (*godo.Action, *godo.Response, error) {
+	ret := m.Called("PowerOff", a0, a1)
+	r0, _ := ret.Get(0).(*godo.Action)
+	r1, _ := ret.Get(1).(*godo.Response)
+	r2 := ret.Error(2)
+	return r0, r1, r2
+}
+
+// PowerOffByTag mocks godo.DropletActionsService.PowerOffByTag.
+func (m *DropletActionsService) PowerOffByTag(a0 context.Context, a1 string) ([]godo.Action, *godo.Response, error) {
+	ret := m.Called("PowerOffByTag", a0, a1)
+	r0, _ := ret.Get(0).([]godo.Action)
+	r1, _ := ret.Get(1).(*godo.Response)
+	r2 := ret.Error(2)
+	return r0, r1, r2
+}
+
+// PowerOn mocks godo.DropletActionsService.PowerOn.
+func (m *DropletActionsService) PowerOn(a0 context.Context, a1 int) (*godo.Action, *godo.Response, error) {
+	ret := m.Called("PowerOn", a0, a1)
+	r0, _ := ret.Get(0).(*godo.Action)
+	r1, _ := ret.Get(1).(*godo.Response)
+	r2 := ret.Error(2)
+	return r0, r1, r2
+}
+
+// PowerOnByTag mocks godo.DropletActionsService.PowerOnByTag.
+func (m *DropletActionsService) PowerOnByTag(a0 context.Context, a1 string) ([]godo.Action, *godo.Response, error) {
+	ret := m.Called("PowerOnByTag", a0, a1)
+	r0, _ := ret.Get(0).([]godo.Action)
+	r1, _ := ret.Get(1).(*godo.Response)
+	r2 := ret.Error(2)
+	return r0, r1, r2
+}
+
+// PowerCycle mocks godo.DropletActionsService.PowerCycle.
+func (m *DropletActionsService) PowerCycle(a0 context.Context, a1 int) (*godo.Action, *godo.Response, error) {
+	ret := m.Called("PowerCycle", a0, a1)
+	r0, _ := ret.Get(0).(*godo.Action)
+	r1, _ := ret.Get(1).(*godo.Response)
+	r2 := ret.Error(2)
+	return r0, r1, r2
+}
+
+// PowerCycleByTag mocks godo.DropletActionsService.PowerCycleByTag.
+func (m *DropletActionsService) PowerCycleByTag(a0 context.Context, a1 string) ([]godo.Action, *godo.Response, error) {
+	ret := m.Called("PowerCycleByTag", a0, a1)
+	r0, _ := ret.Get(0).([]godo.Action)
+	r1, _ := ret.Get(1).(*godo.Response)
+	r2 := ret.Error(2)
+	return r0, r1, r2
+}
+
+// Reboot mocks godo.DropletActionsService.Reboot.
+func (m *DropletActionsService) Reboot(a0 context.Context, a1 int) (*godo.Action, *godo.Response, error) {
+	ret := m.Called("Reboot", a0, a1)
+	r0, _ := ret.Get(0).(*godo.Action)
+	r1, _ := ret.Get(1).(*godo.Response)
+	r2 := ret.Error(2)
+	return r0, r1, r2
+}
+
+// Restore mocks godo.DropletActionsService.Restore.
+func (m *DropletActionsService) Restore(a0 context.Context, a1 int, a2 int) (*godo.Action, *godo.Response, error) {
+	ret := m.Called("Restore", a0, a1, a2)
+	r0, _ := ret.Get(0).(*godo.Action)
+	r1, _ := ret.Get(1).(*godo.Response)
+	r2 := ret.Error(2)
+	return r0, r1, r2
+}
+
+// Resize mocks godo.DropletActionsService.Resize.
+func (m *DropletActionsService) Resize(a0 context.Context, a1 int, a2 string, a3 bool) (*godo.Action, *godo.Response, error) {
+	ret := m.Called("Resize", a0, a1, a2, a3)
+	r0, _ := ret.Get(0).(*godo.Action)
+	r1, _ := ret.Get(1).(*godo.Response)
+	r2 := ret.Error(2)
+	return r0, r1, r2
+}
+
+// Rename mocks godo.DropletActionsService.Rename.
+func (m *DropletActionsService) Rename(a0 context.Context, a1 int, a2 string) (*godo.Action, *godo.Response, error) {
+	ret := m.Called("Rename", a0, a1, a2)
+	r0, _ := ret.Get(0).(*godo.Action)
+	r1, _ := ret.Get(1).(*godo.Response)
+	r2 := ret.Error(2)
+	return r0, r1, r2
+}
+
+// Snapshot mocks godo.DropletActionsService.Snapshot.
+func (m *DropletActionsService) Snapshot(a0 context.Context, a1 int, a2 string) (*godo.Action, *godo.Response, error) {
+	ret := m.Called("Snapshot", a0, a1, a2)
+	r0, _ := ret.Get(0).(*godo.Action)
+	r1, _ := ret.Get(1).(*godo.Response)
+	r2 := ret.Error(2)
+	return r0, r1, r2
+}
+
+// SnapshotByTag mocks godo.DropletActionsService.SnapshotByTag.
+func (m *DropletActionsService) SnapshotByTag(a0 context.Context, a1 string, a2 string) ([]godo.Action, *godo.Response, error) {
+	ret := m.Called("SnapshotByTag", a0, a1, a2)
+	r0, _ := ret.Get(0).([]godo.Action)
+	r1, _ := ret.Get(1).(*godo.Response)
+	r2 := ret.Error(2)
+	return r0, r1, r2
+}
+
+// EnableBackups mocks godo.DropletActionsService.EnableBackups.
+func (m *DropletActionsService) EnableBackups(a0 context.Context, a1 int) (*godo.Action, *godo.Response, error) {
+	ret := m.Called("EnableBackups", a0, a1)
+	r0, _ := ret.Get(0).(*godo.Action)
+	r1, _ := ret.Get(1).(*godo.Response)
+	r2 := ret.Error(2)
+	return r0, r1, r2
+}
+
+// EnableBackupsByTag mocks godo.DropletActionsService.EnableBackupsByTag.
+func (m *DropletActionsService) EnableBackupsByTag(a0 context.Context, a1 string) ([]godo.Action, *godo.Response, error) {
+	ret := m.Called("EnableBackupsByTag", a0, a1)
+	r0, _ := ret.Get(0).([]godo.Action)
+	r1, _ := ret.Get(1).(*godo.Response)
+	r2 := ret.Error(2)
+	return r0, r1, r2
+}
+
+// DisableBackups mocks godo.DropletActionsService.DisableBackups.
+func (m *DropletActionsService) DisableBackups(a0 context.Context, a1 int) (*godo.Action, *godo.Response, error) {
+	ret := m.Called("DisableBackups", a0, a1)
+	r0, _ := ret.Get(0).(*godo.Action)
+	r1, _ := ret.Get(1).(*godo.Response)
+	r2 := ret.Error(2)
+	return r0, r1, r2
+}
+
+// DisableBackupsByTag mocks godo.DropletActionsService.DisableBackupsByTag.
+func (m *DropletActionsService) DisableBackupsByTag(a0 context.Context, a1 string) ([]godo.Action, *godo.Response, error) {
+	ret := m.Called("DisableBackupsByTag", a0, a1)
+	r0, _ := ret.Get(0).([]godo.Action)
+	r1, _ := ret.Get(1).(*godo.Response)
+	r2 := ret.Error(2)
+	return r0, r1, r2
+}
+
+// PasswordReset mocks godo.DropletActionsService.PasswordReset.
+func (m *DropletActionsService) PasswordReset(a0 context.Context, a1 int) (*godo.Action, *godo.Response, error) {
+	ret := m.Called("PasswordReset", a0, a1)
+	r0, _ := ret.Get(0).(*godo.Action)
+	r1, _ := ret.Get(1).(*godo.Response)
+	r2 := ret.Error(2)
+	return r0, r1, r2
+}
+
+// RebuildByImageID mocks godo.DropletActionsService.RebuildByImageID.
+func (m *DropletActionsService) RebuildByImageID(a0 context.Context, a1 int, a2 int) (*godo.Action, *godo.Response, error) {
+	ret := m.Called("RebuildByImageID", a0, a1, a2)
+	r0, _ := ret.Get(0).(*godo.Action)
+	r1, _ := ret.Get(1).(*godo.Response)
+	r2 := ret.Error(2)
+	return r0, r1, r2
+}
+
+// RebuildByImageSlug mocks godo.DropletActionsService.RebuildByImageSlug.
+func (m *DropletActionsService) RebuildByImageSlug(a0 context.Context, a1 int, a2 string) (*godo.Action, *godo.Response, error) {
+	ret := m.Called("RebuildByImageSlug", a0, a1, a2)
+	r0, _ := ret.Get(0).(*godo.Action)
+	r1, _ := ret.Get(1).(*godo.Response)
+	r2 := ret.Error(2)
+	return r0, r1, r2
+}
+
+// ChangeKernel mocks godo.DropletActionsService.ChangeKernel.
+func (m *DropletActionsService) ChangeKernel(a0 context.Context, a1 int, a2 int) (*godo.Action, *godo.Response, error) {
+	ret := m.Called("ChangeKernel", a0, a1, a2)
+	r0, _ := ret.Get(0).(*godo.Action)
+	r1, _ := ret.Get(1).(*godo.Response)
+	r2 := ret.Error(2)
+	return r0, r1, r2
+}
+
+// EnableIPv6 mocks godo.DropletActionsService.EnableIPv6.
+func (m *DropletActionsService) EnableIPv6(a0 context.Context, a1 int) (*godo.Action, *godo.Response, error) {
+	ret := m.Called("EnableIPv6", a0, a1)
+	r0, _ := ret.Get(0).(*godo.Action)
+	r1, _ := ret.Get(1).(*godo.Response)
+	r2 := ret.Error(2)
+	return r0, r1, r2
+}
+
+// EnableIPv6ByTag mocks godo.DropletActionsService.EnableIPv6ByTag.
+func (m *DropletActionsService) EnableIPv6ByTag(a0 context.Context, a1 string) ([]godo.Action, *godo.Response, error) {
+	ret := m.Called("EnableIPv6ByTag", a0, a1)
+	r0, _ := ret.Get(0).([]godo.Action)
+	r1, _ := ret.Get(1).(*godo.Response)
+	r2 := ret.Error(2)
+	return r0, r1, r2
+}
+
+// EnablePrivateNetworking mocks godo.DropletActionsService.EnablePrivateNetworking.
+func (m *DropletActionsService) EnablePrivateNetworking(a0 context.Context, a1 int) (*godo.Action, *godo.Response, error) {
+	ret := m.Called("EnablePrivateNetworking", a0, a1)
+	r0, _ := ret.Get(0).(*godo.Action)
+	r1, _ := ret.Get(1).(*godo.Response)
+	r2 := ret.Error(2)
+	return r0, r1, r2
+}
+
+// EnablePrivateNetworkingByTag mocks godo.DropletActionsService.EnablePrivateNetworkingByTag.
+func (m *DropletActionsService) EnablePrivateNetworkingByTag(a0 context.Context, a1 string) ([]godo.Action, *godo.Response, error) {
+	ret := m.Called("EnablePrivateNetworkingByTag", a0, a1)
+	r0, _ := ret.Get(0).([]godo.Action)
+	r1, _ := ret.Get(1).(*godo.Response)
+	r2 := ret.Error(2)
+	return r0, r1, r2
+}
+
+// Get mocks godo.DropletActionsService.Get.
+func (m *DropletActionsService) Get(a0 context.Context, a1 int, a2 int) (*godo.Action, *godo.Response, error) {
+	ret := m.Called("Get", a0, a1, a2)
+	r0, _ := ret.Get(0).(*godo.Action)
+	r1, _ := ret.Get(1).(*godo.Response)
+	r2 := ret.Error(2)
+	return r0, r1, r2
+}
+
+// GetByURI mocks godo.DropletActionsService.GetByURI.
+func (m *DropletActionsService) GetByURI(a0 context.Context, a1 string) (*godo.Action, *godo.Response, error) {
+	ret := m.Called("GetByURI", a0, a1)
+	r0, _ := ret.Get(0).(*godo.Action)
+	r1, _ := ret.Get(1).(*godo.Response)
+	r2 := ret.Error(2)
+	return r0, r1, r2
+}
+
+// ImagesService is a mock of godo.ImagesService.
+type ImagesService struct {
+	Mock
+}
+
+var _ godo.ImagesService = (*ImagesService)(nil)
+
+// List mocks godo.ImagesService.List.
+func (m *ImagesService) List(a0 context.Context, a1 *godo.ListOptions) ([]godo.Image, *godo.Response, error) {
+	ret := m.Called("List", a0, a1)
+	r0, _ := ret.Get(0).([]godo.Image)
+	r1, _ := ret.Get(1).(*godo.Response)
+	r2 := ret.Error(2)
+	return r0, r1, r2
+}
+
+// ListDistribution mocks godo.ImagesService.ListDistribution.
+func (m *ImagesService) ListDistribution(a0 context.Context, a1 *godo.ListOptions) ([]godo.Image, *godo.Response, error) {
+	ret := m.Called("ListDistribution", a0, a1)
+	r0, _ := ret.Get(0).([]godo.Image)
+	r1, _ := ret.Get(1).(*godo.Response)
+	r2 := ret.Error(2)
+	return r0, r1, r2
+}
+
+// ListApplication mocks godo.ImagesService.ListApplication.
+func (m *ImagesService) ListApplication(a0 context.Context, a1 *godo.ListOptions) ([]godo.Image, *godo.Response, error) {
+	ret := m.Called("ListApplication", a0, a1)
+	r0, _ := ret.Get(0).([]godo.Image)
+	r1, _ := ret.Get(1).(*godo.Response)
+	r2 := ret.Error(2)
+	return r0, r1, r2
+}
+
+// ListUser mocks godo.ImagesService.ListUser.
+func (m *ImagesService) ListUser(a0 context.Context, a1 *godo.ListOptions) ([]godo.Image, *godo.Response, error) {
+	ret := m.Called("ListUser", a0, a1)
+	r0, _ := ret.Get(0).([]godo.Image)
+	r1, _ := ret.Get(1).(*godo.Response)
+	r2 := ret.Error(2)
+	return r0, r1, r2
+}
+
+// ListByTag mocks godo.ImagesService.ListByTag.
+func (m *ImagesService) ListByTag(a0 context.Context, a1 string, a2 *godo.ListOptions) ([]godo.Image, *godo.Response, error) {
+	ret := m.Called("ListByTag", a0, a1, a2)
+	r0, _ := ret.Get(0).([]godo.Image)
+	r1, _ := ret.Get(1).(*godo.Response)
+	r2 := ret.Error(2)
+	return r0, r1, r2
+}
+
+// GetByID mocks godo.ImagesService.GetByID.
+func (m *ImagesService) GetByID(a0 context.Context, a1 int) (*godo.Image, *godo.Response, error) {
+	ret := m.Called("GetByID", a0, a1)
+	r0, _ := ret.Get(0).(*godo.Image)
+	r1, _ := ret.Get(1).(*godo.Response)
+	r2 := ret.Error(2)
+	return r0, r1, r2
+}
+
+// GetBySlug mocks godo.ImagesService.GetBySlug.
+func (m *ImagesService) GetBySlug(a0 context.Context, a1 string) (*godo.Image, *godo.Response, error) {
+	ret := m.Called("GetBySlug", a0, a1)
+	r0, _ := ret.Get(0).(*godo.Image)
+	r1, _ := ret.Get(1).(*godo.Response)
+	r2 := ret.Error(2)
+	return r0, r1, r2
+}
+
+// Create mocks godo.ImagesService.Create.
+func (m *ImagesService) Create(a0 context.Context, a1 *godo.CustomImageCreateRequest) (*godo.Image, *godo.Response, error) {
+	ret := m.Called("Create", a0, a1)
+	r0, _ := ret.Get(0).(*godo.Image)
+	r1, _ := ret.Get(1).(*godo.Response)
+	r2 := ret.Error(2)
+	return r0, r1, r2
+}
+
+// Update mocks godo.ImagesService.Update.
+func (m *ImagesService) Update(a0 context.Context, a1 int, a2 *godo.ImageUpdateRequest) (*godo.Image, *godo.Response, error) {
+	ret := m.Called("Update", a0, a1, a2)
+	r0, _ := ret.Get(0).(*godo.Image)
+	r1, _ := ret.Get(1).(*godo.Response)
+	r2 := ret.Error(2)
+	return r0, r1, r2
+}
+
+// Delete mocks godo.ImagesService.Delete.
+func (m *ImagesService) Delete(a0 context.Context, a1 int) (*godo.Response, error) {
+	ret := m.Called("Delete", a0, a1)
+	r0, _ := ret.Get(0).(*godo.Response)
+	r1 := ret.Error(1)
+	return r0, r1
+}
+
+// ImageActionsService is a mock of godo.ImageActionsService.
+type ImageActionsService struct {
+	Mock
+}
+
+var _ godo.ImageActionsService = (*ImageActionsService)(nil)
+
+// Get mocks godo.ImageActionsService.Get.
+func (m *ImageActionsService) Get(a0 context.Context, a1 int, a2 int) (*godo.Action, *godo.Response, error) {
+	ret := m.Called("Get", a0, a1, a2)
+	r0, _ := ret.Get(0).(*godo.Action)
+	r1, _ := ret.Get(1).(*godo.Response)
+	r2 := ret.Error(2)
+	return r0, r1, r2
+}
+
+// Transfer mocks godo.ImageActionsService.Transfer.
+func (m *ImageActionsService) Transfer(a0 context.Context, a1 int, a2 *godo.ActionRequest) (*godo.Action, *godo.Response, error) {
+	ret := m.Called("Transfer", a0, a1, a2)
+	r0, _ := ret.Get(0).(*godo.Action)
+	r1, _ := ret.Get(1).(*godo.Response)
+	r2 := ret.Error(2)
+	return r0, r1, r2
+}
+
+// Convert mocks godo.ImageActionsService.Convert.
+func (m *ImageActionsService) Convert(a0 context.Context, a1 int) (*godo.Action, *godo.Response, error) {
+	ret := m.Called("Convert", a0, a1)
+	r0, _ := ret.Get(0).(*godo.Action)
+	r1, _ := ret.Get(1).(*godo.Response)
+	r2 := ret.Error(2)
+	return r0, r1, r2
+}
+
+// InvoicesService is a mock of godo.InvoicesService.
+type InvoicesService struct {
+	Mock
+}
+
+var _ godo.InvoicesService = (*InvoicesService)(nil)
+
+// Get mocks godo.InvoicesService.Get.
+func (m *InvoicesService) Get(a0 context.Context, a1 string, a2 *godo.ListOptions) (*godo.Invoice, *godo.Response, error) {
+	ret := m.Called("Get", a0, a1, a2)
+	r0, _ := ret.Get(0).(*godo.Invoice)
+	r1, _ := ret.Get(1).(*godo.Response)
+	r2 := ret.Error(2)
+	return r0, r1, r2
+}
+
+// GetPDF mocks godo.InvoicesService.GetPDF.
+func (m *InvoicesService) GetPDF(a0 context.Context, a1 string) ([]byte, *godo.Response, error) {
+	ret := m.Called("GetPDF", a0, a1)
+	r0, _ := ret.Get(0).([]byte)
+	r1, _ := ret.Get(1).(*godo.Response)
+	r2 := ret.Error(2)
+	return r0, r1, r2
+}
+
+// GetCSV mocks godo.InvoicesService.GetCSV.
+func (m *InvoicesService) GetCSV(a0 context.Context, a1 string) ([]byte, *godo.Response, error) {
+	ret := m.Called("GetCSV", a0, a1)
+	r0, _ := ret.Get(0).([]byte)
+	r1, _ := ret.Get(1).(*godo.Response)
+	r2 := ret.Error(2)
+	return r0, r1, r2
+}
+
+// List mocks godo.InvoicesService.List.
+func (m *InvoicesService) List(a0 context.Context, a1 *godo.ListOptions) (*godo.InvoiceList, *godo.Response, error) {
+	ret := m.Called("List", a0, a1)
+	r0, _ := ret.Get(0).(*godo.InvoiceList)
+	r1, _ := ret.Get(1).(*godo.Response)
+	r2 := ret.Error(2)
+	return r0, r1, r2
+}
+
+// GetSummary mocks godo.InvoicesService.GetSummary.
+func (m *InvoicesService) GetSummary(a0 context.Context, a1 string) (*godo.InvoiceSummary, *godo.Response, error) {
+	ret := m.Called("GetSummary", a0, a1)
+	r0, _ := ret.Get(0).(*godo.InvoiceSummary)
+	r1, _ := ret.Get(1).(*godo.Response)
+	r2 := ret.Error(2)
+	return r0, r1, r2
+}
+
+// KeysService is a mock of godo.KeysService.
+type KeysService struct {
+	Mock
+}
+
+var _ godo.KeysService = (*KeysService)(nil)
+
+// List mocks godo.KeysService.List.
+func (m *KeysService) List(a0 context.Context, a1 *godo.ListOptions) ([]godo.Key, *godo.Response, error) {
+	ret := m.Called("List", a0, a1)
+	r0, _ := ret.Get(0).([]godo.Key)
+	r1, _ := ret.Get(1).(*godo.Response)
+	r2 := ret.Error(2)
+	return r0, r1, r2
+}
+
+// GetByID mocks godo.KeysService.GetByID.
+func (m *KeysService) GetByID(a0 context.Context, a1 int) (*godo.Key, *godo.Response, error) {
+	ret := m.Called("GetByID", a0, a1)
+	r0, _ := ret.Get(0).(*godo.Key)
+	r1, _ := ret.Get(1).(*godo.Response)
+	r2 := ret.Error(2)
+	return r0, r1, r2
+}
+
+// GetByFingerprint mocks godo.KeysService.GetByFingerprint.
+func (m *KeysService) GetByFingerprint(a0 context.Context, a1 string) (*godo.Key, *godo.Response, error) {
+	ret := m.Called("GetByFingerprint", a0, a1)
+	r0, _ := ret.Get(0).(*godo.Key)
+	r1, _ := ret.Get(1).(*godo.Response)
+	r2 := ret.Error(2)
+	return r0, r1, r2
+}
+
+// Create mocks godo.KeysService.Create.
+func (m *KeysService) Create(a0 context.Context, a1 *godo.KeyCreateRequest) (*godo.Key, *godo.Response, error) {
+	ret := m.Called("Create", a0, a1)
+	r0, _ := ret.Get(0).(*godo.Key)
+	r1, _ := ret.Get(1).(*godo.Response)
+	r2 := ret.Error(2)
+	return r0, r1, r2
+}
+
+// UpdateByID mocks godo.KeysService.UpdateByID.
+func (m *KeysService) UpdateByID(a0 context.Context, a1 int, a2 *godo.KeyUpdateRequest) (*godo.Key, *godo.Response, error) {
+	ret := m.Called("UpdateByID", a0, a1, a2)
+	r0, _ := ret.Get(0).(*godo.Key)
+	r1, _ := ret.Get(1).(*godo.Response)
+	r2 := ret.Error(2)
+	return r0, r1, r2
+}
+
+// UpdateByFingerprint mocks godo.KeysService.UpdateByFingerprint.
+func (m *KeysService) UpdateByFingerprint(a0 context.Context, a1 string, a2 *godo.KeyUpdateRequest) (*godo.Key, *godo.Response, error) {
+	ret := m.Called("UpdateByFingerprint", a0, a1, a2)
+	r0, _ := ret.Get(0).(*godo.Key)
+	r1, _ := ret.Get(1).(*godo.Response)
+	r2 := ret.Error(2)
+	return r0, r1, r2
+}
+
+// DeleteByID mocks godo.KeysService.DeleteByID.
+func (m *KeysService) DeleteByID(a0 context.Context, a1 int) (*godo.Response, error) {
+	ret := m.Called("DeleteByID", a0, a1)
+	r0, _ := ret.Get(0).(*godo.Response)
+	r1 := ret.Error(1)
+	return r0, r1
+}
+
+// DeleteByFingerprint mocks godo.KeysService.DeleteByFingerprint.
+func (m *KeysService) DeleteByFingerprint(a0 context.Context, a1 string) (*godo.Response, error) {
+	ret := m.Called("DeleteByFingerprint", a0, a1)
+	r0, _ := ret.Get(0).(*godo.Response)
+	r1 := ret.Error(1)
+	return r0, r1
+}
+
+// RegionsService is a mock of godo.RegionsService.
+type RegionsService struct {
+	Mock
+}
+
+var _ godo.RegionsService = (*RegionsService)(nil)
+
+// List mocks godo.RegionsService.List.
+func (m *RegionsService) List(a0 context.Context, a1 *godo.ListOptions) ([]godo.Region, *godo.Response, error) {
+	ret := m.Called("List", a0, a1)
+	r0, _ := ret.Get(0).([]godo.Region)
+	r1, _ := ret.Get(1).(*godo.Response)
+	r2 := ret.Error(2)
+	return r0, r1, r2
+}
+
+// SizesService is a mock of godo.SizesService.
+type SizesService struct {
+	Mock
+}
+
+var _ godo.SizesService = (*SizesService)(nil)
+
+// List mocks godo.SizesService.List.
+func (m *SizesService) List(a0 context.Context, a1 *godo.ListOptions) ([]godo.Size, *godo.Response, error) {
+	ret := m.Called("List", a0, a1)
+	r0, _ := ret.Get(0).([]godo.Size)
+	r1, _ := ret.Get(1).(*godo.Response)
+	r2 := ret.Error(2)
+	return r0, r1, r2
+}
+
+// FloatingIPsService is a mock of godo.FloatingIPsService.
+type FloatingIPsService struct {
+	Mock
+}
+
+var _ godo.FloatingIPsService = (*FloatingIPsService)(nil)
+
+// List mocks godo.FloatingIPsService.List.
+func (m *FloatingIPsService) List(a0 context.Context, a1 *godo.ListOptions) ([]godo.FloatingIP, *godo.Response, error) {
+	ret := m.Called("List", a0, a1)
+	r0, _ := ret.Get(0).([]godo.FloatingIP)
+	r1, _ := ret.Get(1).(*godo.Response)
+	r2 := ret.Error(2)
+	return r0, r1, r2
+}
+
+// Get mocks godo.FloatingIPsService.Get.
+func (m *FloatingIPsService) Get(a0 context.Context, a1 string) (*godo.FloatingIP, *godo.Response, error) {
+	ret := m.Called("Get", a0, a1)
+	r0, _ := ret.Get(0).(*godo.FloatingIP)
+	r1, _ := ret.Get(1).(*godo.Response)
+	r2 := ret.Error(2)
+	return r0, r1, r2
+}
+
+// Create mocks godo.FloatingIPsService.Create.
+func (m *FloatingIPsService) Create(a0 context.Context, a1 *godo.FloatingIPCreateRequest) (*godo.FloatingIP, *godo.Response, error) {
+	ret := m.Called("Create", a0, a1)
+	r0, _ := ret.Get(0).(*godo.FloatingIP)
+	r1, _ := ret.Get(1).(*godo.Response)
+	r2 := ret.Error(2)
+	return r0, r1, r2
+}
+
+// Delete mocks godo.FloatingIPsService.Delete.
+func (m *FloatingIPsService) Delete(a0 context.Context, a1 string) (*godo.Response, error) {
+	ret := m.Called("Delete", a0, a1)
+	r0, _ := ret.Get(0).(*godo.Response)
+	r1 := ret.Error(1)
+	return r0, r1
+}
+
+// FloatingIPActionsService is a mock of godo.FloatingIPActionsService.
+type FloatingIPActionsService struct {
+	Mock
+}
+
+var _ godo.FloatingIPActionsService = (*FloatingIPActionsService)(nil)
+
+// Assign mocks godo.FloatingIPActionsService.Assign.
+func (m *FloatingIPActionsService) Assign(a0 context.Context, a1 string, a2 int) (*godo.Action, *godo.Response, error) {
+	ret := m.Called("Assign", a0, a1, a2)
+	r0, _ := ret.Get(0).(*godo.Action)
+	r1, _ := ret.Get(1).(*godo.Response)
+	r2 := ret.Error(2)
+	return r0, r1, r2
+}
+
+// Unassign mocks godo.FloatingIPActionsService.Unassign.
+func (m *FloatingIPActionsService) Unassign(a0 context.Context, a1 string) (*godo.Action, *godo.Response, error) {
+	ret := m.Called("Unassign", a0, a1)
+	r0, _ := ret.Get(0).(*godo.Action)
+	r1, _ := ret.Get(1).(*godo.Response)
+	r2 := ret.Error(2)
+	return r0, r1, r2
+}
+
+// Get mocks godo.FloatingIPActionsService.Get.
+func (m *FloatingIPActionsService) Get(a0 context.Context, a1 string, a2 int) (*godo.Action, *godo.Response, error) {
+	ret := m.Called("Get", a0, a1, a2)
+	r0, _ := ret.Get(0).(*godo.Action)
+	r1, _ := ret.Get(1).(*godo.Response)
+	r2 := ret.Error(2)
+	return r0, r1, r2
+}
+
+// List mocks godo.FloatingIPActionsService.List.
+func (m *FloatingIPActionsService) List(a0 context.Context, a1 string, a2 *godo.ListOptions) ([]godo.Action, *godo.Response, error) {
+	ret := m.Called("List", a0, a1, a2)
+	r0, _ := ret.Get(0).([]godo.Action)
+	r1, _ := ret.Get(1).(*godo.Response)
+	r2 := ret.Error(2)
+	return r0, r1, r2
+}
+
+// SnapshotsService is a mock of godo.SnapshotsService.
+type SnapshotsService struct {
+	Mock
+}
+
+var _ godo.SnapshotsService = (*SnapshotsService)(nil)
+
+// List mocks godo.SnapshotsService.List.
+func (m *SnapshotsService) List(a0 context.Context, a1 *godo.ListOptions) ([]godo.Snapshot, *godo.Response, error) {
+	ret := m.Called("List", a0, a1)
+	r0, _ := ret.Get(0).([]godo.Snapshot)
+	r1, _ := ret.Get(1).(*godo.Response)
+	r2 := ret.Error(2)
+	return r0, r1, r2
+}
+
+// ListVolume mocks godo.SnapshotsService.ListVolume.
+func (m *SnapshotsService) ListVolume(a0 context.Context, a1 *godo.ListOptions) ([]godo.Snapshot, *godo.Response, error) {
+	ret := m.Called("ListVolume", a0, a1)
+	r0, _ := ret.Get(0).([]godo.Snapshot)
+	r1, _ := ret.Get(1).(*godo.Response)
+	r2 := ret.Error(2)
+	return r0, r1, r2
+}
+
+// ListDroplet mocks godo.SnapshotsService.ListDroplet.
+func (m *SnapshotsService) ListDroplet(a0 context.Context, a1 *godo.ListOptions) ([]godo.Snapshot, *godo.Response, error) {
+	ret := m.Called("ListDroplet", a0, a1)
+	r0, _ := ret.Get(0).([]godo.Snapshot)
+	r1, _ := ret.Get(1).(*godo.Response)
+	r2 := ret.Error(2)
+	return r0, r1, r2
+}
+
+// Get mocks godo.SnapshotsService.Get.
+func (m *SnapshotsService) Get(a0 context.Context, a1 string) (*godo.Snapshot, *godo.Response, error) {
+	ret := m.Called("Get", a0, a1)
+	r0, _ := ret.Get(0).(*godo.Snapshot)
+	r1, _ := ret.Get(1).(*godo.Response)
+	r2 := ret.Error(2)
+	return r0, r1, r2
+}
+
+// Delete mocks godo.SnapshotsService.Delete.
+func (m *SnapshotsService) Delete(a0 context.Context, a1 string) (*godo.Response, error) {
+	ret := m.Called("Delete", a0, a1)
+	r0, _ := ret.Get(0).(*godo.Response)
+	r1 := ret.Error(1)
+	return r0, r1
+}
+
+// StorageService is a mock of godo.StorageService.
+type StorageService struct {
+	Mock
+}
+
+var _ godo.StorageService = (*StorageService)(nil)
+
+// ListVolumes mocks godo.StorageService.ListVolumes.
+func (m *StorageService) ListVolumes(a0 context.Context, a1 *godo.ListVolumeParams) ([]godo.Volume, *godo.Response, error) {
+	ret := m.Called("ListVolumes", a0, a1)
+	r0, _ := ret.Get(0).([]godo.Volume)
+	r1, _ := ret.Get(1).(*godo.Response)
+	r2 := ret.Error(2)
+	return r0, r1, r2
+}
+
+// GetVolume mocks godo.StorageService.GetVolume.
+func (m *StorageService) GetVolume(a0 context.Context, a1 string) (*godo.Volume, *godo.Response, error) {
+	ret := m.Called("GetVolume", a0, a1)
+	r0, _ := ret.Get(0).(*godo.Volume)
+	r1, _ := ret.Get(1).(*godo.Response)
+	r2 := ret.Error(2)
+	return r0, r1, r2
+}
+
+// CreateVolume mocks godo.StorageService.CreateVolume.
+func (m *StorageService) CreateVolume(a0 context.Context, a1 *godo.VolumeCreateRequest) (*godo.Volume, *godo.Response, error) {
+	ret := m.Called("CreateVolume", a0, a1)
+	r0, _ := ret.Get(0).(*godo.Volume)
+	r1, _ := ret.Get(1).(*godo.Response)
+	r2 := ret.Error(2)
+	return r0, r1, r2
+}
+
+// DeleteVolume mocks godo.StorageService.DeleteVolume.
+func (m *StorageService) DeleteVolume(a0 context.Context, a1 string) (*godo.Response, error) {
+	ret := m.Called("DeleteVolume", a0, a1)
+	r0, _ := ret.Get(0).(*godo.Response)
+	r1 := ret.Error(1)
+	return r0, r1
+}
+
+// ListSnapshots mocks godo.StorageService.ListSnapshots.
+func (m *StorageService) ListSnapshots(a0 context.Context, a1 string, a2 *godo.ListOptions) ([]godo.Snapshot, *godo.Response, error) {
+	ret := m.Called("ListSnapshots", a0, a1, a2)
+	r0, _ := ret.Get(0).([]godo.Snapshot)
+	r1, _ := ret.Get(1).(*godo.Response)
+	r2 := ret.Error(2)
+	return r0, r1, r2
+}
+
+// GetSnapshot mocks godo.StorageService.GetSnapshot.
+func (m *StorageService) GetSnapshot(a0 context.Context, a1 string) (*godo.Snapshot, *godo.Response, error) {
+	ret := m.Called("GetSnapshot", a0, a1)
+	r0, _ := ret.Get(0).(*godo.Snapshot)
+	r1, _ := ret.Get(1).(*godo.Response)
+	r2 := ret.Error(2)
+	return r0, r1, r2
+}
+
+// CreateSnapshot mocks godo.StorageService.CreateSnapshot.
+func (m *StorageService) CreateSnapshot(a0 context.Context, a1 *godo.SnapshotCreateRequest) (*godo.Snapshot, *godo.Response, error) {
+	ret := m.Called("CreateSnapshot", a0, a1)
+	r0, _ := ret.Get(0).(*godo.Snapshot)
+	r1, _ := ret.Get(1).(*godo.Response)
+	r2 := ret.Error(2)
+	return r0, r1, r2
+}
+
+// DeleteSnapshot mocks godo.StorageService.DeleteSnapshot.
+func (m *StorageService) DeleteSnapshot(a0 context.Context, a1 string) (*godo.Response, error) {
+	ret := m.Called("DeleteSnapshot", a0, a1)
+	r0, _ := ret.Get(0).(*godo.Response)
+	r1 := ret.Error(1)
+	return r0, r1
+}
+
+// StorageActionsService is a mock of godo.StorageActionsService.
+type StorageActionsService struct {
+	Mock
+}
+
+var _ godo.StorageActionsService = (*StorageActionsService)(nil)
+
+// Attach mocks godo.StorageActionsService.Attach.
+func (m *StorageActionsService) Attach(a0 context.Context, a1 string, a2 int) (*godo.Action, *godo.Response, error) {
+	ret := m.Called("Attach", a0, a1, a2)
+	r0, _ := ret.Get(0).(*godo.Action)
+	r1, _ := ret.Get(1).(*godo.Response)
+	r2 := ret.Error(2)
+	return r0, r1, r2
+}
+
+// DetachByDropletID mocks godo.StorageActionsService.DetachByDropletID.
+func (m *StorageActionsService) DetachByDropletID(a0 context.Context, a1 string, a2 int) (*godo.Action, *godo.Response, error) {
+	ret := m.Called("DetachByDropletID", a0, a1, a2)
+	r0, _ := ret.Get(0).(*godo.Action)
+	r1, _ := ret.Get(1).(*godo.Response)
+	r2 := ret.Error(2)
+	return r0, r1, r2
+}
+
+// Get mocks godo.StorageActionsService.Get.
+func (m *StorageActionsService) Get(a0 context.Context, a1 string, a2 int) (*godo.Action, *godo.Response, error) {
+	ret := m.Called("Get", a0, a1, a2)
+	r0, _ := ret.Get(0).(*godo.Action)
+	r1, _ := ret.Get(1).(*godo.Response)
+	r2 := ret.Error(2)
+	return r0, r1, r2
+}
+
+// List mocks godo.StorageActionsService.List.
+func (m *StorageActionsService) List(a0 context.Context, a1 string, a2 *godo.ListOptions) ([]godo.Action, *godo.Response, error) {
+	ret := m.Called("List", a0, a1, a2)
+	r0, _ := ret.Get(0).([]godo.Action)
+	r1, _ := ret.Get(1).(*godo.Response)
+	r2 := ret.Error(2)
+	return r0, r1, r2
+}
+
+// Resize mocks godo.StorageActionsService.Resize.
+func (m *StorageActionsService) Resize(a0 context.Context, a1 string, a2 int, a3 string) (*godo.Action, *godo.Response, error) {
+	ret := m.Called("Resize", a0, a1, a2, a3)
+	r0, _ := ret.Get(0).(*godo.Action)
+	r1, _ := ret.Get(1).(*godo.Response)
+	r2 := ret.Error(2)
+	return r0, r1, r2
+}
+
+// TagsService is a mock of godo.TagsService.
+type TagsService struct {
+	Mock
+}
+
+var _ godo.TagsService = (*TagsService)(nil)
+
+// List mocks godo.TagsService.List.
+func (m *TagsService) List(a0 context.Context, a1 *godo.ListOptions) ([]godo.Tag, *godo.Response, error) {
+	ret := m.Called("List", a0, a1)
+	r0, _ := ret.Get(0).([]godo.Tag)
+	r1, _ := ret.Get(1).(*godo.Response)
+	r2 := ret.Error(2)
+	return r0, r1, r2
+}
+
+// Get mocks godo.TagsService.Get.
+func (m *TagsService) Get(a0 context.Context, a1 string) (*godo.Tag, *godo.Response, error) {
+	ret := m.Called("Get", a0, a1)
+	r0, _ := ret.Get(0).(*godo.Tag)
+	r1, _ := ret.Get(1).(*godo.Response)
+	r2 := ret.Error(2)
+	return r0, r1, r2
+}
+
+// Create mocks godo.TagsService.Create.
+func (m *TagsService) Create(a0 context.Context, a1 *godo.TagCreateRequest) (*godo.Tag, *godo.Response, error) {
+	ret := m.Called("Create", a0, a1)
+	r0, _ := ret.Get(0).(*godo.Tag)
+	r1, _ := ret.Get(1).(*godo.Response)
+	r2 := ret.Error(2)
+	return r0, r1, r2
+}
+
+// Delete mocks godo.TagsService.Delete.
+func (m *TagsService) Delete(a0 context.Context, a1 string) (*godo.Response, error) {
+	ret := m.Called("Delete", a0, a1)
+	r0, _ := ret.Get(0).(*godo.Response)
+	r1 := ret.Error(1)
+	return r0, r1
+}
+
+// TagResources mocks godo.TagsService.TagResources.
+func (m *TagsService) TagResources(a0 context.Context, a1 string, a2 *godo.TagResourcesRequest) (*godo.Response, error) {
+	ret := m.Called("TagResources", a0, a1, a2)
+	r0, _ := ret.Get(0).(*godo.Response)
+	r1 := ret.Error(1)
+	return r0, r1
+}
+
+// UntagResources mocks godo.TagsService.UntagResources.
+func (m *TagsService) UntagResources(a0 context.Context, a1 string, a2 *godo.UntagResourcesRequest) (*godo.Response, error) {
+	ret := m.Called("UntagResources", a0, a1, a2)
+	r0, _ := ret.Get(0).(*godo.Response)
+	r1 := ret.Error(1)
+	return r0, r1
+}
+
+// LoadBalancersService is a mock of godo.LoadBalancersService.
+type LoadBalancersService struct {
+	Mock
+}
+
+var _ godo.LoadBalancersService = (*LoadBalancersService)(nil)
+
+// Get mocks godo.LoadBalancersService.Get.
+func (m *LoadBalancersService) Get(a0 context.Context, a1 string) (*godo.LoadBalancer, *godo.Response, error) {
+	ret := m.Called("Get", a0, a1)
+	r0, _ := ret.Get(0).(*godo.LoadBalancer)
+	r1, _ := ret.Get(1).(*godo.Response)
+	r2 := ret.Error(2)
+	return r0, r1, r2
+}
+
+// List mocks godo.LoadBalancersService.List.
+func (m *LoadBalancersService) List(a0 context.Context, a1 *godo.ListOptions) ([]godo.LoadBalancer, *godo.Response, error) {
+	ret := m.Called("List", a0, a1)
+	r0, _ := ret.Get(0).([]godo.LoadBalancer)
+	r1, _ := ret.Get(1).(*godo.Response)
+	r2 := ret.Error(2)
+	return r0, r1, r2
+}
+
+// Create mocks godo.LoadBalancersService.Create.
+func (m *LoadBalancersService) Create(a0 context.Context, a1 *godo.LoadBalancerRequest) (*godo.LoadBalancer, *godo.Response, error) {
+	ret := m.Called("Create", a0, a1)
+	r0, _ := ret.Get(0).(*godo.LoadBalancer)
+	r1, _ := ret.Get(1).(*godo.Response)
+	r2 := ret.Error(2)
+	return r0, r1, r2
+}
+
+// Update mocks godo.LoadBalancersService.Update.
+func (m *LoadBalancersService) Update(a0 context.Context, a1 string, a2 *godo.LoadBalancerRequest) (*godo.LoadBalancer, *godo.Response, error) {
+	ret := m.Called("Update", a0, a1, a2)
+	r0, _ := ret.Get(0).(*godo.LoadBalancer)
+	r1, _ := ret.Get(1).(*godo.Response)
+	r2 := ret.Error(2)
+	return r0, r1, r2
+}
+
+// Delete mocks godo.LoadBalancersService.Delete.
+func (m *LoadBalancersService) Delete(a0 context.Context, a1 string) (*godo.Response, error) {
+	ret := m.Called("Delete", a0, a1)
+	r0, _ := ret.Get(0).(*godo.Response)
+	r1 := ret.Error(1)
+	return r0, r1
+}
+
+// AddDroplets mocks godo.LoadBalancersService.AddDroplets.
+func (m *LoadBalancersService) AddDroplets(a0 context.Context, a1 string, a2 ...int) (*godo.Response, error) {
+	ret := m.Called("AddDroplets", a0, a1, a2)
+	r0, _ := ret.Get(0).(*godo.Response)
+	r1 := ret.Error(1)
+	return r0, r1
+}
+
+// RemoveDroplets mocks godo.LoadBalancersService.RemoveDroplets.
+func (m *LoadBalancersService) RemoveDroplets(a0 context.Context, a1 string, a2 ...int) (*godo.Response, error) {
+	ret := m.Called("RemoveDroplets", a0, a1, a2)
+	r0, _ := ret.Get(0).(*godo.Response)
+	r1 := ret.Error(1)
+	return r0, r1
+}
+
+// AddForwardingRules mocks godo.LoadBalancersService.AddForwardingRules.
+func (m *LoadBalancersService) AddForwardingRules(a0 context.Context, a1 string, a2 ...godo.ForwardingRule) (*godo.Response, error) {
+	ret := m.Called("AddForwardingRules", a0, a1, a2)
+	r0, _ := ret.Get(0).(*godo.Response)
+	r1 := ret.Error(1)
+	return r0, r1
+}
+
+// RemoveForwardingRules mocks godo.LoadBalancersService.RemoveForwardingRules.
+func (m *LoadBalancersService) RemoveForwardingRules(a0 context.Context, a1 string, a2 ...godo.ForwardingRule) (*godo.Response, error) {
+	ret := m.Called("RemoveForwardingRules", a0, a1, a2)
+	r0, _ := ret.Get(0).(*godo.Response)
+	r1 := ret.Error(1)
+	return r0, r1
+}
+
+// CertificatesService is a mock of godo.CertificatesService.
+type CertificatesService struct {
+	Mock
+}
+
+var _ godo.CertificatesService = (*CertificatesService)(nil)
+
+// Get mocks godo.CertificatesService.Get.
+func (m *CertificatesService) Get(a0 context.Context, a1 string) (*godo.Certificate, *godo.Response, error) {
+	ret := m.Called("Get", a0, a1)
+	r0, _ := ret.Get(0).(*godo.Certificate)
+	r1, _ := ret.Get(1).(*godo.Response)
+	r2 := ret.Error(2)
+	return r0, r1, r2
+}
+
+// List mocks godo.CertificatesService.List.
+func (m *CertificatesService) List(a0 context.Context, a1 *godo.ListOptions) ([]godo.Certificate, *godo.Response, error) {
+	ret := m.Called("List", a0, a1)
+	r0, _ := ret.Get(0).([]godo.Certificate)
+	r1, _ := ret.Get(1).(*godo.Response)
+	r2 := ret.Error(2)
+	return r0, r1, r2
+}
+
+// Create mocks godo.CertificatesService.Create.
+func (m *CertificatesService) Create(a0 context.Context, a1 *godo.CertificateRequest) (*godo.Certificate, *godo.Response, error) {
+	ret := m.Called("Create", a0, a1)
+	r0, _ := ret.Get(0).(*godo.Certificate)
+	r1, _ := ret.Get(1).(*godo.Response)
+	r2 := ret.Error(2)
+	return r0, r1, r2
+}
+
+// Delete mocks godo.CertificatesService.Delete.
+func (m *CertificatesService) Delete(a0 context.Context, a1 string) (*godo.Response, error) {
+	ret := m.Called("Delete", a0, a1)
+	r0, _ := ret.Get(0).(*godo.Response)
+	r1 := ret.Error(1)
+	return r0, r1
+}
+
+// FirewallsService is a mock of godo.FirewallsService.
+type FirewallsService struct {
+	Mock
+}
+
+var _ godo.FirewallsService = (*FirewallsService)(nil)
+
+// Get mocks godo.FirewallsService.Get.
+func (m *FirewallsService) Get(a0 context.Context, a1 string) (*godo.Firewall, *godo.Response, error) {
+	ret := m.Called("Get", a0, a1)
+	r0, _ := ret.Get(0).(*godo.Firewall)
+	r1, _ := ret.Get(1).(*godo.Response)
+	r2 := ret.Error(2)
+	return r0, r1, r2
+}
+
+// Create mocks godo.FirewallsService.Create.
+func (m *FirewallsService) Create(a0 context.Context, a1 *godo.FirewallRequest) (*godo.Firewall, *godo.Response, error) {
+	ret := m.Called("Create", a0, a1)
+	r0, _ := ret.Get(0).(*godo.Firewall)
+	r1, _ := ret.Get(1).(*godo.Response)
+	r2 := ret.Error(2)
+	return r0, r1, r2
+}
+
+// Update mocks godo.FirewallsService.Update.
+func (m *FirewallsService) Update(a0 context.Context, a1 string, a2 *godo.FirewallRequest) (*godo.Firewall, *godo.Response, error) {
+	ret := m.Called("Update", a0, a1, a2)
+	r0, _ := ret.Get(0).(*godo.Firewall)
+	r1, _ := ret.Get(1).(*godo.Response)
+	r2 := ret.Error(2)
+	return r0, r1, r2
+}
+
+// Delete mocks godo.FirewallsService.Delete.
+func (m *FirewallsService) Delete(a0 context.Context, a1 string) (*godo.Response, error) {
+	ret := m.Called("Delete", a0, a1)
+	r0, _ := ret.Get(0).(*godo.Response)
+	r1 := ret.Error(1)
+	return r0, r1
+}
+
+// List mocks godo.FirewallsService.List.
+func (m *FirewallsService) List(a0 context.Context, a1 *godo.ListOptions) ([]godo.Firewall, *godo.Response, error) {
+	ret := m.Called("List", a0, a1)
+	r0, _ := ret.Get(0).([]godo.Firewall)
+	r1, _ := ret.Get(1).(*godo.Response)
+	r2 := ret.Error(2)
+	return r0, r1, r2
+}
+
+// ListByDroplet mocks godo.FirewallsService.ListByDroplet.
+func (m *FirewallsService) ListByDroplet(a0 context.Context, a1 int, a2 *godo.ListOptions) ([]godo.Firewall, *godo.Response, error) {
+	ret := m.Called("ListByDroplet", a0, a1, a2)
+	r0, _ := ret.Get(0).([]godo.Firewall)
+	r1, _ := ret.Get(1).(*godo.Response)
+	r2 := ret.Error(2)
+	return r0, r1, r2
+}
+
+// AddDroplets mocks godo.FirewallsService.AddDroplets.
+func (m *FirewallsService) AddDroplets(a0 context.Context, a1 string, a2 ...int) (*godo.Response, error) {
+	ret := m.Called("AddDroplets", a0, a1, a2)
+	r0, _ := ret.Get(0).(*godo.Response)
+	r1 := ret.Error(1)
+	return r0, r1
+}
+
+// RemoveDroplets mocks godo.FirewallsService.RemoveDroplets.
+func (m *FirewallsService) RemoveDroplets(a0 context.Context, a1 string, a2 ...int) (*godo.Response, error) {
+	ret := m.Called("RemoveDroplets", a0, a1, a2)
+	r0, _ := ret.Get(0).(*godo.Response)
+	r1 := ret.Error(1)
+	return r0, r1
+}
+
+// AddTags mocks godo.FirewallsService.AddTags.
+func (m *FirewallsService) AddTags(a0 context.Context, a1 string, a2 ...string) (*godo.Response, error) {
+	ret := m.Called("AddTags", a0, a1, a2)
+	r0, _ := ret.Get(0).(*godo.Response)
+	r1 := ret.Error(1)
+	return r0, r1
+}
+
+// RemoveTags mocks godo.FirewallsService.RemoveTags.
+func (m *FirewallsService) RemoveTags(a0 context.Context, a1 string, a2 ...string) (*godo.Response, error) {
+	ret := m.Called("RemoveTags", a0, a1, a2)
+	r0, _ := ret.Get(0).(*godo.Response)
+	r1 := ret.Error(1)
+	return r0, r1
+}
+
+// AddRules mocks godo.FirewallsService.AddRules.
+func (m *FirewallsService) AddRules(a0 context.Context, a1 string, a2 *godo.FirewallRulesRequest) (*godo.Response, error) {
+	ret := m.Called("AddRules", a0, a1, a2)
+	r0, _ := ret.Get(0).(*godo.Response)
+	r1 := ret.Error(1)
+	return r0, r1
+}
+
+// RemoveRules mocks godo.FirewallsService.RemoveRules.
+func (m *FirewallsService) RemoveRules(a0 context.Context, a1 string, a2 *godo.FirewallRulesRequest) (*godo.Response, error) {
+	ret := m.Called("RemoveRules", a0, a1, a2)
+	r0, _ := ret.Get(0).(*godo.Response)
+	r1 := ret.Error(1)
+	return r0, r1
+}
+
+// ProjectsService is a mock of godo.ProjectsService.
+type ProjectsService struct {
+	Mock
+}
+
+var _ godo.ProjectsService = (*ProjectsService)(nil)
+
+// List mocks godo.ProjectsService.List.
+func (m *ProjectsService) List(a0 context.Context, a1 *godo.ListOptions) ([]godo.Project, *godo.Response, error) {
+	ret := m.Called("List", a0, a1)
+	r0, _ := ret.Get(0).([]godo.Project)
+	r1, _ := ret.Get(1).(*godo.Response)
+	r2 := ret.Error(2)
+	return r0, r1, r2
+}
+
+// GetDefault mocks godo.ProjectsService.GetDefault.
+func (m *ProjectsService) GetDefault(a0 context.Context) (*godo.Project, *godo.Response, error) {
+	ret := m.Called("GetDefault", a0)
+	r0, _ := ret.Get(0).(*godo.Project)
+	r1, _ := ret.Get(1).(*godo.Response)
+	r2 := ret.Error(2)
+	return r0, r1, r2
+}
+
+// Get mocks godo.ProjectsService.Get.
+func (m *ProjectsService) Get(a0 context.Context, a1 string) (*godo.Project, *godo.Response, error) {
+	ret := m.Called("Get", a0, a1)
+	r0, _ := ret.Get(0).(*godo.Project)
+	r1, _ := ret.Get(1).(*godo.Response)
+	r2 := ret.Error(2)
+	return r0, r1, r2
+}
+
+// Create mocks godo.ProjectsService.Create.
+func (m *ProjectsService) Create(a0 context.Context, a1 *godo.CreateProjectRequest) (*godo.Project, *godo.Response, error) {
+	ret := m.Called("Create", a0, a1)
+	r0, _ := ret.Get(0).(*godo.Project)
+	r1, _ := ret.Get(1).(*godo.Response)
+	r2 := ret.Error(2)
+	return r0, r1, r2
+}
+
+// Update mocks godo.ProjectsService.Update.
+func (m *ProjectsService) Update(a0 context.Context, a1 string, a2 *godo.UpdateProjectRequest) (*godo.Project, *godo.Response, error) {
+	ret := m.Called("Update", a0, a1, a2)
+	r0, _ := ret.Get(0).(*godo.Project)
+	r1, _ := ret.Get(1).(*godo.Response)
+	r2 := ret.Error(2)
+	return r0, r1, r2
+}
+
+// Delete mocks godo.ProjectsService.Delete.
+func (m *ProjectsService) Delete(a0 context.Context, a1 string) (*godo.Response, error) {
+	ret := m.Called("Delete", a0, a1)
+	r0, _ := ret.Get(0).(*godo.Response)
+	r1 := ret.Error(1)
+	return r0, r1
+}
+
+// ListResources mocks godo.ProjectsService.ListResources.
+func (m *ProjectsService) ListResources(a0 context.Context, a1 string, a2 *godo.ListOptions) ([]godo.ProjectResource, *godo.Response, error) {
+	ret := m.Called("ListResources", a0, a1, a2)
+	r0, _ := ret.Get(0).([]godo.ProjectResource)
+	r1, _ := ret.Get(1).(*godo.Response)
+	r2 := ret.Error(2)
+	return r0, r1, r2
+}
+
+// AssignResources mocks godo.ProjectsService.AssignResources.
+func (m *ProjectsService) AssignResources(a0 context.Context, a1 string, a2 ...interface{}) ([]godo.ProjectResource, *godo.Response, error) {
+	ret := m.Called("AssignResources", a0, a1, a2)
+	r0, _ := ret.Get(0).([]godo.ProjectResource)
+	r1, _ := ret.Get(1).(*godo.Response)
+	r2 := ret.Error(2)
+	return r0, r1, r2
+}
+
+// KubernetesService is a mock of godo.KubernetesService.
+type KubernetesService struct {
+	Mock
+}
+
+var _ godo.KubernetesService = (*KubernetesService)(nil)
+
+// Create mocks godo.KubernetesService.Create.
+func (m *KubernetesService) Create(a0 context.Context, a1 *godo.KubernetesClusterCreateRequest) (*godo.KubernetesCluster, *godo.Response, error) {
+	ret := m.Called("Create", a0, a1)
+	r0, _ := ret.Get(0).(*godo.KubernetesCluster)
+	r1, _ := ret.Get(1).(*godo.Response)
+	r2 := ret.Error(2)
+	return r0, r1, r2
+}
+
+// Get mocks godo.KubernetesService.Get.
+func (m *KubernetesService) Get(a0 context.Context, a1 string) (*godo.KubernetesCluster, *godo.Response, error) {
+	ret := m.Called("Get", a0, a1)
+	r0, _ := ret.Get(0).(*godo.KubernetesCluster)
+	r1, _ := ret.Get(1).(*godo.Response)
+	r2 := ret.Error(2)
+	return r0, r1, r2
+}
+
+// GetUser mocks godo.KubernetesService.GetUser.
+func (m *KubernetesService) GetUser(a0 context.Context, a1 string) (*godo.KubernetesClusterUser, *godo.Response, error) {
+	ret := m.Called("GetUser", a0, a1)
+	r0, _ := ret.Get(0).(*godo.KubernetesClusterUser)
+	r1, _ := ret.Get(1).(*godo.Response)
+	r2 := ret.Error(2)
+	return r0, r1, r2
+}
+
+// GetUpgrades mocks godo.KubernetesService.GetUpgrades.
+func (m *KubernetesService) GetUpgrades(a0 context.Context, a1 string) ([]*godo.KubernetesVersion, *godo.Response, error) {
+	ret := m.Called("GetUpgrades", a0, a1)
+	r0, _ := ret.Get(0).([]*godo.KubernetesVersion)
+	r1, _ := ret.Get(1).(*godo.Response)
+	r2 := ret.Error(2)
+	return r0, r1, r2
+}
+
+// GetKubeConfig mocks godo.KubernetesService.GetKubeConfig.
+func (m *KubernetesService) GetKubeConfig(a0 context.Context, a1 string) (*godo.KubernetesClusterConfig, *godo.Response, error) {
+	ret := m.Called("GetKubeConfig", a0, a1)
+	r0, _ := ret.Get(0).(*godo.KubernetesClusterConfig)
+	r1, _ := ret.Get(1).(*godo.Response)
+	r2 := ret.Error(2)
+	return r0, r1, r2
+}
+
+// GetCredentials mocks godo.KubernetesService.GetCredentials.
+func (m *KubernetesService) GetCredentials(a0 context.Context, a1 string, a2 *godo.KubernetesClusterCredentialsGetRequest) (*godo.KubernetesClusterCredentials, *godo.Response, error) {
+	ret := m.Called("GetCredentials", a0, a1, a2)
+	r0, _ := ret.Get(0).(*godo.KubernetesClusterCredentials)
+	r1, _ := ret.Get(1).(*godo.Response)
+	r2 := ret.Error(2)
+	return r0, r1, r2
+}
+
+// List mocks godo.KubernetesService.List.
+func (m *KubernetesService) List(a0 context.Context, a1 *godo.ListOptions) ([]*godo.KubernetesCluster, *godo.Response, error) {
+	ret := m.Called("List", a0, a1)
+	r0, _ := ret.Get(0).([]*godo.KubernetesCluster)
+	r1, _ := ret.Get(1).(*godo.Response)
+	r2 := ret.Error(2)
+	return r0, r1, r2
+}
+
+// Update mocks godo.KubernetesService.Update.
+func (m *KubernetesService) Update(a0 context.Context, a1 string, a2 *godo.KubernetesClusterUpdateRequest) (*godo.KubernetesCluster, *godo.Response, error) {
+	ret := m.Called("Update", a0, a1, a2)
+	r0, _ := ret.Get(0).(*godo.KubernetesCluster)
+	r1, _ := ret.Get(1).(*godo.Response)
+	r2 := ret.Error(2)
+	return r0, r1, r2
+}
+
+// Upgrade mocks godo.KubernetesService.Upgrade.
+func (m *KubernetesService) Upgrade(a0 context.Context, a1 string, a2 *godo.KubernetesClusterUpgradeRequest) (*godo.Response, error) {
+	ret := m.Called("Upgrade", a0, a1, a2)
+	r0, _ := ret.Get(0).(*godo.Response)
+	r1 := ret.Error(1)
+	return r0, r1
+}
+
+// Delete mocks godo.KubernetesService.Delete.
+func (m *KubernetesService) Delete(a0 context.Context, a1 string) (*godo.Response, error) {
+	ret := m.Called("Delete", a0, a1)
+	r0, _ := ret.Get(0).(*godo.Response)
+	r1 := ret.Error(1)
+	return r0, r1
+}
+
+// CreateNodePool mocks godo.KubernetesService.CreateNodePool.
+func (m *KubernetesService) CreateNodePool(a0 context.Context, a1 string, a2 *godo.KubernetesNodePoolCreateRequest) (*godo.KubernetesNodePool, *godo.Response, error) {
+	ret := m.Called("CreateNodePool", a0, a1, a2)
+	r0, _ := ret.Get(0).(*godo.KubernetesNodePool)
+	r1, _ := ret.Get(1).(*godo.Response)
+	r2 := ret.Error(2)
+	return r0, r1, r2
+}
+
+// GetNodePool mocks godo.KubernetesService.GetNodePool.
+func (m *KubernetesService) GetNodePool(a0 context.Context, a1 string, a2 string) (*godo.KubernetesNodePool, *godo.Response, error) {
+	ret := m.Called("GetNodePool", a0, a1, a2)
+	r0, _ := ret.Get(0).(*godo.KubernetesNodePool)
+	r1, _ := ret.Get(1).(*godo.Response)
+	r2 := ret.Error(2)
+	return r0, r1, r2
+}
+
+// ListNodePools mocks godo.KubernetesService.ListNodePools.
+func (m *KubernetesService) ListNodePools(a0 context.Context, a1 string, a2 *godo.ListOptions) ([]*godo.KubernetesNodePool, *godo.Response, error) {
+	ret := m.Called("ListNodePools", a0, a1, a2)
+	r0, _ := ret.Get(0).([]*godo.KubernetesNodePool)
+	r1, _ := ret.Get(1).(*godo.Response)
+	r2 := ret.Error(2)
+	return r0, r1, r2
+}
+
+// UpdateNodePool mocks godo.KubernetesService.UpdateNodePool.
+func (m *KubernetesService) UpdateNodePool(a0 context.Context, a1 string, a2 string, a3 *godo.KubernetesNodePoolUpdateRequest) (*godo.KubernetesNodePool, *godo.Response, error) {
+	ret := m.Called("UpdateNodePool", a0, a1, a2, a3)
+	r0, _ := ret.Get(0).(*godo.KubernetesNodePool)
+	r1, _ := ret.Get(1).(*godo.Response)
+	r2 := ret.Error(2)
+	return r0, r1, r2
+}
+
+// RecycleNodePoolNodes mocks godo.KubernetesService.RecycleNodePoolNodes.
+func (m *KubernetesService) RecycleNodePoolNodes(a0 context.Context, a1 string, a2 string, a3 *godo.KubernetesNodePoolRecycleNodesRequest) (*godo.Response, error) {
+	ret := m.Called("RecycleNodePoolNodes", a0, a1, a2, a3)
+	r0, _ := ret.Get(0).(*godo.Response)
+	r1 := ret.Error(1)
+	return r0, r1
+}
+
+// DeleteNodePool mocks godo.KubernetesService.DeleteNodePool.
+func (m *KubernetesService) DeleteNodePool(a0 context.Context, a1 string, a2 string) (*godo.Response, error) {
+	ret := m.Called("DeleteNodePool", a0, a1, a2)
+	r0, _ := ret.Get(0).(*godo.Response)
+	r1 := ret.Error(1)
+	return r0, r1
+}
+
+// DeleteNode mocks godo.KubernetesService.DeleteNode.
+func (m *KubernetesService) DeleteNode(a0 context.Context, a1 string, a2 string, a3 string, a4 *godo.KubernetesNodeDeleteRequest) (*godo.Response, error) {
+	ret := m.Called("DeleteNode", a0, a1, a2, a3, a4)
+	r0, _ := ret.Get(0).(*godo.Response)
+	r1 := ret.Error(1)
+	return r0, r1
+}
+
+// GetOptions mocks godo.KubernetesService.GetOptions.
+func (m *KubernetesService) GetOptions(a0 context.Context) (*godo.KubernetesOptions, *godo.Response, error) {
+	ret := m.Called("GetOptions", a0)
+	r0, _ := ret.Get(0).(*godo.KubernetesOptions)
+	r1, _ := ret.Get(1).(*godo.Response)
+	r2 := ret.Error(2)
+	return r0, r1, r2
+}
+
+// RegistryService is a mock of godo.RegistryService.
+type RegistryService struct {
+	Mock
+}
+
+var _ godo.RegistryService = (*RegistryService)(nil)
+
+// Create mocks godo.RegistryService.Create.
+func (m *RegistryService) Create(a0 context.Context, a1 *godo.RegistryCreateRequest) (*godo.Registry, *godo.Response, error) {
+	ret := m.Called("Create", a0, a1)
+	r0, _ := ret.Get(0).(*godo.Registry)
+	r1, _ := ret.Get(1).(*godo.Response)
+	r2 := ret.Error(2)
+	return r0, r1, r2
+}
+
+// Get mocks godo.RegistryService.Get.
+func (m *RegistryService) Get(a0 context.Context) (*godo.Registry, *godo.Response, error) {
+	ret := m.Called("Get", a0)
+	r0, _ := ret.Get(0).(*godo.Registry)
+	r1, _ := ret.Get(1).(*godo.Response)
+	r2 := ret.Error(2)
+	return r0, r1, r2
+}
+
+// Delete mocks godo.RegistryService.Delete.
+func (m *RegistryService) Delete(a0 context.Context) (*godo.Response, error) {
+	ret := m.Called("Delete", a0)
+	r0, _ := ret.Get(0).(*godo.Response)
+	r1 := ret.Error(1)
+	return r0, r1
+}
+
+// DockerCredentials mocks godo.RegistryService.DockerCredentials.
+func (m *RegistryService) DockerCredentials(a0 context.Context, a1 *godo.RegistryDockerCredentialsRequest) (*godo.DockerCredentials, *godo.Response, error) {
+	ret := m.Called("DockerCredentials", a0, a1)
+	r0, _ := ret.Get(0).(*godo.DockerCredentials)
+	r1, _ := ret.Get(1).(*godo.Response)
+	r2 := ret.Error(2)
+	return r0, r1, r2
+}
+
+// ListRepositories mocks godo.RegistryService.ListRepositories.
+func (m *RegistryService) ListRepositories(a0 context.Context, a1 string, a2 *godo.ListOptions) ([]*godo.Repository, *godo.Response, error) {
+	ret := m.Called("ListRepositories", a0, a1, a2)
+	r0, _ := ret.Get(0).([]*godo.Repository)
+	r1, _ := ret.Get(1).(*godo.Response)
+	r2 := ret.Error(2)
+	return r0, r1, r2
+}
+
+// ListRepositoryTags mocks godo.RegistryService.ListRepositoryTags.
+func (m *RegistryService) ListRepositoryTags(a0 context.Context, a1 string, a2 string, a3 *godo.ListOptions) ([]*godo.RepositoryTag, *godo.Response, error) {
+	ret := m.Called("ListRepositoryTags", a0, a1, a2, a3)
+	r0, _ := ret.Get(0).([]*godo.RepositoryTag)
+	r1, _ := ret.Get(1).(*godo.Response)
+	r2 := ret.Error(2)
+	return r0, r1, r2
+}
+
+// DeleteTag mocks godo.RegistryService.DeleteTag.
+func (m *RegistryService) DeleteTag(a0 context.Context, a1 string, a2 string, a3 string) (*godo.Response, error) {
+	ret := m.Called("DeleteTag", a0, a1, a2, a3)
+	r0, _ := ret.Get(0).(*godo.Response)
+	r1 := ret.Error(1)
+	return r0, r1
+}
+
+// DeleteManifest mocks godo.RegistryService.DeleteManifest.
+func (m *RegistryService) DeleteManifest(a0 context.Context, a1 string, a2 string, a3 string) (*godo.Response, error) {
+	ret := m.Called("DeleteManifest", a0, a1, a2, a3)
+	r0, _ := ret.Get(0).(*godo.Response)
+	r1 := ret.Error(1)
+	return r0, r1
+}
+
+// DatabasesService is a mock of godo.DatabasesService.
+type DatabasesService struct {
+	Mock
+}
+
+var _ godo.DatabasesService = (*DatabasesService)(nil)
+
+// List mocks godo.DatabasesService.List.
+func (m *DatabasesService) List(a0 context.Context, a1 *godo.ListOptions) ([]godo.Database, *godo.Response, error) {
+	ret := m.Called("List", a0, a1)
+	r0, _ := ret.Get(0).([]godo.Database)
+	r1, _ := ret.Get(1).(*godo.Response)
+	r2 := ret.Error(2)
+	return r0, r1, r2
+}
+
+// Get mocks godo.DatabasesService.Get.
+func (m *DatabasesService) Get(a0 context.Context, a1 string) (*godo.Database, *godo.Response, error) {
+	ret := m.Called("Get", a0, a1)
+	r0, _ := ret.Get(0).(*godo.Database)
+	r1, _ := ret.Get(1).(*godo.Response)
+	r2 := ret.Error(2)
+	return r0, r1, r2
+}
+
+// Create mocks godo.DatabasesService.Create.
+func (m *DatabasesService) Create(a0 context.Context, a1 *godo.DatabaseCreateRequest) (*godo.Database, *godo.Response, error) {
+	ret := m.Called("Create", a0, a1)
+	r0, _ := ret.Get(0).(*godo.Database)
+	r1, _ := ret.Get(1).(*godo.Response)
+	r2 := ret.Error(2)
+	return r0, r1, r2
+}
+
+// Delete mocks godo.DatabasesService.Delete.
+func (m *DatabasesService) Delete(a0 context.Context, a1 string) (*godo.Response, error) {
+	ret := m.Called("Delete", a0, a1)
+	r0, _ := ret.Get(0).(*godo.Response)
+	r1 := ret.Error(1)
+	return r0, r1
+}
+
+// Resize mocks godo.DatabasesService.Resize.
+func (m *DatabasesService) Resize(a0 context.Context, a1 string, a2 *godo.DatabaseResizeRequest) (*godo.Response, error) {
+	ret := m.Called("Resize", a0, a1, a2)
+	r0, _ := ret.Get(0).(*godo.Response)
+	r1 := ret.Error(1)
+	return r0, r1
+}
+
+// Migrate mocks godo.DatabasesService.Migrate.
+func (m *DatabasesService) Migrate(a0 context.Context, a1 string, a2 *godo.DatabaseMigrateRequest) (*godo.Response, error) {
+	ret := m.Called("Migrate", a0, a1, a2)
+	r0, _ := ret.Get(0).(*godo.Response)
+	r1 := ret.Error(1)
+	return r0, r1
+}
+
+// UpdateMaintenance mocks godo.DatabasesService.UpdateMaintenance.
+func (m *DatabasesService) UpdateMaintenance(a0 context.Context, a1 string, a2 *godo.DatabaseUpdateMaintenanceRequest) (*godo.Response, error) {
+	ret := m.Called("UpdateMaintenance", a0, a1, a2)
+	r0, _ := ret.Get(0).(*godo.Response)
+	r1 := ret.Error(1)
+	return r0, r1
+}
+
+// ListBackups mocks godo.DatabasesService.ListBackups.
+func (m *DatabasesService) ListBackups(a0 context.Context, a1 string, a2 *godo.ListOptions) ([]godo.DatabaseBackup, *godo.Response, error) {
+	ret := m.Called("ListBackups", a0, a1, a2)
+	r0, _ := ret.Get(0).([]godo.DatabaseBackup)
+	r1, _ := ret.Get(1).(*godo.Response)
+	r2 := ret.Error(2)
+	return r0, r1, r2
+}
+
+// GetUser mocks godo.DatabasesService.GetUser.
+func (m *DatabasesService) GetUser(a0 context.Context, a1 string, a2 string) (*godo.DatabaseUser, *godo.Response, error) {
+	ret := m.Called("GetUser", a0, a1, a2)
+	r0, _ := ret.Get(0).(*godo.DatabaseUser)
+	r1, _ := ret.Get(1).(*godo.Response)
+	r2 := ret.Error(2)
+	return r0, r1, r2
+}
+
+// ListUsers mocks godo.DatabasesService.ListUsers.
+func (m *DatabasesService) ListUsers(a0 context.Context, a1 string, a2 *godo.ListOptions) ([]godo.DatabaseUser, *godo.Response, error) {
+	ret := m.Called("ListUsers", a0, a1, a2)
+	r0, _ := ret.Get(0).([]godo.DatabaseUser)
+	r1, _ := ret.Get(1).(*godo.Response)
+	r2 := ret.Error(2)
+	return r0, r1, r2
+}
+
+// CreateUser mocks godo.DatabasesService.CreateUser.
+func (m *DatabasesService) CreateUser(a0 context.Context, a1 string, a2 *godo.DatabaseCreateUserRequest) (*godo.DatabaseUser, *godo.Response, error) {
+	ret := m.Called("CreateUser", a0, a1, a2)
+	r0, _ := ret.Get(0).(*godo.DatabaseUser)
+	r1, _ := ret.Get(1).(*godo.Response)
+	r2 := ret.Error(2)
+	return r0, r1, r2
+}
+
+// DeleteUser mocks godo.DatabasesService.DeleteUser.
+func (m *DatabasesService) DeleteUser(a0 context.Context, a1 string, a2 string) (*godo.Response, error) {
+	ret := m.Called("DeleteUser", a0, a1, a2)
+	r0, _ := ret.Get(0).(*godo.Response)
+	r1 := ret.Error(1)
+	return r0, r1
+}
+
+// ResetUserAuth mocks godo.DatabasesService.ResetUserAuth.
+func (m *DatabasesService) ResetUserAuth(a0 context.Context, a1 string, a2 string, a3 *godo.DatabaseResetUserAuthRequest) (*godo.DatabaseUser, *godo.Response, error) {
+	ret := m.Called("ResetUserAuth", a0, a1, a2, a3)
+	r0, _ := ret.Get(0).(*godo.DatabaseUser)
+	r1, _ := ret.Get(1).(*godo.Response)
+	r2 := ret.Error(2)
+	return r0, r1, r2
+}
+
+// ListDBs mocks godo.DatabasesService.ListDBs.
+func (m *DatabasesService) ListDBs(a0 context.Context, a1 string, a2 *godo.ListOptions) ([]godo.DatabaseDB, *godo.Response, error) {
+	ret := m.Called("ListDBs", a0, a1, a2)
+	r0, _ := ret.Get(0).([]godo.DatabaseDB)
+	r1, _ := ret.Get(1).(*godo.Response)
+	r2 := ret.Error(2)
+	return r0, r1, r2
+}
+
+// CreateDB mocks godo.DatabasesService.CreateDB.
+func (m *DatabasesService) CreateDB(a0 context.Context, a1 string, a2 *godo.DatabaseCreateDBRequest) (*godo.DatabaseDB, *godo.Response, error) {
+	ret := m.Called("CreateDB", a0, a1, a2)
+	r0, _ := ret.Get(0).(*godo.DatabaseDB)
+	r1, _ := ret.Get(1).(*godo.Response)
+	r2 := ret.Error(2)
+	return r0, r1, r2
+}
+
+// GetDB mocks godo.DatabasesService.GetDB.
+func (m *DatabasesService) GetDB(a0 context.Context, a1 string, a2 string) (*godo.DatabaseDB, *godo.Response, error) {
+	ret := m.Called("GetDB", a0, a1, a2)
+	r0, _ := ret.Get(0).(*godo.DatabaseDB)
+	r1, _ := ret.Get(1).(*godo.Response)
+	r2 := ret.Error(2)
+	return r0, r1, r2
+}
+
+// DeleteDB mocks godo.DatabasesService.DeleteDB.
+func (m *DatabasesService) DeleteDB(a0 context.Context, a1 string, a2 string) (*godo.Response, error) {
+	ret := m.Called("DeleteDB", a0, a1, a2)
+	r0, _ := ret.Get(0).(*godo.Response)
+	r1 := ret.Error(1)
+	return r0, r1
+}
+
+// ListPools mocks godo.DatabasesService.ListPools.
+func (m *DatabasesService) ListPools(a0 context.Context, a1 string, a2 *godo.ListOptions) ([]godo.DatabasePool, *godo.Response, error) {
+	ret := m.Called("ListPools", a0, a1, a2)
+	r0, _ := ret.Get(0).([]godo.DatabasePool)
+	r1, _ := ret.Get(1).(*godo.Response)
+	r2 := ret.Error(2)
+	return r0, r1, r2
+}
+
+// CreatePool mocks godo.DatabasesService.CreatePool.
+func (m *DatabasesService) CreatePool(a0 context.Context, a1 string, a2 *godo.DatabaseCreatePoolRequest) (*godo.DatabasePool, *godo.Response, error) {
+	ret := m.Called("CreatePool", a0, a1, a2)
+	r0, _ := ret.Get(0).(*godo.DatabasePool)
+	r1, _ := ret.Get(1).(*godo.Response)
+	r2 := ret.Error(2)
+	return r0, r1, r2
+}
+
+// GetPool mocks godo.DatabasesService.GetPool.
+func (m *DatabasesService) GetPool(a0 context.Context, a1 string, a2 string) (*godo.DatabasePool, *godo.Response, error) {
+	ret := m.Called("GetPool", a0, a1, a2)
+	r0, _ := ret.Get(0).(*godo.DatabasePool)
+	r1, _ := ret.Get(1).(*godo.Response)
+	r2 := ret.Error(2)
+	return r0, r1, r2
+}
+
+// DeletePool mocks godo.DatabasesService.DeletePool.
+func (m *DatabasesService) DeletePool(a0 context.Context, a1 string, a2 string) (*godo.Response, error) {
+	ret := m.Called("DeletePool", a0, a1, a2)
+	r0, _ := ret.Get(0).(*godo.Response)
+	r1 := ret.Error(1)
+	return r0, r1
+}
+
+// GetReplica mocks godo.DatabasesService.GetReplica.
+func (m *DatabasesService) GetReplica(a0 context.Context, a1 string, a2 string) (*godo.DatabaseReplica, *godo.Response, error) {
+	ret := m.Called("GetReplica", a0, a1, a2)
+	r0, _ := ret.Get(0).(*godo.DatabaseReplica)
+	r1, _ := ret.Get(1).(*godo.Response)
+	r2 := ret.Error(2)
+	return r0, r1, r2
+}
+
+// ListReplicas mocks godo.DatabasesService.ListReplicas.
+func (m *DatabasesService) ListReplicas(a0 context.Context, a1 string, a2 *godo.ListOptions) ([]godo.DatabaseReplica, *godo.Response, error) {
+	ret := m.Called("ListReplicas", a0, a1, a2)
+	r0, _ := ret.Get(0).([]godo.DatabaseReplica)
+	r1, _ := ret.Get(1).(*godo.Response)
+	r2 := ret.Error(2)
+	return r0, r1, r2
+}
+
+// CreateReplica mocks godo.DatabasesService.CreateReplica.
+func (m *DatabasesService) CreateReplica(a0 context.Context, a1 string, a2 *godo.DatabaseCreateReplicaRequest) (*godo.DatabaseReplica, *godo.Response, error) {
+	ret := m.Called("CreateReplica", a0, a1, a2)
+	r0, _ := ret.Get(0).(*godo.DatabaseReplica)
+	r1, _ := ret.Get(1).(*godo.Response)
+	r2 := ret.Error(2)
+	return r0, r1, r2
+}
+
+// DeleteReplica mocks godo.DatabasesService.DeleteReplica.
+func (m *DatabasesService) DeleteReplica(a0 context.Context, a1 string, a2 string) (*godo.Response, error) {
+	ret := m.Called("DeleteReplica", a0, a1, a2)
+	r0, _ := ret.Get(0).(*godo.Response)
+	r1 := ret.Error(1)
+	return r0, r1
+}
+
+// GetEvictionPolicy mocks godo.DatabasesService.GetEvictionPolicy.
+func (m *DatabasesService) GetEvictionPolicy(a0 context.Context, a1 string) (string, *godo.Response, error) {
+	ret := m.Called("GetEvictionPolicy", a0, a1)
+	r0, _ := ret.Get(0).(string)
+	r1, _ := ret.Get(1).(*godo.Response)
+	r2 := ret.Error(2)
+	return r0, r1, r2
+}
+
+// SetEvictionPolicy mocks godo.DatabasesService.SetEvictionPolicy.
+func (m *DatabasesService) SetEvictionPolicy(a0 context.Context, a1 string, a2 string) (*godo.Response, error) {
+	ret := m.Called("SetEvictionPolicy", a0, a1, a2)
+	r0, _ := ret.Get(0).(*godo.Response)
+	r1 := ret.Error(1)
+	return r0, r1
+}
+
+// GetSQLMode mocks godo.DatabasesService.GetSQLMode.
+func (m *DatabasesService) GetSQLMode(a0 context.Context, a1 string) (string, *godo.Response, error) {
+	ret := m.Called("GetSQLMode", a0, a1)
+	r0, _ := ret.Get(0).(string)
+	r1, _ := ret.Get(1).(*godo.Response)
+	r2 := ret.Error(2)
+	return r0, r1, r2
+}
+
+// SetSQLMode mocks godo.DatabasesService.SetSQLMode.
+func (m *DatabasesService) SetSQLMode(a0 context.Context, a1 string, a2 ...string) (*godo.Response, error) {
+	ret := m.Called("SetSQLMode", a0, a1, a2)
+	r0, _ := ret.Get(0).(*godo.Response)
+	r1 := ret.Error(1)
+	return r0, r1
+}
+
+// GetFirewallRules mocks godo.DatabasesService.GetFirewallRules.
+func (m *DatabasesService) GetFirewallRules(a0 context.Context, a1 string) ([]godo.DatabaseFirewallRule, *godo.Response, error) {
+	ret := m.Called("GetFirewallRules", a0, a1)
+	r0, _ := ret.Get(0).([]godo.DatabaseFirewallRule)
+	r1, _ := ret.Get(1).(*godo.Response)
+	r2 := ret.Error(2)
+	return r0, r1, r2
+}
+
+// UpdateFirewallRules mocks godo.DatabasesService.UpdateFirewallRules.
+func (m *DatabasesService) UpdateFirewallRules(a0 context.Context, a1 string, a2 *godo.DatabaseUpdateFirewallRulesRequest) (*godo.Response, error) {
+	ret := m.Called("UpdateFirewallRules", a0, a1, a2)
+	r0, _ := ret.Get(0).(*godo.Response)
+	r1 := ret.Error(1)
+	return r0, r1
+}
+
+// VPCsService is a mock of godo.VPCsService.
+type VPCsService struct {
+	Mock
+}
+
+var _ godo.VPCsService = (*VPCsService)(nil)
+
+// Create mocks godo.VPCsService.Create.
+func (m *VPCsService) Create(a0 context.Context, a1 *godo.VPCCreateRequest) (*godo.VPC, *godo.Response, error) {
+	ret := m.Called("Create", a0, a1)
+	r0, _ := ret.Get(0).(*godo.VPC)
+	r1, _ := ret.Get(1).(*godo.Response)
+	r2 := ret.Error(2)
+	return r0, r1, r2
+}
+
+// Get mocks godo.VPCsService.Get.
+func (m *VPCsService) Get(a0 context.Context, a1 string) (*godo.VPC, *godo.Response, error) {
+	ret := m.Called("Get", a0, a1)
+	r0, _ := ret.Get(0).(*godo.VPC)
+	r1, _ := ret.Get(1).(*godo.Response)
+	r2 := ret.Error(2)
+	return r0, r1, r2
+}
+
+// List mocks godo.VPCsService.List.
+func (m *VPCsService) List(a0 context.Context, a1 *godo.ListOptions) ([]*godo.VPC, *godo.Response, error) {
+	ret := m.Called("List", a0, a1)
+	r0, _ := ret.Get(0).([]*godo.VPC)
+	r1, _ := ret.Get(1).(*godo.Response)
+	r2 := ret.Error(2)
+	return r0, r1, r2
+}
+
+// Update mocks godo.VPCsService.Update.
+func (m *VPCsService) Update(a0 context.Context, a1 string, a2 *godo.VPCUpdateRequest) (*godo.VPC, *godo.Response, error) {
+	ret := m.Called("Update", a0, a1, a2)
+	r0, _ := ret.Get(0).(*godo.VPC)
+	r1, _ := ret.Get(1).(*godo.Response)
+	r2 := ret.Error(2)
+	return r0, r1, r2
+}
+
+// Set mocks godo.VPCsService.Set.
+func (m *VPCsService) Set(a0 context.Context, a1 string, a2 ...godo.VPCSetField) (*godo.VPC, *godo.Response, error) {
+	ret := m.Called("Set", a0, a1, a2)
+	r0, _ := ret.Get(0).(*godo.VPC)
+	r1, _ := ret.Get(1).(*godo.Response)
+	r2 := ret.Error(2)
+	return r0, r1, r2
+}
+
+// Delete mocks godo.VPCsService.Delete.
+func (m *VPCsService) Delete(a0 context.Context, a1 string) (*godo.Response, error) {
+	ret := m.Called("Delete", a0, a1)
+	r0, _ := ret.Get(0).(*godo.Response)
+	r1 := ret.Error(1)
+	return r0, r1
+}
+
+// Mocks holds the mocks of the services of a client built by NewClient.
+type Mocks struct {
+	Account           *AccountService
+	Actions           *ActionsService
+	Balance           *BalanceService
+	BillingHistory    *BillingHistoryService
+	CDNs              *CDNService
+	Domains           *DomainsService
+	Droplets          *DropletsService
+	DropletActions    *DropletActionsService
+	Images            *ImagesService
+	ImageActions      *ImageActionsService
+	Invoices          *InvoicesService
+	Keys              *KeysService
+	Regions           *RegionsService
+	Sizes             *SizesService
+	FloatingIPs       *FloatingIPsService
+	FloatingIPActions *FloatingIPActionsService
+	Snapshots         *SnapshotsService
+	Storage           *StorageService
+	StorageActions    *StorageActionsService
+	Tags              *TagsService
+	LoadBalancers     *LoadBalancersService
+	Certificates      *CertificatesService
+	Firewalls         *FirewallsService
+	Projects          *ProjectsService
+	Kubernetes        *KubernetesService
+	Registry          *RegistryService
+	Databases         *DatabasesService
+	VPCs              *VPCsService
+}
+
+// NewClient returns a client whose services are all mocks, along with the
+// mocks.
+func NewClient() (*godo.Client, *Mocks) {
+	m := &Mocks{
+		Account:           &AccountService{},
+		Actions:           &ActionsService{},
+		Balance:           &BalanceService{},
+		BillingHistory:    &BillingHistoryService{},
+		CDNs:              &CDNService{},
+		Domains:           &DomainsService{},
+		Droplets:          &DropletsService{},
+		DropletActions:    &DropletActionsService{},
+		Images:            &ImagesService{},
+		ImageActions:      &ImageActionsService{},
+		Invoices:          &InvoicesService{},
+		Keys:              &KeysService{},
+		Regions:           &RegionsService{},
+		Sizes:             &SizesService{},
+		FloatingIPs:       &FloatingIPsService{},
+		FloatingIPActions: &FloatingIPActionsService{},
+		Snapshots:         &SnapshotsService{},
+		Storage:           &StorageService{},
+		StorageActions:    &StorageActionsService{},
+		Tags:              &TagsService{},
+		LoadBalancers:     &LoadBalancersService{},
+		Certificates:      &CertificatesService{},
+		Firewalls:         &FirewallsService{},
+		Projects:          &ProjectsService{},
+		Kubernetes:        &KubernetesService{},
+		Registry:          &RegistryService{},
+		Databases:         &DatabasesService{},
+		VPCs:              &VPCsService{},
+	}
+
+	c := godo.NewClient(nil)
+	c.Account = m.Account
+	c.Actions = m.Actions
+	c.Balance = m.Balance
+	c.BillingHistory = m.BillingHistory
+	c.CDNs = m.CDNs
+	c.Domains = m.Domains
+	c.Droplets = m.Droplets
+	c.DropletActions = m.DropletActions
+	c.Images = m.Images
+	c.ImageActions = m.ImageActions
+	c.Invoices = m.Invoices
+	c.Keys = m.Keys
+	c.Regions = m.Regions
+	c.Sizes = m.Sizes
+	c.FloatingIPs = m.FloatingIPs
+	c.FloatingIPActions = m.FloatingIPActions
+	c.Snapshots = m.Snapshots
+	c.Storage = m.Storage
+	c.StorageActions = m.StorageActions
+	c.Tags = m.Tags
+	c.LoadBalancers = m.LoadBalancers
+	c.Certificates = m.Certificates
+	c.Firewalls = m.Firewalls
+	c.Projects = m.Projects
+	c.Kubernetes = m.Kubernetes
+	c.Registry = m.Registry
+	c.Databases = m.Databases
+	c.VPCs = m.VPCs
+	return c, m
+}
+
+// all returns the mocks of all services.
+func (m *Mocks) all() []*Mock {
+	return []*Mock{
+		&m.Account.Mock,
+		&m.Actions.Mock,
+		&m.Balance.Mock,
+		&m.BillingHistory.Mock,
+		&m.CDNs.Mock,
+		&m.Domains.Mock,
+		&m.Droplets.Mock,
+		&m.DropletActions.Mock,
+		&m.Images.Mock,
+		&m.ImageActions.Mock,
+		&m.Invoices.Mock,
+		&m.Keys.Mock,
+		&m.Regions.Mock,
+		&m.Sizes.Mock,
+		&m.FloatingIPs.Mock,
+		&m.FloatingIPActions.Mock,
+		&m.Snapshots.Mock,
+		&m.Storage.Mock,
+		&m.StorageActions.Mock,
+		&m.Tags.Mock,
+		&m.LoadBalancers.Mock,
+		&m.Certificates.Mock,
+		&m.Firewalls.Mock,
+		&m.Projects.Mock,
+		&m.Kubernetes.Mock,
+		&m.Registry.Mock,
+		&m.Databases.Mock,
+		&m.VPCs.Mock,
+	}
+}
@@ -0,0 +1,151 @@
+package godotest
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/digitalocean/godo"
+)
+
+// action is an action in progress or finished.
+type action struct {
+	*godo.Action
+
+	// resource is the ID of the resource, which is not numeric for volumes
+	// and floating IPs.
+	resource string
+	polls    int
+
+	// done is called when the action completes successfully, to apply its
+	// effects.
+	done func()
+}
+
+func (s *Server) registerActions() {
+	s.handle(http.MethodGet, "/v2/actions", s.listActions)
+	s.handle(http.MethodGet, "/v2/actions/*", s.getAction)
+}
+
+// startAction starts an action on a resource. done is called when it
+// completes.
+func (s *Server) startAction(actionType, resourceType, resource, region string, done func()) *godo.Action {
+	resourceID, _ := strconv.Atoi(resource)
+	a := &action{
+		Action: &godo.Action{
+			ID:           s.newID(),
+			Status:       godo.ActionInProgress,
+			Type:         actionType,
+			StartedAt:    &godo.Timestamp{Time: time.Now().UTC()},
+			ResourceID:   resourceID,
+			ResourceType: resourceType,
+			RegionSlug:   region,
+		},
+		resource: resource,
+		done:     done,
+	}
+	if region != "" {
+		a.Region = &godo.Region{Slug: region}
+	}
+
+	s.actions.put(strconv.Itoa(a.ID), a)
+	return a.Action
+}
+
+// pollAction records a fetch of the action, completing it once polled
+// enough.
+func (s *Server) pollAction(a *action) {
+	if a.Status != godo.ActionInProgress {
+		return
+	}
+
+	a.polls++
+	if a.polls < s.polls {
+		return
+	}
+
+	a.CompletedAt = &godo.Timestamp{Time: time.Now().UTC()}
+	if s.erroredActions[a.Type] {
+		a.Status = "errored"
+		return
+	}
+
+	a.Status = godo.ActionCompleted
+	if a.done != nil {
+		a.done()
+	}
+}
+
+// findAction returns the action with the given ID, optionally restricted to
+// a resource type.
+func (s *Server) findAction(id, resourceType string) (*action, bool) {
+	v, ok := s.actions.get(id)
+	if !ok {
+		return nil, false
+	}
+
+	a := v.(*action)
+	if resourceType != "" && a.ResourceType != resourceType {
+		return nil, false
+	}
+	return a, true
+}
+
+// resourceActions returns the actions of a resource.
+func (s *Server) resourceActions(resourceType, resource string) []interface{} {
+	var actions []interface{}
+	for _, v := range s.actions.list() {
+		a := v.(*action)
+		if a.ResourceType == resourceType && a.resource == resource {
+			actions = append(actions, a.Action)
+		}
+	}
+	return actions
+}
+
+func (s *Server) listActions(w http.ResponseWriter, r *http.Request, _ []string) {
+	var actions []interface{}
+	for _, v := range s.actions.list() {
+		actions = append(actions, v.(*action).Action)
+	}
+
+	// The API lists the most recent actions first.
+	for i, j := 0, len(actions)-1; i < j; i, j = i+1, j-1 {
+		actions[i], actions[j] = actions[j], actions[i]
+	}
+	s.writeList(w, r, "actions", actions)
+}
+
+func (s *Server) getAction(w http.ResponseWriter, r *http.Request, params []string) {
+	s.writeAction(w, params[0], "")
+}
+
+// writeAction writes the action with the given ID after polling it.
+func (s *Server) writeAction(w http.ResponseWriter, id, resourceType string) {
+	a, ok := s.findAction(id, resourceType)
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+
+	s.pollAction(a)
+	writeJSON(w, http.StatusOK, map[string]interface{}{"action": a.Action})
+}
+
+// writeStartedAction writes the response to a request starting an action.
+func writeStartedAction(w http.ResponseWriter, a *godo.Action) {
+	writeJSON(w, http.StatusCreated, map[string]interface{}{"action": a})
+}
+
+// actionLinks returns the links to the actions started by a request.
+func (s *Server) actionLinks(rel string, actions ...*godo.Action) *godo.Links {
+	links := &godo.Links{}
+	for _, a := range actions {
+		links.Actions = append(links.Actions, godo.LinkAction{
+			ID:   a.ID,
+			Rel:  rel,
+			HREF: s.URL + "/v2/actions/" + strconv.Itoa(a.ID),
+		})
+	}
+	return links
+}
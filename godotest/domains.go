@@ -0,0 +1,193 @@
+package godotest
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/digitalocean/godo"
+)
+
+const defaultTTL = 1800
+
+func (s *Server) registerDomains() {
+	s.handle(http.MethodGet, "/v2/domains", s.listDomains)
+	s.handle(http.MethodPost, "/v2/domains", s.createDomain)
+	s.handle(http.MethodGet, "/v2/domains/*", s.getDomain)
+	s.handle(http.MethodDelete, "/v2/domains/*", s.deleteDomain)
+	s.handle(http.MethodGet, "/v2/domains/*/records", s.listRecords)
+	s.handle(http.MethodPost, "/v2/domains/*/records", s.createRecord)
+	s.handle(http.MethodGet, "/v2/domains/*/records/*", s.getRecord)
+	s.handle(http.MethodPut, "/v2/domains/*/records/*", s.editRecord)
+	s.handle(http.MethodDelete, "/v2/domains/*/records/*", s.deleteRecord)
+}
+
+func (s *Server) listDomains(w http.ResponseWriter, r *http.Request, _ []string) {
+	s.writeList(w, r, "domains", s.domains.list())
+}
+
+func (s *Server) createDomain(w http.ResponseWriter, r *http.Request, _ []string) {
+	var req godo.DomainCreateRequest
+	if !readJSON(w, r, &req) {
+		return
+	}
+	if req.Name == "" {
+		writeError(w, http.StatusUnprocessableEntity, "name is required")
+		return
+	}
+	if _, ok := s.domains.get(req.Name); ok {
+		writeError(w, http.StatusUnprocessableEntity, "Name already exists")
+		return
+	}
+
+	records := newCollection()
+	for _, ns := range []string{"ns1.digitalocean.com.", "ns2.digitalocean.com.", "ns3.digitalocean.com."} {
+		s.putRecord(records, &godo.DomainRecord{Type: "NS", Name: "@", Data: ns, TTL: defaultTTL})
+	}
+	if req.IPAddress != "" {
+		s.putRecord(records, &godo.DomainRecord{Type: "A", Name: "@", Data: req.IPAddress, TTL: defaultTTL})
+	}
+
+	d := &godo.Domain{Name: req.Name, TTL: defaultTTL}
+	s.domains.put(d.Name, d)
+	s.records[d.Name] = records
+	writeJSON(w, http.StatusCreated, map[string]interface{}{"domain": d})
+}
+
+func (s *Server) putRecord(records *collection, rec *godo.DomainRecord) {
+	rec.ID = s.newID()
+	records.put(strconv.Itoa(rec.ID), rec)
+}
+
+func (s *Server) getDomain(w http.ResponseWriter, r *http.Request, params []string) {
+	d, ok := s.domains.get(params[0])
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"domain": d})
+}
+
+func (s *Server) deleteDomain(w http.ResponseWriter, r *http.Request, params []string) {
+	if !s.domains.delete(params[0]) {
+		writeNotFound(w)
+		return
+	}
+	delete(s.records, params[0])
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) listRecords(w http.ResponseWriter, r *http.Request, params []string) {
+	records, ok := s.records[params[0]]
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+
+	q := r.URL.Query()
+	var list []interface{}
+	for _, v := range records.list() {
+		rec := v.(*godo.DomainRecord)
+		if t := q.Get("type"); t != "" && rec.Type != t {
+			continue
+		}
+		if name := q.Get("name"); name != "" && rec.Name != name {
+			continue
+		}
+		list = append(list, rec)
+	}
+	s.writeList(w, r, "domain_records", list)
+}
+
+func (s *Server) createRecord(w http.ResponseWriter, r *http.Request, params []string) {
+	records, ok := s.records[params[0]]
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+
+	var req godo.DomainRecordEditRequest
+	if !readJSON(w, r, &req) {
+		return
+	}
+	if req.Type == "" || req.Data == "" {
+		writeError(w, http.StatusUnprocessableEntity, "type and data are required")
+		return
+	}
+
+	rec := &godo.DomainRecord{}
+	applyRecordEdit(rec, &req)
+	if rec.TTL == 0 {
+		rec.TTL = defaultTTL
+	}
+	s.putRecord(records, rec)
+	writeJSON(w, http.StatusCreated, map[string]interface{}{"domain_record": rec})
+}
+
+func (s *Server) record(params []string) (*godo.DomainRecord, bool) {
+	records, ok := s.records[params[0]]
+	if !ok {
+		return nil, false
+	}
+	v, ok := records.get(params[1])
+	if !ok {
+		return nil, false
+	}
+	return v.(*godo.DomainRecord), true
+}
+
+func (s *Server) getRecord(w http.ResponseWriter, r *http.Request, params []string) {
+	rec, ok := s.record(params)
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"domain_record": rec})
+}
+
+func (s *Server) editRecord(w http.ResponseWriter, r *http.Request, params []string) {
+	rec, ok := s.record(params)
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+
+	var req godo.DomainRecordEditRequest
+	if !readJSON(w, r, &req) {
+		return
+	}
+	applyRecordEdit(rec, &req)
+	writeJSON(w, http.StatusOK, map[string]interface{}{"domain_record": rec})
+}
+
+func applyRecordEdit(rec *godo.DomainRecord, req *godo.DomainRecordEditRequest) {
+	if req.Type != "" {
+		rec.Type = req.Type
+	}
+	if req.Name != "" {
+		rec.Name = req.Name
+	}
+	if req.Data != "" {
+		rec.Data = req.Data
+	}
+	if req.Port != 0 {
+		rec.Port = req.Port
+	}
+	if req.TTL != 0 {
+		rec.TTL = req.TTL
+	}
+	if req.Tag != "" {
+		rec.Tag = req.Tag
+	}
+	rec.Priority = req.Priority
+	rec.Weight = req.Weight
+	rec.Flags = req.Flags
+}
+
+func (s *Server) deleteRecord(w http.ResponseWriter, r *http.Request, params []string) {
+	if _, ok := s.record(params); !ok {
+		writeNotFound(w)
+		return
+	}
+	s.records[params[0]].delete(params[1])
+	w.WriteHeader(http.StatusNoContent)
+}
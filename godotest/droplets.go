@@ -0,0 +1,295 @@
+package godotest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/digitalocean/godo"
+)
+
+// dropletCreateRequest is the body of a request creating one or several
+// Droplets.
+type dropletCreateRequest struct {
+	Name    string          `json:"name"`
+	Names   []string        `json:"names"`
+	Region  string          `json:"region"`
+	Size    string          `json:"size"`
+	Image   json.RawMessage `json:"image"`
+	Backups bool            `json:"backups"`
+	IPv6    bool            `json:"ipv6"`
+	Tags    []string        `json:"tags"`
+	VPCUUID string          `json:"vpc_uuid"`
+}
+
+func (s *Server) registerDroplets() {
+	s.handle(http.MethodGet, "/v2/droplets", s.listDroplets)
+	s.handle(http.MethodPost, "/v2/droplets", s.createDroplets)
+	s.handle(http.MethodDelete, "/v2/droplets", s.deleteDropletsByTag)
+	s.handle(http.MethodPost, "/v2/droplets/actions", s.dropletActionByTag)
+	s.handle(http.MethodGet, "/v2/droplets/*", s.getDroplet)
+	s.handle(http.MethodDelete, "/v2/droplets/*", s.deleteDroplet)
+	s.handle(http.MethodGet, "/v2/droplets/*/actions", s.listDropletActions)
+	s.handle(http.MethodPost, "/v2/droplets/*/actions", s.dropletAction)
+	s.handle(http.MethodGet, "/v2/droplets/*/actions/*", s.getDropletAction)
+	s.handle(http.MethodGet, "/v2/droplets/*/firewalls", s.listDropletFirewalls)
+}
+
+func (s *Server) droplet(id string) (*godo.Droplet, bool) {
+	v, ok := s.droplets.get(id)
+	if !ok {
+		return nil, false
+	}
+	return v.(*godo.Droplet), true
+}
+
+// taggedDroplets returns the Droplets with the given tag.
+func (s *Server) taggedDroplets(tag string) []*godo.Droplet {
+	var droplets []*godo.Droplet
+	for _, v := range s.droplets.list() {
+		if d := v.(*godo.Droplet); contains(d.Tags, tag) {
+			droplets = append(droplets, d)
+		}
+	}
+	return droplets
+}
+
+func (s *Server) listDroplets(w http.ResponseWriter, r *http.Request, _ []string) {
+	var droplets []interface{}
+	tag := r.URL.Query().Get("tag_name")
+	for _, v := range s.droplets.list() {
+		if tag == "" || contains(v.(*godo.Droplet).Tags, tag) {
+			droplets = append(droplets, v)
+		}
+	}
+	s.writeList(w, r, "droplets", droplets)
+}
+
+func (s *Server) createDroplets(w http.ResponseWriter, r *http.Request, _ []string) {
+	var req dropletCreateRequest
+	if !readJSON(w, r, &req) {
+		return
+	}
+
+	names := req.Names
+	if req.Name != "" {
+		names = []string{req.Name}
+	}
+	if len(names) == 0 || req.Region == "" || req.Size == "" || len(req.Image) == 0 {
+		writeError(w, http.StatusUnprocessableEntity, "name, region, size and image are required")
+		return
+	}
+
+	var droplets []*godo.Droplet
+	var actions []*godo.Action
+	for _, name := range names {
+		d := s.newDroplet(name, &req)
+		droplets = append(droplets, d)
+		actions = append(actions, s.startAction("create", "droplet", strconv.Itoa(d.ID), req.Region, func() {
+			d.Status = "active"
+			d.Locked = false
+		}))
+	}
+
+	links := s.actionLinks("create", actions...)
+	if req.Name != "" {
+		writeJSON(w, http.StatusAccepted, map[string]interface{}{"droplet": droplets[0], "links": links})
+		return
+	}
+	writeJSON(w, http.StatusAccepted, map[string]interface{}{"droplets": droplets, "links": links})
+}
+
+func (s *Server) newDroplet(name string, req *dropletCreateRequest) *godo.Droplet {
+	id := s.newID()
+
+	image := &godo.Image{}
+	if err := json.Unmarshal(req.Image, &image.Slug); err != nil {
+		json.Unmarshal(req.Image, &image.ID)
+	}
+
+	var features []string
+	if req.Backups {
+		features = append(features, "backups")
+	}
+	networks := &godo.Networks{V4: []godo.NetworkV4{{
+		IPAddress: fmt.Sprintf("203.0.113.%d", id%254+1),
+		Netmask:   "255.255.255.0",
+		Gateway:   "203.0.113.254",
+		Type:      "public",
+	}}}
+	if req.IPv6 {
+		features = append(features, "ipv6")
+		networks.V6 = []godo.NetworkV6{{
+			IPAddress: fmt.Sprintf("2001:db8::%x", id),
+			Netmask:   64,
+			Gateway:   "2001:db8::1",
+			Type:      "public",
+		}}
+	}
+
+	d := &godo.Droplet{
+		ID:        id,
+		Name:      name,
+		Region:    &godo.Region{Slug: req.Region},
+		Image:     image,
+		Size:      &godo.Size{Slug: req.Size},
+		SizeSlug:  req.Size,
+		Features:  features,
+		Locked:    true,
+		Status:    "new",
+		Networks:  networks,
+		Created:   now(),
+		Tags:      append([]string{}, req.Tags...),
+		VolumeIDs: []string{},
+		VPCUUID:   req.VPCUUID,
+	}
+	s.droplets.put(strconv.Itoa(id), d)
+	s.ensureTags(d.Tags)
+	return d
+}
+
+func (s *Server) getDroplet(w http.ResponseWriter, r *http.Request, params []string) {
+	d, ok := s.droplet(params[0])
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"droplet": d})
+}
+
+func (s *Server) deleteDroplet(w http.ResponseWriter, r *http.Request, params []string) {
+	if !s.droplets.delete(params[0]) {
+		writeNotFound(w)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) deleteDropletsByTag(w http.ResponseWriter, r *http.Request, _ []string) {
+	tag := r.URL.Query().Get("tag_name")
+	if tag == "" {
+		writeError(w, http.StatusBadRequest, "tag_name is required")
+		return
+	}
+
+	for _, d := range s.taggedDroplets(tag) {
+		s.droplets.delete(strconv.Itoa(d.ID))
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) listDropletActions(w http.ResponseWriter, r *http.Request, params []string) {
+	if _, ok := s.droplet(params[0]); !ok {
+		writeNotFound(w)
+		return
+	}
+	s.writeList(w, r, "actions", s.resourceActions("droplet", params[0]))
+}
+
+func (s *Server) getDropletAction(w http.ResponseWriter, r *http.Request, params []string) {
+	if _, ok := s.droplet(params[0]); !ok {
+		writeNotFound(w)
+		return
+	}
+	s.writeAction(w, params[1], "droplet")
+}
+
+func (s *Server) dropletAction(w http.ResponseWriter, r *http.Request, params []string) {
+	d, ok := s.droplet(params[0])
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+
+	var req godo.ActionRequest
+	if !readJSON(w, r, &req) {
+		return
+	}
+
+	a, msg := s.startDropletAction(d, req)
+	if a == nil {
+		writeError(w, http.StatusUnprocessableEntity, msg)
+		return
+	}
+	writeStartedAction(w, a)
+}
+
+func (s *Server) dropletActionByTag(w http.ResponseWriter, r *http.Request, _ []string) {
+	tag := r.URL.Query().Get("tag_name")
+	if tag == "" {
+		writeError(w, http.StatusBadRequest, "tag_name is required")
+		return
+	}
+
+	var req godo.ActionRequest
+	if !readJSON(w, r, &req) {
+		return
+	}
+
+	actions := []*godo.Action{}
+	for _, d := range s.taggedDroplets(tag) {
+		a, msg := s.startDropletAction(d, req)
+		if a == nil {
+			writeError(w, http.StatusUnprocessableEntity, msg)
+			return
+		}
+		actions = append(actions, a)
+	}
+	writeJSON(w, http.StatusCreated, map[string]interface{}{"actions": actions})
+}
+
+// startDropletAction starts the action described by req on d, returning an
+// error message if it is invalid.
+func (s *Server) startDropletAction(d *godo.Droplet, req godo.ActionRequest) (*godo.Action, string) {
+	actionType, _ := req["type"].(string)
+	if actionType == "" {
+		return nil, "action type is required"
+	}
+
+	var done func()
+	switch actionType {
+	case "power_on", "reboot", "power_cycle", "restore", "rebuild":
+		done = func() { d.Status = "active" }
+	case "power_off", "shutdown":
+		done = func() { d.Status = "off" }
+	case "rename":
+		name, _ := req["name"].(string)
+		if name == "" {
+			return nil, "name is required"
+		}
+		done = func() { d.Name = name }
+	case "resize":
+		size, _ := req["size"].(string)
+		if size == "" {
+			return nil, "size is required"
+		}
+		done = func() {
+			d.SizeSlug = size
+			d.Size = &godo.Size{Slug: size}
+		}
+	case "enable_backups":
+		done = func() { d.Features = appendFeature(d.Features, "backups") }
+	case "disable_backups":
+		done = func() { d.Features = remove(d.Features, "backups") }
+	case "enable_ipv6":
+		done = func() { d.Features = appendFeature(d.Features, "ipv6") }
+	case "enable_private_networking":
+		done = func() { d.Features = appendFeature(d.Features, "private_networking") }
+	}
+
+	d.Locked = true
+	return s.startAction(actionType, "droplet", strconv.Itoa(d.ID), d.Region.Slug, func() {
+		d.Locked = false
+		if done != nil {
+			done()
+		}
+	}), ""
+}
+
+func appendFeature(features []string, f string) []string {
+	if contains(features, f) {
+		return features
+	}
+	return append(features, f)
+}
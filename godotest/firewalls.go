@@ -0,0 +1,217 @@
+package godotest
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/digitalocean/godo"
+)
+
+// firewallChangeRequest is the body of requests adding or removing Droplets,
+// tags or rules of a firewall.
+type firewallChangeRequest struct {
+	DropletIDs    []int               `json:"droplet_ids"`
+	Tags          []string            `json:"tags"`
+	InboundRules  []godo.InboundRule  `json:"inbound_rules"`
+	OutboundRules []godo.OutboundRule `json:"outbound_rules"`
+}
+
+func (s *Server) registerFirewalls() {
+	s.handle(http.MethodGet, "/v2/firewalls", s.listFirewalls)
+	s.handle(http.MethodPost, "/v2/firewalls", s.createFirewall)
+	s.handle(http.MethodGet, "/v2/firewalls/*", s.getFirewall)
+	s.handle(http.MethodPut, "/v2/firewalls/*", s.updateFirewall)
+	s.handle(http.MethodDelete, "/v2/firewalls/*", s.deleteFirewall)
+	for _, kind := range []string{"droplets", "tags", "rules"} {
+		s.handle(http.MethodPost, "/v2/firewalls/*/"+kind, s.changeFirewall(true))
+		s.handle(http.MethodDelete, "/v2/firewalls/*/"+kind, s.changeFirewall(false))
+	}
+}
+
+func (s *Server) firewall(id string) (*godo.Firewall, bool) {
+	v, ok := s.firewalls.get(id)
+	if !ok {
+		return nil, false
+	}
+	return v.(*godo.Firewall), true
+}
+
+func (s *Server) listFirewalls(w http.ResponseWriter, r *http.Request, _ []string) {
+	s.writeList(w, r, "firewalls", s.firewalls.list())
+}
+
+func (s *Server) listDropletFirewalls(w http.ResponseWriter, r *http.Request, params []string) {
+	d, ok := s.droplet(params[0])
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+
+	var firewalls []interface{}
+	for _, v := range s.firewalls.list() {
+		if f := v.(*godo.Firewall); s.firewallApplies(f, d) {
+			firewalls = append(firewalls, f)
+		}
+	}
+	s.writeList(w, r, "firewalls", firewalls)
+}
+
+// firewallApplies reports whether f applies to d, directly or through a tag.
+func (s *Server) firewallApplies(f *godo.Firewall, d *godo.Droplet) bool {
+	if containsInt(f.DropletIDs, d.ID) {
+		return true
+	}
+	for _, tag := range f.Tags {
+		if contains(d.Tags, tag) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Server) createFirewall(w http.ResponseWriter, r *http.Request, _ []string) {
+	var req godo.FirewallRequest
+	if !readJSON(w, r, &req) {
+		return
+	}
+	if req.Name == "" {
+		writeError(w, http.StatusUnprocessableEntity, "name is required")
+		return
+	}
+
+	f := &godo.Firewall{
+		ID:             s.newUUID(),
+		Status:         "succeeded",
+		Created:        now(),
+		PendingChanges: []godo.PendingChange{},
+	}
+	applyFirewallRequest(f, &req)
+	s.firewalls.put(f.ID, f)
+	writeJSON(w, http.StatusAccepted, map[string]interface{}{"firewall": f})
+}
+
+func applyFirewallRequest(f *godo.Firewall, req *godo.FirewallRequest) {
+	f.Name = req.Name
+	f.InboundRules = append([]godo.InboundRule{}, req.InboundRules...)
+	f.OutboundRules = append([]godo.OutboundRule{}, req.OutboundRules...)
+	f.DropletIDs = append([]int{}, req.DropletIDs...)
+	f.Tags = append([]string{}, req.Tags...)
+}
+
+func (s *Server) getFirewall(w http.ResponseWriter, r *http.Request, params []string) {
+	f, ok := s.firewall(params[0])
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"firewall": f})
+}
+
+func (s *Server) updateFirewall(w http.ResponseWriter, r *http.Request, params []string) {
+	f, ok := s.firewall(params[0])
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+
+	var req godo.FirewallRequest
+	if !readJSON(w, r, &req) {
+		return
+	}
+	if req.Name == "" {
+		writeError(w, http.StatusUnprocessableEntity, "name is required")
+		return
+	}
+
+	applyFirewallRequest(f, &req)
+	writeJSON(w, http.StatusOK, map[string]interface{}{"firewall": f})
+}
+
+func (s *Server) deleteFirewall(w http.ResponseWriter, r *http.Request, params []string) {
+	if !s.firewalls.delete(params[0]) {
+		writeNotFound(w)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// changeFirewall returns a handler adding or removing the Droplets, tags or
+// rules of a firewall.
+func (s *Server) changeFirewall(add bool) handler {
+	return func(w http.ResponseWriter, r *http.Request, params []string) {
+		f, ok := s.firewall(params[0])
+		if !ok {
+			writeNotFound(w)
+			return
+		}
+
+		var req firewallChangeRequest
+		if !readJSON(w, r, &req) {
+			return
+		}
+
+		for _, id := range req.DropletIDs {
+			if _, ok := s.droplet(strconv.Itoa(id)); !ok && add {
+				writeError(w, http.StatusUnprocessableEntity, "droplet not found")
+				return
+			}
+		}
+
+		if add {
+			for _, id := range req.DropletIDs {
+				if !containsInt(f.DropletIDs, id) {
+					f.DropletIDs = append(f.DropletIDs, id)
+				}
+			}
+			for _, tag := range req.Tags {
+				if !contains(f.Tags, tag) {
+					f.Tags = append(f.Tags, tag)
+				}
+			}
+			f.InboundRules = append(f.InboundRules, req.InboundRules...)
+			f.OutboundRules = append(f.OutboundRules, req.OutboundRules...)
+		} else {
+			for _, id := range req.DropletIDs {
+				f.DropletIDs = removeInt(f.DropletIDs, id)
+			}
+			for _, tag := range req.Tags {
+				f.Tags = remove(f.Tags, tag)
+			}
+			f.InboundRules = removeInboundRules(f.InboundRules, req.InboundRules)
+			f.OutboundRules = removeOutboundRules(f.OutboundRules, req.OutboundRules)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func removeInboundRules(rules, removed []godo.InboundRule) []godo.InboundRule {
+	out := []godo.InboundRule{}
+	for _, rule := range rules {
+		keep := true
+		for _, r := range removed {
+			if rule.Protocol == r.Protocol && rule.PortRange == r.PortRange {
+				keep = false
+			}
+		}
+		if keep {
+			out = append(out, rule)
+		}
+	}
+	return out
+}
+
+func removeOutboundRules(rules, removed []godo.OutboundRule) []godo.OutboundRule {
+	out := []godo.OutboundRule{}
+	for _, rule := range rules {
+		keep := true
+		for _, r := range removed {
+			if rule.Protocol == r.Protocol && rule.PortRange == r.PortRange {
+				keep = false
+			}
+		}
+		if keep {
+			out = append(out, rule)
+		}
+	}
+	return out
+}
@@ -0,0 +1,127 @@
+package godotest
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/digitalocean/godo"
+)
+
+func (s *Server) registerFloatingIPs() {
+	s.handle(http.MethodGet, "/v2/floating_ips", s.listFloatingIPs)
+	s.handle(http.MethodPost, "/v2/floating_ips", s.createFloatingIP)
+	s.handle(http.MethodGet, "/v2/floating_ips/*", s.getFloatingIP)
+	s.handle(http.MethodDelete, "/v2/floating_ips/*", s.deleteFloatingIP)
+	s.handle(http.MethodGet, "/v2/floating_ips/*/actions", s.listFloatingIPActions)
+	s.handle(http.MethodPost, "/v2/floating_ips/*/actions", s.floatingIPAction)
+	s.handle(http.MethodGet, "/v2/floating_ips/*/actions/*", s.getFloatingIPAction)
+}
+
+func (s *Server) floatingIP(ip string) (*godo.FloatingIP, bool) {
+	v, ok := s.floatingIPs.get(ip)
+	if !ok {
+		return nil, false
+	}
+	return v.(*godo.FloatingIP), true
+}
+
+func (s *Server) listFloatingIPs(w http.ResponseWriter, r *http.Request, _ []string) {
+	s.writeList(w, r, "floating_ips", s.floatingIPs.list())
+}
+
+func (s *Server) createFloatingIP(w http.ResponseWriter, r *http.Request, _ []string) {
+	var req godo.FloatingIPCreateRequest
+	if !readJSON(w, r, &req) {
+		return
+	}
+
+	fip := &godo.FloatingIP{IP: fmt.Sprintf("198.51.100.%d", s.newID()%254+1)}
+	switch {
+	case req.DropletID != 0:
+		d, ok := s.droplet(strconv.Itoa(req.DropletID))
+		if !ok {
+			writeError(w, http.StatusUnprocessableEntity, "droplet not found")
+			return
+		}
+		fip.Droplet = d
+		fip.Region = d.Region
+	case req.Region != "":
+		fip.Region = &godo.Region{Slug: req.Region}
+	default:
+		writeError(w, http.StatusUnprocessableEntity, "region or droplet_id is required")
+		return
+	}
+
+	s.floatingIPs.put(fip.IP, fip)
+	writeJSON(w, http.StatusAccepted, map[string]interface{}{"floating_ip": fip})
+}
+
+func (s *Server) getFloatingIP(w http.ResponseWriter, r *http.Request, params []string) {
+	fip, ok := s.floatingIP(params[0])
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"floating_ip": fip})
+}
+
+func (s *Server) deleteFloatingIP(w http.ResponseWriter, r *http.Request, params []string) {
+	if !s.floatingIPs.delete(params[0]) {
+		writeNotFound(w)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) listFloatingIPActions(w http.ResponseWriter, r *http.Request, params []string) {
+	if _, ok := s.floatingIP(params[0]); !ok {
+		writeNotFound(w)
+		return
+	}
+	s.writeList(w, r, "actions", s.resourceActions("floating_ip", params[0]))
+}
+
+func (s *Server) getFloatingIPAction(w http.ResponseWriter, r *http.Request, params []string) {
+	if _, ok := s.floatingIP(params[0]); !ok {
+		writeNotFound(w)
+		return
+	}
+	s.writeAction(w, params[1], "floating_ip")
+}
+
+func (s *Server) floatingIPAction(w http.ResponseWriter, r *http.Request, params []string) {
+	fip, ok := s.floatingIP(params[0])
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+
+	var req godo.ActionRequest
+	if !readJSON(w, r, &req) {
+		return
+	}
+
+	var done func()
+	actionType, _ := req["type"].(string)
+	switch actionType {
+	case "assign":
+		d, ok := s.droplet(strconv.Itoa(intParam(req, "droplet_id")))
+		if !ok {
+			writeError(w, http.StatusUnprocessableEntity, "droplet not found")
+			return
+		}
+		done = func() { fip.Droplet = d }
+	case "unassign":
+		if fip.Droplet == nil {
+			writeError(w, http.StatusUnprocessableEntity, "floating IP is not assigned")
+			return
+		}
+		done = func() { fip.Droplet = nil }
+	default:
+		writeError(w, http.StatusUnprocessableEntity, "unsupported floating IP action "+actionType)
+		return
+	}
+
+	writeStartedAction(w, s.startAction(actionType, "floating_ip", fip.IP, fip.Region.Slug, done))
+}
@@ -0,0 +1,301 @@
+package godotest
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/digitalocean/godo"
+)
+
+const defaultKubernetesVersion = "1.18.8-do.0"
+
+func (s *Server) registerKubernetes() {
+	s.handle(http.MethodGet, "/v2/kubernetes/clusters", s.listClusters)
+	s.handle(http.MethodPost, "/v2/kubernetes/clusters", s.createCluster)
+	s.handle(http.MethodGet, "/v2/kubernetes/clusters/*", s.getCluster)
+	s.handle(http.MethodPut, "/v2/kubernetes/clusters/*", s.updateCluster)
+	s.handle(http.MethodDelete, "/v2/kubernetes/clusters/*", s.deleteCluster)
+	s.handle(http.MethodGet, "/v2/kubernetes/clusters/*/kubeconfig", s.getKubeConfig)
+	s.handle(http.MethodGet, "/v2/kubernetes/clusters/*/node_pools", s.listNodePools)
+	s.handle(http.MethodPost, "/v2/kubernetes/clusters/*/node_pools", s.createNodePool)
+	s.handle(http.MethodGet, "/v2/kubernetes/clusters/*/node_pools/*", s.getNodePool)
+	s.handle(http.MethodPut, "/v2/kubernetes/clusters/*/node_pools/*", s.updateNodePool)
+	s.handle(http.MethodDelete, "/v2/kubernetes/clusters/*/node_pools/*", s.deleteNodePool)
+}
+
+func (s *Server) cluster(id string) (*godo.KubernetesCluster, bool) {
+	v, ok := s.clusters.get(id)
+	if !ok {
+		return nil, false
+	}
+	return v.(*godo.KubernetesCluster), true
+}
+
+func (s *Server) listClusters(w http.ResponseWriter, r *http.Request, _ []string) {
+	s.writeList(w, r, "kubernetes_clusters", s.clusters.list())
+}
+
+func (s *Server) createCluster(w http.ResponseWriter, r *http.Request, _ []string) {
+	var req godo.KubernetesClusterCreateRequest
+	if !readJSON(w, r, &req) {
+		return
+	}
+	if req.Name == "" || req.RegionSlug == "" || len(req.NodePools) == 0 {
+		writeError(w, http.StatusUnprocessableEntity, "name, region and node_pools are required")
+		return
+	}
+
+	id := s.newUUID()
+	c := &godo.KubernetesCluster{
+		ID:                id,
+		Name:              req.Name,
+		RegionSlug:        req.RegionSlug,
+		VersionSlug:       req.VersionSlug,
+		ClusterSubnet:     "10.244.0.0/16",
+		ServiceSubnet:     "10.245.0.0/16",
+		Endpoint:          fmt.Sprintf("https://%s.k8s.ondigitalocean.com", id),
+		Tags:              append([]string{"k8s", "k8s:" + id}, req.Tags...),
+		VPCUUID:           req.VPCUUID,
+		MaintenancePolicy: req.MaintenancePolicy,
+		AutoUpgrade:       req.AutoUpgrade,
+		Status:            &godo.KubernetesClusterStatus{State: godo.KubernetesClusterStatusProvisioning},
+		CreatedAt:         time.Now().UTC().Truncate(time.Second),
+	}
+	if c.VersionSlug == "" || c.VersionSlug == "latest" {
+		c.VersionSlug = defaultKubernetesVersion
+	}
+	c.UpdatedAt = c.CreatedAt
+	for _, np := range req.NodePools {
+		c.NodePools = append(c.NodePools, s.newNodePool(np))
+	}
+
+	s.clusters.put(id, c)
+	s.provision("cluster:"+id, func() {
+		c.Status = &godo.KubernetesClusterStatus{State: godo.KubernetesClusterStatusRunning}
+		c.IPv4 = fmt.Sprintf("192.0.2.%d", s.newID()%254+1)
+		for _, np := range c.NodePools {
+			for _, n := range np.Nodes {
+				n.Status = &godo.KubernetesNodeStatus{State: "running"}
+			}
+		}
+	})
+	writeJSON(w, http.StatusCreated, map[string]interface{}{"kubernetes_cluster": c})
+}
+
+func (s *Server) newNodePool(req *godo.KubernetesNodePoolCreateRequest) *godo.KubernetesNodePool {
+	np := &godo.KubernetesNodePool{
+		ID:        s.newUUID(),
+		Name:      req.Name,
+		Size:      req.Size,
+		Tags:      append([]string{}, req.Tags...),
+		Labels:    req.Labels,
+		AutoScale: req.AutoScale,
+		MinNodes:  req.MinNodes,
+		MaxNodes:  req.MaxNodes,
+	}
+	s.scaleNodePool(np, req.Count, "provisioning")
+	return np
+}
+
+// scaleNodePool adds or removes nodes of np so that it has count nodes. New
+// nodes are in the given state.
+func (s *Server) scaleNodePool(np *godo.KubernetesNodePool, count int, state string) {
+	for len(np.Nodes) < count {
+		now := time.Now().UTC().Truncate(time.Second)
+		np.Nodes = append(np.Nodes, &godo.KubernetesNode{
+			ID:        s.newUUID(),
+			Name:      fmt.Sprintf("%s-%d", np.Name, len(np.Nodes)+1),
+			Status:    &godo.KubernetesNodeStatus{State: state},
+			DropletID: strconv.Itoa(s.newID()),
+			CreatedAt: now,
+			UpdatedAt: now,
+		})
+	}
+	np.Nodes = np.Nodes[:count]
+	np.Count = count
+}
+
+func (s *Server) getCluster(w http.ResponseWriter, r *http.Request, params []string) {
+	c, ok := s.cluster(params[0])
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+
+	s.poll("cluster:" + c.ID)
+	writeJSON(w, http.StatusOK, map[string]interface{}{"kubernetes_cluster": c})
+}
+
+func (s *Server) updateCluster(w http.ResponseWriter, r *http.Request, params []string) {
+	c, ok := s.cluster(params[0])
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+
+	var req godo.KubernetesClusterUpdateRequest
+	if !readJSON(w, r, &req) {
+		return
+	}
+
+	if req.Name != "" {
+		c.Name = req.Name
+	}
+	if req.Tags != nil {
+		c.Tags = append([]string{"k8s", "k8s:" + c.ID}, req.Tags...)
+	}
+	if req.MaintenancePolicy != nil {
+		c.MaintenancePolicy = req.MaintenancePolicy
+	}
+	if req.AutoUpgrade != nil {
+		c.AutoUpgrade = *req.AutoUpgrade
+	}
+	c.UpdatedAt = time.Now().UTC().Truncate(time.Second)
+	writeJSON(w, http.StatusAccepted, map[string]interface{}{"kubernetes_cluster": c})
+}
+
+func (s *Server) deleteCluster(w http.ResponseWriter, r *http.Request, params []string) {
+	if !s.clusters.delete(params[0]) {
+		writeNotFound(w)
+		return
+	}
+	delete(s.pending, "cluster:"+params[0])
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) getKubeConfig(w http.ResponseWriter, r *http.Request, params []string) {
+	c, ok := s.cluster(params[0])
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+
+	name := "do-" + c.RegionSlug + "-" + c.Name
+	w.Header().Set("Content-Type", "application/yaml")
+	fmt.Fprintf(w, `apiVersion: v1
+kind: Config
+clusters:
+- cluster:
+    server: %[1]s
+  name: %[2]s
+contexts:
+- context:
+    cluster: %[2]s
+    user: %[2]s-admin
+  name: %[2]s
+current-context: %[2]s
+users:
+- name: %[2]s-admin
+  user:
+    token: godotest-token
+`, c.Endpoint, name)
+}
+
+// nodePool returns the node pool identified by the cluster and node pool IDs
+// in params.
+func (s *Server) nodePool(params []string) (*godo.KubernetesCluster, int, bool) {
+	c, ok := s.cluster(params[0])
+	if !ok {
+		return nil, 0, false
+	}
+	for i, np := range c.NodePools {
+		if np.ID == params[1] {
+			return c, i, true
+		}
+	}
+	return nil, 0, false
+}
+
+func (s *Server) listNodePools(w http.ResponseWriter, r *http.Request, params []string) {
+	c, ok := s.cluster(params[0])
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+
+	var pools []interface{}
+	for _, np := range c.NodePools {
+		pools = append(pools, np)
+	}
+	s.writeList(w, r, "node_pools", pools)
+}
+
+func (s *Server) createNodePool(w http.ResponseWriter, r *http.Request, params []string) {
+	c, ok := s.cluster(params[0])
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+
+	var req godo.KubernetesNodePoolCreateRequest
+	if !readJSON(w, r, &req) {
+		return
+	}
+	if req.Name == "" || req.Size == "" || req.Count < 1 {
+		writeError(w, http.StatusUnprocessableEntity, "name, size and count are required")
+		return
+	}
+
+	np := s.newNodePool(&req)
+	c.NodePools = append(c.NodePools, np)
+	writeJSON(w, http.StatusCreated, map[string]interface{}{"node_pool": np})
+}
+
+func (s *Server) getNodePool(w http.ResponseWriter, r *http.Request, params []string) {
+	c, i, ok := s.nodePool(params)
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"node_pool": c.NodePools[i]})
+}
+
+func (s *Server) updateNodePool(w http.ResponseWriter, r *http.Request, params []string) {
+	c, i, ok := s.nodePool(params)
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+
+	var req godo.KubernetesNodePoolUpdateRequest
+	if !readJSON(w, r, &req) {
+		return
+	}
+
+	np := c.NodePools[i]
+	if req.Name != "" {
+		np.Name = req.Name
+	}
+	if req.Count != nil {
+		s.scaleNodePool(np, *req.Count, "running")
+	}
+	if req.Tags != nil {
+		np.Tags = req.Tags
+	}
+	if req.Labels != nil {
+		np.Labels = req.Labels
+	}
+	if req.AutoScale != nil {
+		np.AutoScale = *req.AutoScale
+	}
+	if req.MinNodes != nil {
+		np.MinNodes = *req.MinNodes
+	}
+	if req.MaxNodes != nil {
+		np.MaxNodes = *req.MaxNodes
+	}
+	writeJSON(w, http.StatusAccepted, map[string]interface{}{"node_pool": np})
+}
+
+func (s *Server) deleteNodePool(w http.ResponseWriter, r *http.Request, params []string) {
+	c, i, ok := s.nodePool(params)
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+
+	c.NodePools = append(c.NodePools[:i], c.NodePools[i+1:]...)
+	w.WriteHeader(http.StatusNoContent)
+}
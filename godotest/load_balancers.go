@@ -0,0 +1,187 @@
+package godotest
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/digitalocean/godo"
+)
+
+// loadBalancerChangeRequest is the body of requests adding or removing
+// Droplets or forwarding rules of a load balancer.
+type loadBalancerChangeRequest struct {
+	DropletIDs      []int                 `json:"droplet_ids"`
+	ForwardingRules []godo.ForwardingRule `json:"forwarding_rules"`
+}
+
+func (s *Server) registerLoadBalancers() {
+	s.handle(http.MethodGet, "/v2/load_balancers", s.listLoadBalancers)
+	s.handle(http.MethodPost, "/v2/load_balancers", s.createLoadBalancer)
+	s.handle(http.MethodGet, "/v2/load_balancers/*", s.getLoadBalancer)
+	s.handle(http.MethodPut, "/v2/load_balancers/*", s.updateLoadBalancer)
+	s.handle(http.MethodDelete, "/v2/load_balancers/*", s.deleteLoadBalancer)
+	for _, kind := range []string{"droplets", "forwarding_rules"} {
+		s.handle(http.MethodPost, "/v2/load_balancers/*/"+kind, s.changeLoadBalancer(true))
+		s.handle(http.MethodDelete, "/v2/load_balancers/*/"+kind, s.changeLoadBalancer(false))
+	}
+}
+
+func (s *Server) loadBalancer(id string) (*godo.LoadBalancer, bool) {
+	v, ok := s.loadBalancers.get(id)
+	if !ok {
+		return nil, false
+	}
+	return v.(*godo.LoadBalancer), true
+}
+
+func (s *Server) listLoadBalancers(w http.ResponseWriter, r *http.Request, _ []string) {
+	s.writeList(w, r, "load_balancers", s.loadBalancers.list())
+}
+
+func (s *Server) createLoadBalancer(w http.ResponseWriter, r *http.Request, _ []string) {
+	var req godo.LoadBalancerRequest
+	if !readJSON(w, r, &req) {
+		return
+	}
+	if req.Name == "" || req.Region == "" || len(req.ForwardingRules) == 0 {
+		writeError(w, http.StatusUnprocessableEntity, "name, region and forwarding_rules are required")
+		return
+	}
+	if req.Tag != "" && len(req.DropletIDs) > 0 {
+		writeError(w, http.StatusUnprocessableEntity, "droplet_ids and tag are mutually exclusive")
+		return
+	}
+
+	id := s.newUUID()
+	lb := &godo.LoadBalancer{
+		ID:      id,
+		Status:  "new",
+		Created: now(),
+		Region:  &godo.Region{Slug: req.Region},
+	}
+	applyLoadBalancerRequest(lb, &req)
+	if lb.Algorithm == "" {
+		lb.Algorithm = "round_robin"
+	}
+
+	s.loadBalancers.put(id, lb)
+	s.provision("load_balancer:"+id, func() {
+		lb.Status = "active"
+		lb.IP = fmt.Sprintf("192.0.2.%d", s.newID()%254+1)
+	})
+	writeJSON(w, http.StatusAccepted, map[string]interface{}{"load_balancer": lb})
+}
+
+func applyLoadBalancerRequest(lb *godo.LoadBalancer, req *godo.LoadBalancerRequest) {
+	lb.Name = req.Name
+	lb.Algorithm = req.Algorithm
+	lb.ForwardingRules = append([]godo.ForwardingRule{}, req.ForwardingRules...)
+	lb.HealthCheck = req.HealthCheck
+	lb.StickySessions = req.StickySessions
+	lb.DropletIDs = append([]int{}, req.DropletIDs...)
+	lb.Tag = req.Tag
+	lb.Tags = append([]string{}, req.Tags...)
+	lb.RedirectHttpToHttps = req.RedirectHttpToHttps
+	lb.EnableProxyProtocol = req.EnableProxyProtocol
+	lb.EnableBackendKeepalive = req.EnableBackendKeepalive
+	lb.VPCUUID = req.VPCUUID
+}
+
+func (s *Server) getLoadBalancer(w http.ResponseWriter, r *http.Request, params []string) {
+	lb, ok := s.loadBalancer(params[0])
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+
+	s.poll("load_balancer:" + lb.ID)
+	writeJSON(w, http.StatusOK, map[string]interface{}{"load_balancer": lb})
+}
+
+func (s *Server) updateLoadBalancer(w http.ResponseWriter, r *http.Request, params []string) {
+	lb, ok := s.loadBalancer(params[0])
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+
+	var req godo.LoadBalancerRequest
+	if !readJSON(w, r, &req) {
+		return
+	}
+	if req.Name == "" || len(req.ForwardingRules) == 0 {
+		writeError(w, http.StatusUnprocessableEntity, "name and forwarding_rules are required")
+		return
+	}
+
+	applyLoadBalancerRequest(lb, &req)
+	writeJSON(w, http.StatusOK, map[string]interface{}{"load_balancer": lb})
+}
+
+func (s *Server) deleteLoadBalancer(w http.ResponseWriter, r *http.Request, params []string) {
+	if !s.loadBalancers.delete(params[0]) {
+		writeNotFound(w)
+		return
+	}
+	delete(s.pending, "load_balancer:"+params[0])
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// changeLoadBalancer returns a handler adding or removing the Droplets or
+// forwarding rules of a load balancer.
+func (s *Server) changeLoadBalancer(add bool) handler {
+	return func(w http.ResponseWriter, r *http.Request, params []string) {
+		lb, ok := s.loadBalancer(params[0])
+		if !ok {
+			writeNotFound(w)
+			return
+		}
+
+		var req loadBalancerChangeRequest
+		if !readJSON(w, r, &req) {
+			return
+		}
+
+		if len(req.DropletIDs) > 0 && lb.Tag != "" {
+			writeError(w, http.StatusUnprocessableEntity, "load balancer uses a tag to select Droplets")
+			return
+		}
+		for _, id := range req.DropletIDs {
+			if _, ok := s.droplet(strconv.Itoa(id)); !ok && add {
+				writeError(w, http.StatusUnprocessableEntity, "droplet not found")
+				return
+			}
+		}
+
+		if add {
+			for _, id := range req.DropletIDs {
+				if !containsInt(lb.DropletIDs, id) {
+					lb.DropletIDs = append(lb.DropletIDs, id)
+				}
+			}
+			lb.ForwardingRules = append(lb.ForwardingRules, req.ForwardingRules...)
+		} else {
+			for _, id := range req.DropletIDs {
+				lb.DropletIDs = removeInt(lb.DropletIDs, id)
+			}
+			rules := []godo.ForwardingRule{}
+			for _, rule := range lb.ForwardingRules {
+				if !containsRule(req.ForwardingRules, rule) {
+					rules = append(rules, rule)
+				}
+			}
+			lb.ForwardingRules = rules
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func containsRule(rules []godo.ForwardingRule, rule godo.ForwardingRule) bool {
+	for _, r := range rules {
+		if r == rule {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,234 @@
+package godotest
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/digitalocean/godo"
+)
+
+func (s *Server) registerProjects() {
+	s.handle(http.MethodGet, "/v2/projects", s.listProjects)
+	s.handle(http.MethodPost, "/v2/projects", s.createProject)
+	s.handle(http.MethodGet, "/v2/projects/*", s.getProject)
+	s.handle(http.MethodPut, "/v2/projects/*", s.updateProject)
+	s.handle(http.MethodPatch, "/v2/projects/*", s.updateProject)
+	s.handle(http.MethodDelete, "/v2/projects/*", s.deleteProject)
+	s.handle(http.MethodGet, "/v2/projects/*/resources", s.listProjectResources)
+	s.handle(http.MethodPost, "/v2/projects/*/resources", s.assignProjectResources)
+}
+
+func (s *Server) createDefaultProject() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.putProject(&godo.Project{
+		Name:        "first-project",
+		Description: "Default project",
+		Purpose:     "Just trying out DigitalOcean",
+		Environment: "Development",
+		IsDefault:   true,
+	})
+}
+
+func (s *Server) putProject(p *godo.Project) {
+	p.ID = s.newUUID()
+	p.OwnerUUID = "00000000-0000-4000-8000-000000000000"
+	p.OwnerID = 1
+	p.CreatedAt = now()
+	p.UpdatedAt = p.CreatedAt
+	s.projects.put(p.ID, p)
+}
+
+// project returns the project with the given ID, or the default project for
+// "default".
+func (s *Server) project(id string) (*godo.Project, bool) {
+	if id == "default" {
+		for _, v := range s.projects.list() {
+			if p := v.(*godo.Project); p.IsDefault {
+				return p, true
+			}
+		}
+		return nil, false
+	}
+
+	v, ok := s.projects.get(id)
+	if !ok {
+		return nil, false
+	}
+	return v.(*godo.Project), true
+}
+
+func (s *Server) listProjects(w http.ResponseWriter, r *http.Request, _ []string) {
+	s.writeList(w, r, "projects", s.projects.list())
+}
+
+func (s *Server) createProject(w http.ResponseWriter, r *http.Request, _ []string) {
+	var req godo.CreateProjectRequest
+	if !readJSON(w, r, &req) {
+		return
+	}
+	if req.Name == "" || req.Purpose == "" {
+		writeError(w, http.StatusUnprocessableEntity, "name and purpose are required")
+		return
+	}
+
+	p := &godo.Project{
+		Name:        req.Name,
+		Description: req.Description,
+		Purpose:     req.Purpose,
+		Environment: req.Environment,
+	}
+	s.putProject(p)
+	writeJSON(w, http.StatusCreated, map[string]interface{}{"project": p})
+}
+
+func (s *Server) getProject(w http.ResponseWriter, r *http.Request, params []string) {
+	p, ok := s.project(params[0])
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"project": p})
+}
+
+func (s *Server) updateProject(w http.ResponseWriter, r *http.Request, params []string) {
+	p, ok := s.project(params[0])
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+
+	// Fields are optional: null or missing fields are left unchanged.
+	var req struct {
+		Name        *string `json:"name"`
+		Description *string `json:"description"`
+		Purpose     *string `json:"purpose"`
+		Environment *string `json:"environment"`
+		IsDefault   *bool   `json:"is_default"`
+	}
+	if !readJSON(w, r, &req) {
+		return
+	}
+
+	if req.Name != nil {
+		p.Name = *req.Name
+	}
+	if req.Description != nil {
+		p.Description = *req.Description
+	}
+	if req.Purpose != nil {
+		p.Purpose = *req.Purpose
+	}
+	if req.Environment != nil {
+		p.Environment = *req.Environment
+	}
+	if req.IsDefault != nil && *req.IsDefault {
+		for _, v := range s.projects.list() {
+			v.(*godo.Project).IsDefault = false
+		}
+		p.IsDefault = true
+	}
+	p.UpdatedAt = now()
+	writeJSON(w, http.StatusOK, map[string]interface{}{"project": p})
+}
+
+func (s *Server) deleteProject(w http.ResponseWriter, r *http.Request, params []string) {
+	p, ok := s.project(params[0])
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+	if p.IsDefault || len(s.resources[p.ID]) > 0 {
+		writeError(w, http.StatusPreconditionFailed, "cannot delete a default project or a project with resources")
+		return
+	}
+
+	s.projects.delete(p.ID)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) listProjectResources(w http.ResponseWriter, r *http.Request, params []string) {
+	p, ok := s.project(params[0])
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+
+	var resources []interface{}
+	for _, res := range s.resources[p.ID] {
+		resources = append(resources, res)
+	}
+	s.writeList(w, r, "resources", resources)
+}
+
+func (s *Server) assignProjectResources(w http.ResponseWriter, r *http.Request, params []string) {
+	p, ok := s.project(params[0])
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+
+	var req struct {
+		Resources []string `json:"resources"`
+	}
+	if !readJSON(w, r, &req) {
+		return
+	}
+
+	assigned := []godo.ProjectResource{}
+	for _, urn := range req.Resources {
+		if !strings.HasPrefix(urn, "do:") {
+			writeError(w, http.StatusUnprocessableEntity, "invalid URN "+urn)
+			return
+		}
+
+		// A resource belongs to a single project.
+		for id, resources := range s.resources {
+			s.resources[id] = removeResource(resources, urn)
+		}
+
+		res := godo.ProjectResource{
+			URN:        urn,
+			AssignedAt: now(),
+			Links:      &godo.ProjectResourceLinks{Self: s.resourceURL(urn)},
+			Status:     "assigned",
+		}
+		s.resources[p.ID] = append(s.resources[p.ID], res)
+		assigned = append(assigned, res)
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{"resources": assigned})
+}
+
+func removeResource(resources []godo.ProjectResource, urn string) []godo.ProjectResource {
+	out := resources[:0]
+	for _, res := range resources {
+		if res.URN != urn {
+			out = append(out, res)
+		}
+	}
+	return out
+}
+
+// resourceURL returns the API URL of the resource with the given URN, such
+// as "do:droplet:1".
+func (s *Server) resourceURL(urn string) string {
+	parts := strings.SplitN(urn, ":", 3)
+	if len(parts) != 3 {
+		return ""
+	}
+
+	collection := map[string]string{
+		"droplet":      "droplets",
+		"volume":       "volumes",
+		"floatingip":   "floating_ips",
+		"domain":       "domains",
+		"loadbalancer": "load_balancers",
+		"kubernetes":   "kubernetes/clusters",
+	}[parts[1]]
+	if collection == "" {
+		return ""
+	}
+	return s.URL + "/v2/" + collection + "/" + parts[2]
+}
@@ -0,0 +1,331 @@
+package godotest
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/digitalocean/godo"
+)
+
+// secretHeaders are the headers removed from fixtures.
+var secretHeaders = []string{
+	"Authorization",
+	"Proxy-Authorization",
+	"Cookie",
+	"Set-Cookie",
+}
+
+// Fixture is a sequence of recorded API interactions.
+type Fixture struct {
+	Interactions []*Interaction `json:"interactions"`
+}
+
+// Interaction is a recorded request and its response.
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+// RecordedRequest is a sanitized API request.
+type RecordedRequest struct {
+	Method string      `json:"method"`
+	Path   string      `json:"path"`
+	Query  string      `json:"query,omitempty"`
+	Header http.Header `json:"header,omitempty"`
+	Body   string      `json:"body,omitempty"`
+}
+
+// RecordedResponse is a sanitized API response.
+type RecordedResponse struct {
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body,omitempty"`
+}
+
+// String describes the request for error messages.
+func (r *RecordedRequest) String() string {
+	s := r.Method + " " + r.Path
+	if r.Query != "" {
+		s += "?" + r.Query
+	}
+	if r.Body != "" {
+		s += " " + r.Body
+	}
+	return s
+}
+
+// Recorder is an http.RoundTripper recording the requests it sends and their
+// responses, to be replayed by a Replayer. Credentials and secrets are
+// stripped from the recorded interactions. Use it as the transport of the
+// HTTP client given to godo.New:
+//
+//	rec := godotest.NewRecorder("testdata/droplets.json", http.DefaultTransport)
+//	defer rec.Save()
+//
+//	client, err := godo.New(&http.Client{
+//		Transport: &oauth2.Transport{Source: tokenSource, Base: rec},
+//	})
+//
+// A Recorder is safe for concurrent use.
+type Recorder struct {
+	path      string
+	transport http.RoundTripper
+	sanitizer *sanitizer
+
+	mu      sync.Mutex
+	fixture Fixture
+}
+
+// NewRecorder returns a Recorder sending requests with transport and saving
+// them to the fixture file at path. The values of redactKeys are redacted from
+// JSON bodies, in addition to known secrets such as passwords and tokens.
+func NewRecorder(path string, transport http.RoundTripper, redactKeys ...string) *Recorder {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	return &Recorder{
+		path:      path,
+		transport: transport,
+		sanitizer: newSanitizer(redactKeys),
+	}
+}
+
+// RoundTrip sends req and records it along with its response.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	var reqBody []byte
+	if req.Body != nil {
+		data, err := ioutil.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		reqBody = data
+		req.Body = ioutil.NopCloser(bytes.NewReader(data))
+	}
+
+	resp, err := r.transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	respBody, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = ioutil.NopCloser(bytes.NewReader(respBody))
+
+	i := &Interaction{
+		Request: r.sanitizer.request(req, reqBody),
+		Response: RecordedResponse{
+			StatusCode: resp.StatusCode,
+			Header:     r.sanitizer.header(resp.Header),
+			Body:       r.sanitizer.body(req.URL.Path, respBody),
+		},
+	}
+
+	r.mu.Lock()
+	r.fixture.Interactions = append(r.fixture.Interactions, i)
+	r.mu.Unlock()
+
+	return resp, nil
+}
+
+// Interactions returns the interactions recorded so far.
+func (r *Recorder) Interactions() []*Interaction {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]*Interaction{}, r.fixture.Interactions...)
+}
+
+// Save writes the recorded interactions to the fixture file, creating its
+// directory if needed.
+func (r *Recorder) Save() error {
+	r.mu.Lock()
+	data, err := json.MarshalIndent(&r.fixture, "", "  ")
+	r.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(r.path), 0755); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(r.path, append(data, '\n'), 0644)
+}
+
+// Replayer is an http.RoundTripper answering requests with the responses of
+// a fixture recorded by a Recorder, without network access. Requests match a
+// recorded interaction if their method, path, query and body are the same;
+// each interaction is replayed once, in the recorded order. A request that
+// does not match any interaction left fails with an error describing it.
+//
+// A Replayer is safe for concurrent use.
+type Replayer struct {
+	sanitizer *sanitizer
+
+	mu           sync.Mutex
+	interactions []*Interaction
+	used         []bool
+}
+
+// NewReplayer returns a Replayer for the fixture file at path. redactKeys
+// must be the keys given to the Recorder that created it.
+func NewReplayer(path string, redactKeys ...string) (*Replayer, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var f Fixture
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("godotest: invalid fixture %s: %v", path, err)
+	}
+
+	return &Replayer{
+		sanitizer:    newSanitizer(redactKeys),
+		interactions: f.Interactions,
+		used:         make([]bool, len(f.Interactions)),
+	}, nil
+}
+
+// RoundTrip returns the recorded response matching req.
+func (r *Replayer) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		data, err := ioutil.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		body = data
+	}
+	recorded := r.sanitizer.request(req, body)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for n, i := range r.interactions {
+		if r.used[n] || !matches(&i.Request, &recorded) {
+			continue
+		}
+
+		r.used[n] = true
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", i.Response.StatusCode, http.StatusText(i.Response.StatusCode)),
+			StatusCode:    i.Response.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        i.Response.Header.Clone(),
+			Body:          ioutil.NopCloser(strings.NewReader(i.Response.Body)),
+			ContentLength: int64(len(i.Response.Body)),
+			Request:       req,
+		}, nil
+	}
+
+	return nil, r.unmatched(&recorded)
+}
+
+// unmatched returns the error for a request matching no interaction left,
+// listing the candidates with the same method and path.
+func (r *Replayer) unmatched(req *RecordedRequest) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "godotest: no recorded interaction left for %s", req)
+
+	var candidates []string
+	for n, i := range r.interactions {
+		if i.Request.Method == req.Method && i.Request.Path == req.Path {
+			state := "unused"
+			if r.used[n] {
+				state = "already replayed"
+			}
+			candidates = append(candidates, fmt.Sprintf("%s (%s)", &i.Request, state))
+		}
+	}
+	if len(candidates) > 0 {
+		fmt.Fprintf(&b, "; recorded requests to the same endpoint: %s", strings.Join(candidates, ", "))
+	}
+	return errors.New(b.String())
+}
+
+// Unused returns the recorded interactions that have not been replayed, to
+// check that a test made all the requests it was recorded with.
+func (r *Replayer) Unused() []*Interaction {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var unused []*Interaction
+	for n, i := range r.interactions {
+		if !r.used[n] {
+			unused = append(unused, i)
+		}
+	}
+	return unused
+}
+
+func matches(recorded, req *RecordedRequest) bool {
+	return recorded.Method == req.Method &&
+		recorded.Path == req.Path &&
+		recorded.Query == req.Query &&
+		recorded.Body == req.Body
+}
+
+// sanitizer strips credentials and secrets from recorded interactions.
+type sanitizer struct {
+	redactor *godo.Redactor
+}
+
+func newSanitizer(redactKeys []string) *sanitizer {
+	return &sanitizer{redactor: godo.NewRedactor(redactKeys...)}
+}
+
+// request returns the sanitized form of req. The query is encoded with
+// sorted keys and JSON bodies are normalized, so equivalent requests have the
+// same form.
+func (s *sanitizer) request(req *http.Request, body []byte) RecordedRequest {
+	return RecordedRequest{
+		Method: req.Method,
+		Path:   req.URL.Path,
+		Query:  sortedQuery(req.URL.RawQuery),
+		Header: s.header(req.Header),
+		Body:   s.body(req.URL.Path, body),
+	}
+}
+
+func sortedQuery(raw string) string {
+	q, err := url.ParseQuery(raw)
+	if err != nil {
+		return raw
+	}
+	return q.Encode()
+}
+
+func (s *sanitizer) header(h http.Header) http.Header {
+	out := h.Clone()
+	for _, k := range secretHeaders {
+		out.Del(k)
+	}
+	if len(out) == 0 {
+		return nil
+	}
+	return out
+}
+
+func (s *sanitizer) body(path string, data []byte) string {
+	out, ok := s.redactor.Body(path, data)
+	if !ok {
+		return string(data)
+	}
+	return string(out)
+}
@@ -0,0 +1,533 @@
+// Package godotest provides an in-memory fake of the DigitalOcean v2 API for
+// testing code built on godo without network access.
+//
+// The fake keeps state across requests: resources created through it can be
+// listed, fetched, updated and deleted, lists are paginated with links and
+// meta like the real API, and actions progress from "in-progress" to
+// "completed" as they are polled. Failures can be injected per endpoint.
+//
+//	fake := godotest.NewServer()
+//	defer fake.Close()
+//
+//	client, _ := godo.New(nil, godo.SetBaseURL(fake.URL))
+//
+// Droplets, actions, volumes, floating IPs, domains, firewalls, load
+// balancers, tags, projects, VPCs and Kubernetes clusters are supported.
+package godotest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/digitalocean/godo"
+)
+
+const (
+	defaultPerPage = 20
+	maxPerPage     = 200
+
+	rateLimit = 5000
+)
+
+// Server is a fake DigitalOcean API server. It is safe for concurrent use.
+type Server struct {
+	// URL is the base URL of the server, to be used with godo.SetBaseURL.
+	URL string
+
+	server *httptest.Server
+	routes []route
+	polls  int
+
+	mu             sync.Mutex
+	nextID         int
+	failures       []*Failure
+	erroredActions map[string]bool
+	pending        map[string]*pending
+	requests       int
+
+	actions       *collection
+	droplets      *collection
+	volumes       *collection
+	floatingIPs   *collection
+	domains       *collection
+	records       map[string]*collection
+	firewalls     *collection
+	loadBalancers *collection
+	tags          *collection
+	projects      *collection
+	resources     map[string][]godo.ProjectResource
+	vpcs          *collection
+	clusters      *collection
+}
+
+// Option configures a Server.
+type Option func(*Server)
+
+// WithActionPolls sets how many times an action, or a resource being
+// provisioned such as a load balancer or a Kubernetes cluster, must be
+// fetched before it completes. The default is 1: the response to the request
+// starting an action reports it in progress, and the first fetch of the
+// action reports it completed.
+func WithActionPolls(n int) Option {
+	return func(s *Server) {
+		s.polls = n
+	}
+}
+
+// NewServer starts and returns a new Server. The caller should call Close
+// when finished, to shut it down.
+func NewServer(opts ...Option) *Server {
+	s := &Server{
+		polls:          1,
+		erroredActions: make(map[string]bool),
+		pending:        make(map[string]*pending),
+		actions:        newCollection(),
+		droplets:       newCollection(),
+		volumes:        newCollection(),
+		floatingIPs:    newCollection(),
+		domains:        newCollection(),
+		records:        make(map[string]*collection),
+		firewalls:      newCollection(),
+		loadBalancers:  newCollection(),
+		tags:           newCollection(),
+		projects:       newCollection(),
+		resources:      make(map[string][]godo.ProjectResource),
+		vpcs:           newCollection(),
+		clusters:       newCollection(),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	s.registerActions()
+	s.registerDroplets()
+	s.registerVolumes()
+	s.registerFloatingIPs()
+	s.registerDomains()
+	s.registerFirewalls()
+	s.registerLoadBalancers()
+	s.registerTags()
+	s.registerProjects()
+	s.registerVPCs()
+	s.registerKubernetes()
+
+	s.server = httptest.NewServer(s)
+	s.URL = s.server.URL
+	s.createDefaultProject()
+	return s
+}
+
+// Close shuts down the server.
+func (s *Server) Close() {
+	s.server.Close()
+}
+
+// Client returns a godo client sending requests to the server.
+func (s *Server) Client(opts ...godo.ClientOpt) (*godo.Client, error) {
+	return godo.New(s.server.Client(), append([]godo.ClientOpt{godo.SetBaseURL(s.URL)}, opts...)...)
+}
+
+// Failure describes an error response to inject.
+type Failure struct {
+	// Method is the HTTP method of the requests to fail. An empty method
+	// matches any method.
+	Method string
+
+	// Path is the path prefix of the requests to fail, such as
+	// "/v2/droplets". An empty path matches any request.
+	Path string
+
+	// StatusCode is the HTTP status code returned.
+	StatusCode int
+
+	// Message is the error message returned. It defaults to the status text.
+	Message string
+
+	// Header holds additional headers to return, such as Retry-After.
+	Header http.Header
+
+	// Times is the number of requests to fail. Zero fails every matching
+	// request.
+	Times int
+}
+
+// Fail injects a failure: matching requests are answered with an API error
+// instead of being served.
+func (s *Server) Fail(f Failure) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.failures = append(s.failures, &f)
+}
+
+// FailActions makes actions of the given type, such as "power_off", end with
+// the "errored" status instead of "completed".
+func (s *Server) FailActions(actionType string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.erroredActions[actionType] = true
+}
+
+// Reset removes all injected failures.
+func (s *Server) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.failures = nil
+	s.erroredActions = make(map[string]bool)
+}
+
+// Requests returns the number of requests served.
+func (s *Server) Requests() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.requests
+}
+
+// ServeHTTP serves the fake API.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.requests++
+	w.Header().Set("RateLimit-Limit", strconv.Itoa(rateLimit))
+	w.Header().Set("RateLimit-Remaining", strconv.Itoa(rateLimit-s.requests%rateLimit))
+	w.Header().Set("RateLimit-Reset", strconv.FormatInt(time.Now().Add(time.Hour).Unix(), 10))
+	w.Header().Set("X-Request-Id", fmt.Sprintf("godotest-%d", s.requests))
+
+	if f := s.failure(r); f != nil {
+		for k, v := range f.Header {
+			w.Header()[k] = v
+		}
+		writeError(w, f.StatusCode, f.Message)
+		return
+	}
+
+	segments := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	var found bool
+	for _, rt := range s.routes {
+		params, ok := rt.match(segments)
+		if !ok {
+			continue
+		}
+		found = true
+		if rt.method == r.Method {
+			rt.h(w, r, params)
+			return
+		}
+	}
+
+	if found {
+		writeError(w, http.StatusMethodNotAllowed, "")
+		return
+	}
+	writeError(w, http.StatusNotFound, "")
+}
+
+func (s *Server) failure(r *http.Request) *Failure {
+	for i, f := range s.failures {
+		if f.Method != "" && f.Method != r.Method {
+			continue
+		}
+		if !strings.HasPrefix(r.URL.Path, f.Path) {
+			continue
+		}
+
+		if f.Times > 0 {
+			f.Times--
+			if f.Times == 0 {
+				s.failures = append(s.failures[:i], s.failures[i+1:]...)
+			}
+		}
+		return f
+	}
+	return nil
+}
+
+// handler serves a request with the values of the wildcards of its route.
+type handler func(w http.ResponseWriter, r *http.Request, params []string)
+
+type route struct {
+	method   string
+	segments []string
+	h        handler
+}
+
+// handle registers h for requests with the given method and path pattern,
+// where "*" matches any path segment.
+func (s *Server) handle(method, pattern string, h handler) {
+	s.routes = append(s.routes, route{
+		method:   method,
+		segments: strings.Split(strings.Trim(pattern, "/"), "/"),
+		h:        h,
+	})
+}
+
+func (rt *route) match(segments []string) ([]string, bool) {
+	if len(rt.segments) != len(segments) {
+		return nil, false
+	}
+
+	var params []string
+	for i, s := range rt.segments {
+		switch {
+		case s == "*":
+			params = append(params, segments[i])
+		case s != segments[i]:
+			return nil, false
+		}
+	}
+	return params, true
+}
+
+// collection stores resources by ID in creation order.
+type collection struct {
+	ids   []string
+	items map[string]interface{}
+}
+
+func newCollection() *collection {
+	return &collection{items: make(map[string]interface{})}
+}
+
+func (c *collection) put(id string, v interface{}) {
+	if _, ok := c.items[id]; !ok {
+		c.ids = append(c.ids, id)
+	}
+	c.items[id] = v
+}
+
+func (c *collection) get(id string) (interface{}, bool) {
+	v, ok := c.items[id]
+	return v, ok
+}
+
+func (c *collection) delete(id string) bool {
+	if _, ok := c.items[id]; !ok {
+		return false
+	}
+
+	delete(c.items, id)
+	for i, v := range c.ids {
+		if v == id {
+			c.ids = append(c.ids[:i], c.ids[i+1:]...)
+			break
+		}
+	}
+	return true
+}
+
+func (c *collection) len() int {
+	return len(c.ids)
+}
+
+func (c *collection) list() []interface{} {
+	items := make([]interface{}, 0, len(c.ids))
+	for _, id := range c.ids {
+		items = append(items, c.items[id])
+	}
+	return items
+}
+
+// pending tracks a resource being provisioned, which becomes ready after
+// being fetched a number of times.
+type pending struct {
+	polls int
+	ready func()
+}
+
+// provision calls ready once the resource with the given key has been
+// fetched as many times as configured with WithActionPolls.
+func (s *Server) provision(key string, ready func()) {
+	s.pending[key] = &pending{ready: ready}
+}
+
+// poll records a fetch of the resource with the given key.
+func (s *Server) poll(key string) {
+	p, ok := s.pending[key]
+	if !ok {
+		return
+	}
+
+	p.polls++
+	if p.polls >= s.polls {
+		delete(s.pending, key)
+		p.ready()
+	}
+}
+
+func (s *Server) newID() int {
+	s.nextID++
+	return s.nextID
+}
+
+func (s *Server) newUUID() string {
+	id := s.newID()
+	return fmt.Sprintf("%08x-0000-4000-8000-%012x", id, id)
+}
+
+func now() string {
+	return time.Now().UTC().Format(time.RFC3339)
+}
+
+// page returns the items of the page requested by r, with the links and meta
+// of the list.
+func (s *Server) page(r *http.Request, items []interface{}) ([]interface{}, *godo.Links, *godo.Meta) {
+	q := r.URL.Query()
+	page, _ := strconv.Atoi(q.Get("page"))
+	if page < 1 {
+		page = 1
+	}
+	perPage, _ := strconv.Atoi(q.Get("per_page"))
+	if perPage < 1 {
+		perPage = defaultPerPage
+	}
+	if perPage > maxPerPage {
+		perPage = maxPerPage
+	}
+
+	last := (len(items) + perPage - 1) / perPage
+	if last < 1 {
+		last = 1
+	}
+
+	start := (page - 1) * perPage
+	if start > len(items) {
+		start = len(items)
+	}
+	end := start + perPage
+	if end > len(items) {
+		end = len(items)
+	}
+
+	link := func(p int) string {
+		u := url.URL{Path: r.URL.Path}
+		q := r.URL.Query()
+		q.Set("page", strconv.Itoa(p))
+		q.Set("per_page", strconv.Itoa(perPage))
+		u.RawQuery = q.Encode()
+		return s.URL + u.String()
+	}
+
+	links := &godo.Links{}
+	if last > 1 {
+		links.Pages = &godo.Pages{}
+		if page > 1 {
+			links.Pages.First = link(1)
+			links.Pages.Prev = link(page - 1)
+		}
+		if page < last {
+			links.Pages.Next = link(page + 1)
+			links.Pages.Last = link(last)
+		}
+	}
+
+	return append([]interface{}{}, items[start:end]...), links, &godo.Meta{Total: len(items)}
+}
+
+// writeList writes the page requested by r of items under key.
+func (s *Server) writeList(w http.ResponseWriter, r *http.Request, key string, items []interface{}) {
+	page, links, meta := s.page(r, items)
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		key:     page,
+		"links": links,
+		"meta":  meta,
+	})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+// errorIDs are the IDs of the API errors returned for status codes.
+var errorIDs = map[int]string{
+	http.StatusBadRequest:          "bad_request",
+	http.StatusUnauthorized:        "unauthorized",
+	http.StatusForbidden:           "forbidden",
+	http.StatusNotFound:            "not_found",
+	http.StatusMethodNotAllowed:    "method_not_allowed",
+	http.StatusConflict:            "conflict",
+	http.StatusUnprocessableEntity: "unprocessable_entity",
+	http.StatusTooManyRequests:     "too_many_requests",
+	http.StatusInternalServerError: "server_error",
+	http.StatusServiceUnavailable:  "service_unavailable",
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	id, ok := errorIDs[status]
+	if !ok {
+		id = "error"
+	}
+	if message == "" {
+		message = http.StatusText(status)
+	}
+
+	writeJSON(w, status, map[string]string{
+		"id":         id,
+		"message":    message,
+		"request_id": w.Header().Get("X-Request-Id"),
+	})
+}
+
+func writeNotFound(w http.ResponseWriter) {
+	writeError(w, http.StatusNotFound, "The resource you were accessing could not be found.")
+}
+
+// readJSON decodes the body of r into v, writing an error response and
+// returning false if it is invalid.
+func readJSON(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
+		return false
+	}
+	return true
+}
+
+func contains(values []string, v string) bool {
+	for _, s := range values {
+		if s == v {
+			return true
+		}
+	}
+	return false
+}
+
+func remove(values []string, v string) []string {
+	out := values[:0]
+	for _, s := range values {
+		if s != v {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+func containsInt(values []int, v int) bool {
+	for _, i := range values {
+		if i == v {
+			return true
+		}
+	}
+	return false
+}
+
+func removeInt(values []int, v int) []int {
+	out := values[:0]
+	for _, i := range values {
+		if i != v {
+			out = append(out, i)
+		}
+	}
+	return out
+}
@@ -0,0 +1,621 @@
+package godotest
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/digitalocean/godo"
+)
+
+var ctx = context.TODO()
+
+func setup(t *testing.T, opts ...Option) (*Server, *godo.Client) {
+	fake := NewServer(opts...)
+	client, err := fake.Client()
+	if err != nil {
+		fake.Close()
+		t.Fatalf("Client(): %v", err)
+	}
+	return fake, client
+}
+
+func createDroplet(t *testing.T, client *godo.Client, name string, tags ...string) (*godo.Droplet, *godo.Response) {
+	d, resp, err := client.Droplets.Create(ctx, &godo.DropletCreateRequest{
+		Name:   name,
+		Region: "nyc3",
+		Size:   "s-1vcpu-1gb",
+		Image:  godo.DropletCreateImage{Slug: "ubuntu-20-04-x64"},
+		Tags:   tags,
+	})
+	if err != nil {
+		t.Fatalf("Droplets.Create(): %v", err)
+	}
+	return d, resp
+}
+
+func TestDroplets(t *testing.T) {
+	fake, client := setup(t)
+	defer fake.Close()
+
+	d, resp := createDroplet(t, client, "web-1", "web")
+	if d.Status != "new" || d.Image.Slug != "ubuntu-20-04-x64" || d.Region.Slug != "nyc3" {
+		t.Errorf("created Droplet = %+v", d)
+	}
+	if len(resp.Links.Actions) != 1 || resp.Links.Actions[0].Rel != "create" {
+		t.Fatalf("create links = %+v, expected the create action", resp.Links)
+	}
+
+	a, _, err := client.Actions.Get(ctx, resp.Links.Actions[0].ID)
+	if err != nil {
+		t.Fatalf("Actions.Get(): %v", err)
+	}
+	if a.Status != godo.ActionCompleted || a.ResourceID != d.ID {
+		t.Errorf("create action = %+v, expected completed", a)
+	}
+
+	d, _, err = client.Droplets.Get(ctx, d.ID)
+	if err != nil {
+		t.Fatalf("Droplets.Get(): %v", err)
+	}
+	if d.Status != "active" || d.Locked {
+		t.Errorf("Droplet status = %s, locked = %v, expected active and unlocked", d.Status, d.Locked)
+	}
+
+	if _, err := client.Droplets.Delete(ctx, d.ID); err != nil {
+		t.Fatalf("Droplets.Delete(): %v", err)
+	}
+	_, _, err = client.Droplets.Get(ctx, d.ID)
+	if !errors.Is(err, godo.ErrNotFound) {
+		t.Errorf("Droplets.Get() error = %v, expected not found", err)
+	}
+}
+
+func TestDroplets_pagination(t *testing.T) {
+	fake, client := setup(t)
+	defer fake.Close()
+
+	var ids []int
+	for i := 0; i < 25; i++ {
+		d, _ := createDroplet(t, client, "d")
+		ids = append(ids, d.ID)
+	}
+
+	droplets, resp, err := client.Droplets.List(ctx, &godo.ListOptions{Page: 2, PerPage: 10})
+	if err != nil {
+		t.Fatalf("Droplets.List(): %v", err)
+	}
+	if len(droplets) != 10 || droplets[0].ID != ids[10] {
+		t.Errorf("page 2 = %d Droplets starting at %d, expected 10 starting at %d", len(droplets), droplets[0].ID, ids[10])
+	}
+	if page, _ := resp.Links.CurrentPage(); page != 2 {
+		t.Errorf("current page = %d, expected 2", page)
+	}
+	if resp.Links.IsLastPage() || resp.Meta.Total != 25 {
+		t.Errorf("links = %+v, meta = %+v", resp.Links.Pages, resp.Meta)
+	}
+
+	var all []godo.Droplet
+	err = godo.ListAll(ctx, &all, func(ctx context.Context, opt *godo.ListOptions) (interface{}, *godo.Response, error) {
+		return client.Droplets.List(ctx, opt)
+	}, &godo.ListOptions{PerPage: 7})
+	if err != nil {
+		t.Fatalf("ListAll(): %v", err)
+	}
+	if len(all) != 25 {
+		t.Errorf("ListAll() = %d Droplets, expected 25", len(all))
+	}
+}
+
+func TestDropletActions_byTag(t *testing.T) {
+	fake, client := setup(t, WithActionPolls(2))
+	defer fake.Close()
+
+	createDroplet(t, client, "web-1", "web")
+	createDroplet(t, client, "web-2", "web")
+	createDroplet(t, client, "db-1", "db")
+
+	actions, _, err := client.DropletActions.PowerOffByTag(ctx, "web")
+	if err != nil {
+		t.Fatalf("DropletActions.PowerOffByTag(): %v", err)
+	}
+	if len(actions) != 2 {
+		t.Fatalf("actions = %d, expected 2", len(actions))
+	}
+
+	for _, want := range []string{godo.ActionInProgress, godo.ActionCompleted} {
+		a, _, err := client.DropletActions.Get(ctx, actions[0].ResourceID, actions[0].ID)
+		if err != nil {
+			t.Fatalf("DropletActions.Get(): %v", err)
+		}
+		if a.Status != want {
+			t.Errorf("action status = %s, expected %s", a.Status, want)
+		}
+	}
+
+	d, _, _ := client.Droplets.Get(ctx, actions[0].ResourceID)
+	if d.Status != "off" {
+		t.Errorf("Droplet status = %s, expected off", d.Status)
+	}
+
+	tagged, _, err := client.Droplets.ListByTag(ctx, "web", nil)
+	if err != nil {
+		t.Fatalf("Droplets.ListByTag(): %v", err)
+	}
+	if len(tagged) != 2 {
+		t.Errorf("tagged Droplets = %d, expected 2", len(tagged))
+	}
+}
+
+func TestFail(t *testing.T) {
+	fake, client := setup(t)
+	defer fake.Close()
+
+	fake.Fail(Failure{Method: http.MethodGet, Path: "/v2/droplets", StatusCode: http.StatusServiceUnavailable, Times: 1})
+
+	_, _, err := client.Droplets.List(ctx, nil)
+	if !godo.IsRetryable(err) {
+		t.Fatalf("Droplets.List() error = %v, expected a retryable error", err)
+	}
+	if _, _, err := client.Droplets.List(ctx, nil); err != nil {
+		t.Errorf("Droplets.List() error = %v after the failure was exhausted", err)
+	}
+
+	fake.Fail(Failure{Path: "/v2/volumes", StatusCode: http.StatusTooManyRequests, Message: "slow down"})
+	for i := 0; i < 2; i++ {
+		_, _, err = client.Storage.ListVolumes(ctx, nil)
+		if !errors.Is(err, godo.ErrRateLimited) || !strings.Contains(err.Error(), "slow down") {
+			t.Errorf("Storage.ListVolumes() error = %v, expected rate limited", err)
+		}
+	}
+
+	fake.Reset()
+	if _, _, err := client.Storage.ListVolumes(ctx, nil); err != nil {
+		t.Errorf("Storage.ListVolumes() error = %v after Reset", err)
+	}
+}
+
+func TestFail_retried(t *testing.T) {
+	fake := NewServer()
+	defer fake.Close()
+
+	client, err := fake.Client(godo.SetRetryPolicy(godo.RetryPolicy{MaxAttempts: 3, WaitMin: time.Millisecond, WaitMax: time.Millisecond}))
+	if err != nil {
+		t.Fatalf("Client(): %v", err)
+	}
+
+	fake.Fail(Failure{Path: "/v2/tags", StatusCode: http.StatusBadGateway, Times: 2})
+	_, resp, err := client.Tags.List(ctx, nil)
+	if err != nil {
+		t.Fatalf("Tags.List(): %v", err)
+	}
+	if resp.Attempts != 3 {
+		t.Errorf("attempts = %d, expected 3", resp.Attempts)
+	}
+}
+
+func TestFailActions(t *testing.T) {
+	fake, client := setup(t)
+	defer fake.Close()
+
+	d, _ := createDroplet(t, client, "web-1")
+	fake.FailActions("reboot")
+
+	a, _, err := client.DropletActions.Reboot(ctx, d.ID)
+	if err != nil {
+		t.Fatalf("DropletActions.Reboot(): %v", err)
+	}
+	a, _, err = client.Actions.Get(ctx, a.ID)
+	if err != nil {
+		t.Fatalf("Actions.Get(): %v", err)
+	}
+	if a.Status != "errored" {
+		t.Errorf("action status = %s, expected errored", a.Status)
+	}
+}
+
+func TestWaitForAction(t *testing.T) {
+	fake, client := setup(t, WithActionPolls(2))
+	defer fake.Close()
+
+	d, _ := createDroplet(t, client, "web-1")
+	fake.FailActions("reboot")
+
+	opts := godo.ActionWaitOptions{WaitOptions: godo.WaitOptions{Interval: time.Millisecond, MaxInterval: time.Millisecond}}
+	a, _, err := client.DropletActions.PowerOff(ctx, d.ID)
+	if err != nil {
+		t.Fatalf("DropletActions.PowerOff(): %v", err)
+	}
+	if a, err = godo.WaitForAction(ctx, client.Actions, a.ID, opts); err != nil || a.Status != godo.ActionCompleted {
+		t.Errorf("WaitForAction() = %+v, %v", a, err)
+	}
+
+	a, _, err = client.DropletActions.Reboot(ctx, d.ID)
+	if err != nil {
+		t.Fatalf("DropletActions.Reboot(): %v", err)
+	}
+	_, err = godo.WaitForAction(ctx, client.Actions, a.ID, opts)
+	var actionErr *godo.ActionError
+	if !errors.As(err, &actionErr) {
+		t.Errorf("WaitForAction() error = %v, expected a *godo.ActionError", err)
+	}
+}
+
+func TestWaitForLinkedActions(t *testing.T) {
+	fake, client := setup(t, WithActionPolls(2))
+	defer fake.Close()
+
+	droplets, resp, err := client.Droplets.CreateMultiple(ctx, &godo.DropletMultiCreateRequest{
+		Names:  []string{"web-1", "web-2", "web-3"},
+		Region: "nyc3",
+		Size:   "s-1vcpu-1gb",
+		Image:  godo.DropletCreateImage{Slug: "ubuntu-20-04-x64"},
+	})
+	if err != nil {
+		t.Fatalf("Droplets.CreateMultiple(): %v", err)
+	}
+
+	batch, err := resp.WaitForLinkedActions(ctx, client.Actions, godo.BatchWaitOptions{
+		ActionWaitOptions: godo.ActionWaitOptions{WaitOptions: godo.WaitOptions{Interval: time.Millisecond, MaxInterval: time.Millisecond}},
+	})
+	if err != nil {
+		t.Fatalf("WaitForLinkedActions(): %v", err)
+	}
+	if len(batch.Completed) != len(droplets) {
+		t.Errorf("%d actions completed, expected %d", len(batch.Completed), len(droplets))
+	}
+
+	d, _, err := client.Droplets.Get(ctx, droplets[0].ID)
+	if err != nil {
+		t.Fatalf("Droplets.Get(): %v", err)
+	}
+	if d.Status != "active" {
+		t.Errorf("droplet status = %s, expected active", d.Status)
+	}
+}
+
+func TestVolumes(t *testing.T) {
+	fake, client := setup(t)
+	defer fake.Close()
+
+	d, _ := createDroplet(t, client, "web-1")
+	vol, _, err := client.Storage.CreateVolume(ctx, &godo.VolumeCreateRequest{Name: "data", Region: "nyc3", SizeGigaBytes: 10})
+	if err != nil {
+		t.Fatalf("Storage.CreateVolume(): %v", err)
+	}
+
+	_, _, err = client.Storage.CreateVolume(ctx, &godo.VolumeCreateRequest{Name: "data", Region: "nyc3", SizeGigaBytes: 10})
+	if !errors.Is(err, godo.ErrConflict) {
+		t.Errorf("duplicate Storage.CreateVolume() error = %v, expected conflict", err)
+	}
+
+	a, _, err := client.StorageActions.Attach(ctx, vol.ID, d.ID)
+	if err != nil {
+		t.Fatalf("StorageActions.Attach(): %v", err)
+	}
+	if _, _, err := client.StorageActions.Get(ctx, vol.ID, a.ID); err != nil {
+		t.Fatalf("StorageActions.Get(): %v", err)
+	}
+
+	volumes, _, err := client.Storage.ListVolumes(ctx, &godo.ListVolumeParams{Name: "data", Region: "nyc3"})
+	if err != nil {
+		t.Fatalf("Storage.ListVolumes(): %v", err)
+	}
+	if len(volumes) != 1 || len(volumes[0].DropletIDs) != 1 || volumes[0].DropletIDs[0] != d.ID {
+		t.Errorf("volumes = %+v, expected data attached to %d", volumes, d.ID)
+	}
+
+	d, _, _ = client.Droplets.Get(ctx, d.ID)
+	if len(d.VolumeIDs) != 1 || d.VolumeIDs[0] != vol.ID {
+		t.Errorf("Droplet volumes = %v, expected %s", d.VolumeIDs, vol.ID)
+	}
+}
+
+func TestFloatingIPs(t *testing.T) {
+	fake, client := setup(t)
+	defer fake.Close()
+
+	d, _ := createDroplet(t, client, "web-1")
+	fip, _, err := client.FloatingIPs.Create(ctx, &godo.FloatingIPCreateRequest{Region: "nyc3"})
+	if err != nil {
+		t.Fatalf("FloatingIPs.Create(): %v", err)
+	}
+
+	a, _, err := client.FloatingIPActions.Assign(ctx, fip.IP, d.ID)
+	if err != nil {
+		t.Fatalf("FloatingIPActions.Assign(): %v", err)
+	}
+	if _, _, err := client.FloatingIPActions.Get(ctx, fip.IP, a.ID); err != nil {
+		t.Fatalf("FloatingIPActions.Get(): %v", err)
+	}
+
+	fip, _, err = client.FloatingIPs.Get(ctx, fip.IP)
+	if err != nil {
+		t.Fatalf("FloatingIPs.Get(): %v", err)
+	}
+	if fip.Droplet == nil || fip.Droplet.ID != d.ID {
+		t.Errorf("floating IP Droplet = %+v, expected %d", fip.Droplet, d.ID)
+	}
+}
+
+func TestDomains(t *testing.T) {
+	fake, client := setup(t)
+	defer fake.Close()
+
+	if _, _, err := client.Domains.Create(ctx, &godo.DomainCreateRequest{Name: "example.com", IPAddress: "192.0.2.1"}); err != nil {
+		t.Fatalf("Domains.Create(): %v", err)
+	}
+
+	rec, _, err := client.Domains.CreateRecord(ctx, "example.com", &godo.DomainRecordEditRequest{Type: "CNAME", Name: "www", Data: "@"})
+	if err != nil {
+		t.Fatalf("Domains.CreateRecord(): %v", err)
+	}
+	if _, _, err := client.Domains.EditRecord(ctx, "example.com", rec.ID, &godo.DomainRecordEditRequest{Data: "example.com.", TTL: 60}); err != nil {
+		t.Fatalf("Domains.EditRecord(): %v", err)
+	}
+
+	records, _, err := client.Domains.Records(ctx, "example.com", nil)
+	if err != nil {
+		t.Fatalf("Domains.Records(): %v", err)
+	}
+	if len(records) != 5 {
+		t.Errorf("records = %d, expected 3 NS, 1 A and 1 CNAME", len(records))
+	}
+	if last := records[len(records)-1]; last.Data != "example.com." || last.TTL != 60 || last.Name != "www" {
+		t.Errorf("edited record = %+v", last)
+	}
+}
+
+func TestFirewalls(t *testing.T) {
+	fake, client := setup(t)
+	defer fake.Close()
+
+	d, _ := createDroplet(t, client, "web-1", "web")
+	f, _, err := client.Firewalls.Create(ctx, &godo.FirewallRequest{
+		Name:         "web",
+		InboundRules: []godo.InboundRule{{Protocol: "tcp", PortRange: "443", Sources: &godo.Sources{Addresses: []string{"0.0.0.0/0"}}}},
+	})
+	if err != nil {
+		t.Fatalf("Firewalls.Create(): %v", err)
+	}
+	if _, err := client.Firewalls.AddTags(ctx, f.ID, "web"); err != nil {
+		t.Fatalf("Firewalls.AddTags(): %v", err)
+	}
+
+	firewalls, _, err := client.Firewalls.ListByDroplet(ctx, d.ID, nil)
+	if err != nil {
+		t.Fatalf("Firewalls.ListByDroplet(): %v", err)
+	}
+	if len(firewalls) != 1 || firewalls[0].ID != f.ID {
+		t.Errorf("Droplet firewalls = %+v, expected %s", firewalls, f.ID)
+	}
+}
+
+func TestLoadBalancers(t *testing.T) {
+	fake, client := setup(t)
+	defer fake.Close()
+
+	lb, _, err := client.LoadBalancers.Create(ctx, &godo.LoadBalancerRequest{
+		Name:            "lb",
+		Region:          "nyc3",
+		ForwardingRules: []godo.ForwardingRule{{EntryProtocol: "http", EntryPort: 80, TargetProtocol: "http", TargetPort: 80}},
+		Tag:             "web",
+	})
+	if err != nil {
+		t.Fatalf("LoadBalancers.Create(): %v", err)
+	}
+	if lb.Status != "new" || lb.IP != "" {
+		t.Errorf("created load balancer = %+v, expected new", lb)
+	}
+
+	lb, _, err = client.LoadBalancers.Get(ctx, lb.ID)
+	if err != nil {
+		t.Fatalf("LoadBalancers.Get(): %v", err)
+	}
+	if lb.Status != "active" || lb.IP == "" {
+		t.Errorf("load balancer = %+v, expected active with an IP", lb)
+	}
+}
+
+func TestTags(t *testing.T) {
+	fake, client := setup(t)
+	defer fake.Close()
+
+	d, _ := createDroplet(t, client, "web-1")
+	if _, _, err := client.Tags.Create(ctx, &godo.TagCreateRequest{Name: "blue"}); err != nil {
+		t.Fatalf("Tags.Create(): %v", err)
+	}
+	_, err := client.Tags.TagResources(ctx, "blue", &godo.TagResourcesRequest{
+		Resources: []godo.Resource{{ID: strconv.Itoa(d.ID), Type: godo.DropletResourceType}},
+	})
+	if err != nil {
+		t.Fatalf("Tags.TagResources(): %v", err)
+	}
+
+	tag, _, err := client.Tags.Get(ctx, "blue")
+	if err != nil {
+		t.Fatalf("Tags.Get(): %v", err)
+	}
+	if tag.Resources.Count != 1 || tag.Resources.Droplets.LastTagged.ID != d.ID {
+		t.Errorf("tag resources = %+v, expected Droplet %d", tag.Resources, d.ID)
+	}
+
+	_, err = client.Tags.TagResources(ctx, "blue", &godo.TagResourcesRequest{
+		Resources: []godo.Resource{{ID: "404", Type: godo.DropletResourceType}},
+	})
+	if !errors.Is(err, godo.ErrUnprocessableEntity) {
+		t.Errorf("Tags.TagResources() error = %v, expected unprocessable entity", err)
+	}
+}
+
+func TestProjects(t *testing.T) {
+	fake, client := setup(t)
+	defer fake.Close()
+
+	def, _, err := client.Projects.GetDefault(ctx)
+	if err != nil {
+		t.Fatalf("Projects.GetDefault(): %v", err)
+	}
+	if !def.IsDefault {
+		t.Errorf("default project = %+v", def)
+	}
+
+	p, _, err := client.Projects.Create(ctx, &godo.CreateProjectRequest{Name: "app", Purpose: "Web Application"})
+	if err != nil {
+		t.Fatalf("Projects.Create(): %v", err)
+	}
+	if _, _, err := client.Projects.Update(ctx, p.ID, &godo.UpdateProjectRequest{Description: "the app"}); err != nil {
+		t.Fatalf("Projects.Update(): %v", err)
+	}
+
+	d, _ := createDroplet(t, client, "web-1")
+	if _, _, err := client.Projects.AssignResources(ctx, p.ID, d); err != nil {
+		t.Fatalf("Projects.AssignResources(): %v", err)
+	}
+
+	resources, _, err := client.Projects.ListResources(ctx, p.ID, nil)
+	if err != nil {
+		t.Fatalf("Projects.ListResources(): %v", err)
+	}
+	if len(resources) != 1 || resources[0].URN != d.URN() {
+		t.Errorf("project resources = %+v, expected %s", resources, d.URN())
+	}
+
+	p, _, _ = client.Projects.Get(ctx, p.ID)
+	if p.Name != "app" || p.Description != "the app" {
+		t.Errorf("updated project = %+v", p)
+	}
+}
+
+func TestVPCs(t *testing.T) {
+	fake, client := setup(t)
+	defer fake.Close()
+
+	vpc, _, err := client.VPCs.Create(ctx, &godo.VPCCreateRequest{Name: "net", RegionSlug: "nyc3"})
+	if err != nil {
+		t.Fatalf("VPCs.Create(): %v", err)
+	}
+	vpc, _, err = client.VPCs.Set(ctx, vpc.ID, godo.VPCSetDescription("private"))
+	if err != nil {
+		t.Fatalf("VPCs.Set(): %v", err)
+	}
+	if vpc.Name != "net" || vpc.Description != "private" || vpc.IPRange == "" {
+		t.Errorf("VPC = %+v", vpc)
+	}
+}
+
+func TestKubernetes(t *testing.T) {
+	fake, client := setup(t, WithActionPolls(2))
+	defer fake.Close()
+
+	c, _, err := client.Kubernetes.Create(ctx, &godo.KubernetesClusterCreateRequest{
+		Name:       "k8s",
+		RegionSlug: "nyc3",
+		NodePools:  []*godo.KubernetesNodePoolCreateRequest{{Name: "pool", Size: "s-2vcpu-4gb", Count: 3}},
+	})
+	if err != nil {
+		t.Fatalf("Kubernetes.Create(): %v", err)
+	}
+	if c.Status.State != godo.KubernetesClusterStatusProvisioning || len(c.NodePools[0].Nodes) != 3 {
+		t.Errorf("created cluster = %+v", c)
+	}
+
+	for _, want := range []godo.KubernetesClusterStatusState{godo.KubernetesClusterStatusProvisioning, godo.KubernetesClusterStatusRunning} {
+		c, _, err = client.Kubernetes.Get(ctx, c.ID)
+		if err != nil {
+			t.Fatalf("Kubernetes.Get(): %v", err)
+		}
+		if c.Status.State != want {
+			t.Errorf("cluster state = %s, expected %s", c.Status.State, want)
+		}
+	}
+
+	count := 1
+	np, _, err := client.Kubernetes.UpdateNodePool(ctx, c.ID, c.NodePools[0].ID, &godo.KubernetesNodePoolUpdateRequest{Count: &count})
+	if err != nil {
+		t.Fatalf("Kubernetes.UpdateNodePool(): %v", err)
+	}
+	if np.Count != 1 || len(np.Nodes) != 1 {
+		t.Errorf("node pool = %+v, expected a single node", np)
+	}
+
+	config, _, err := client.Kubernetes.GetKubeConfig(ctx, c.ID)
+	if err != nil {
+		t.Fatalf("Kubernetes.GetKubeConfig(): %v", err)
+	}
+	if !strings.Contains(string(config.KubeconfigYAML), c.Endpoint) {
+		t.Errorf("kubeconfig = %s, expected the cluster endpoint", config.KubeconfigYAML)
+	}
+}
+
+func TestWaitForKubernetesCluster(t *testing.T) {
+	fake, client := setup(t, WithActionPolls(3))
+	defer fake.Close()
+
+	c, _, err := client.Kubernetes.Create(ctx, &godo.KubernetesClusterCreateRequest{
+		Name:       "k8s",
+		RegionSlug: "nyc3",
+		NodePools:  []*godo.KubernetesNodePoolCreateRequest{{Name: "pool", Size: "s-2vcpu-4gb", Count: 1}},
+	})
+	if err != nil {
+		t.Fatalf("Kubernetes.Create(): %v", err)
+	}
+
+	var states []string
+	c, err = godo.WaitForKubernetesCluster(ctx, client.Kubernetes, c.ID, godo.ResourceWaitOptions{
+		WaitOptions: godo.WaitOptions{Interval: time.Millisecond, MaxInterval: time.Millisecond},
+		OnProgress:  func(state string) { states = append(states, state) },
+	})
+	if err != nil || c.Status.State != godo.KubernetesClusterStatusRunning {
+		t.Fatalf("WaitForKubernetesCluster() = %+v, %v", c, err)
+	}
+	if len(states) != 3 {
+		t.Errorf("states = %v, expected 3 polls", states)
+	}
+}
+
+func TestServer_noSchemaDrift(t *testing.T) {
+	fake := NewServer(WithActionPolls(1))
+	defer fake.Close()
+
+	client, err := fake.Client(godo.SetStrictDecoding(godo.StrictDecodingOptions{Fail: true}))
+	if err != nil {
+		t.Fatalf("Client(): %v", err)
+	}
+
+	d, _ := createDroplet(t, client, "web", "frontend")
+	if _, _, err := client.Droplets.Get(ctx, d.ID); err != nil {
+		t.Errorf("Droplets.Get(): %v", err)
+	}
+	if _, _, err := client.Droplets.List(ctx, nil); err != nil {
+		t.Errorf("Droplets.List(): %v", err)
+	}
+	if _, _, err := client.DropletActions.PowerOff(ctx, d.ID); err != nil {
+		t.Errorf("DropletActions.PowerOff(): %v", err)
+	}
+	if _, _, err := client.Tags.List(ctx, nil); err != nil {
+		t.Errorf("Tags.List(): %v", err)
+	}
+	if _, _, err := client.Projects.GetDefault(ctx); err != nil {
+		t.Errorf("Projects.GetDefault(): %v", err)
+	}
+	if _, _, err := client.LoadBalancers.Create(ctx, &godo.LoadBalancerRequest{
+		Name:   "lb",
+		Region: "nyc3",
+		ForwardingRules: []godo.ForwardingRule{{
+			EntryProtocol:  "http",
+			EntryPort:      80,
+			TargetProtocol: "http",
+			TargetPort:     80,
+		}},
+	}); err != nil {
+		t.Errorf("LoadBalancers.Create(): %v", err)
+	}
+	if _, _, err := client.Storage.CreateVolume(ctx, &godo.VolumeCreateRequest{Name: "data", Region: "nyc3", SizeGigaBytes: 10}); err != nil {
+		t.Errorf("Storage.CreateVolume(): %v", err)
+	}
+}
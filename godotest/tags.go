@@ -0,0 +1,153 @@
+package godotest
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/digitalocean/godo"
+)
+
+func (s *Server) registerTags() {
+	s.handle(http.MethodGet, "/v2/tags", s.listTags)
+	s.handle(http.MethodPost, "/v2/tags", s.createTag)
+	s.handle(http.MethodGet, "/v2/tags/*", s.getTag)
+	s.handle(http.MethodDelete, "/v2/tags/*", s.deleteTag)
+	s.handle(http.MethodPost, "/v2/tags/*/resources", s.tagResources(true))
+	s.handle(http.MethodDelete, "/v2/tags/*/resources", s.tagResources(false))
+}
+
+// ensureTags creates the tags that do not exist yet, as the API does when
+// resources are created with tags.
+func (s *Server) ensureTags(tags []string) {
+	for _, name := range tags {
+		if _, ok := s.tags.get(name); !ok {
+			s.tags.put(name, name)
+		}
+	}
+}
+
+// tag returns the tag with its tagged resources.
+func (s *Server) tag(name string) *godo.Tag {
+	resources := &godo.TaggedResources{
+		Droplets:        &godo.TaggedDropletsResources{},
+		Images:          &godo.TaggedImagesResources{},
+		Volumes:         &godo.TaggedVolumesResources{},
+		VolumeSnapshots: &godo.TaggedVolumeSnapshotsResources{},
+		Databases:       &godo.TaggedDatabasesResources{},
+	}
+
+	for _, d := range s.taggedDroplets(name) {
+		resources.Count++
+		resources.Droplets.Count++
+		resources.Droplets.LastTagged = d
+		resources.Droplets.LastTaggedURI = s.URL + "/v2/droplets/" + strconv.Itoa(d.ID)
+		resources.LastTaggedURI = resources.Droplets.LastTaggedURI
+	}
+	for _, v := range s.volumes.list() {
+		if vol := v.(*godo.Volume); contains(vol.Tags, name) {
+			resources.Count++
+			resources.Volumes.Count++
+			resources.Volumes.LastTaggedURI = s.URL + "/v2/volumes/" + vol.ID
+			resources.LastTaggedURI = resources.Volumes.LastTaggedURI
+		}
+	}
+
+	return &godo.Tag{Name: name, Resources: resources}
+}
+
+func (s *Server) listTags(w http.ResponseWriter, r *http.Request, _ []string) {
+	var tags []interface{}
+	for _, v := range s.tags.list() {
+		tags = append(tags, s.tag(v.(string)))
+	}
+	s.writeList(w, r, "tags", tags)
+}
+
+func (s *Server) createTag(w http.ResponseWriter, r *http.Request, _ []string) {
+	var req godo.TagCreateRequest
+	if !readJSON(w, r, &req) {
+		return
+	}
+	if req.Name == "" {
+		writeError(w, http.StatusUnprocessableEntity, "name is required")
+		return
+	}
+
+	s.ensureTags([]string{req.Name})
+	writeJSON(w, http.StatusCreated, map[string]interface{}{"tag": s.tag(req.Name)})
+}
+
+func (s *Server) getTag(w http.ResponseWriter, r *http.Request, params []string) {
+	if _, ok := s.tags.get(params[0]); !ok {
+		writeNotFound(w)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"tag": s.tag(params[0])})
+}
+
+func (s *Server) deleteTag(w http.ResponseWriter, r *http.Request, params []string) {
+	name := params[0]
+	if !s.tags.delete(name) {
+		writeNotFound(w)
+		return
+	}
+
+	for _, d := range s.taggedDroplets(name) {
+		d.Tags = remove(d.Tags, name)
+	}
+	for _, v := range s.volumes.list() {
+		vol := v.(*godo.Volume)
+		vol.Tags = remove(vol.Tags, name)
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// tagResources returns a handler tagging or untagging resources.
+func (s *Server) tagResources(add bool) handler {
+	return func(w http.ResponseWriter, r *http.Request, params []string) {
+		name := params[0]
+		if _, ok := s.tags.get(name); !ok {
+			writeNotFound(w)
+			return
+		}
+
+		var req godo.TagResourcesRequest
+		if !readJSON(w, r, &req) {
+			return
+		}
+
+		// Validate all resources before changing any.
+		tags := make([]*[]string, len(req.Resources))
+		for i, res := range req.Resources {
+			switch res.Type {
+			case godo.DropletResourceType:
+				d, ok := s.droplet(res.ID)
+				if !ok {
+					writeError(w, http.StatusUnprocessableEntity, "droplet "+res.ID+" not found")
+					return
+				}
+				tags[i] = &d.Tags
+			case godo.VolumeResourceType:
+				vol, ok := s.volume(res.ID)
+				if !ok {
+					writeError(w, http.StatusUnprocessableEntity, "volume "+res.ID+" not found")
+					return
+				}
+				tags[i] = &vol.Tags
+			default:
+				writeError(w, http.StatusUnprocessableEntity, "unsupported resource type "+string(res.Type))
+				return
+			}
+		}
+
+		for _, t := range tags {
+			switch {
+			case add && !contains(*t, name):
+				*t = append(*t, name)
+			case !add:
+				*t = remove(*t, name)
+			}
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
@@ -0,0 +1,185 @@
+package godotest
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/digitalocean/godo"
+)
+
+func (s *Server) registerVolumes() {
+	s.handle(http.MethodGet, "/v2/volumes", s.listVolumes)
+	s.handle(http.MethodPost, "/v2/volumes", s.createVolume)
+	s.handle(http.MethodGet, "/v2/volumes/*", s.getVolume)
+	s.handle(http.MethodDelete, "/v2/volumes/*", s.deleteVolume)
+	s.handle(http.MethodGet, "/v2/volumes/*/actions", s.listVolumeActions)
+	s.handle(http.MethodPost, "/v2/volumes/*/actions", s.volumeAction)
+	s.handle(http.MethodGet, "/v2/volumes/*/actions/*", s.getVolumeAction)
+}
+
+func (s *Server) volume(id string) (*godo.Volume, bool) {
+	v, ok := s.volumes.get(id)
+	if !ok {
+		return nil, false
+	}
+	return v.(*godo.Volume), true
+}
+
+func (s *Server) listVolumes(w http.ResponseWriter, r *http.Request, _ []string) {
+	q := r.URL.Query()
+
+	var volumes []interface{}
+	for _, v := range s.volumes.list() {
+		vol := v.(*godo.Volume)
+		if name := q.Get("name"); name != "" && vol.Name != name {
+			continue
+		}
+		if region := q.Get("region"); region != "" && vol.Region.Slug != region {
+			continue
+		}
+		volumes = append(volumes, vol)
+	}
+	s.writeList(w, r, "volumes", volumes)
+}
+
+func (s *Server) createVolume(w http.ResponseWriter, r *http.Request, _ []string) {
+	var req godo.VolumeCreateRequest
+	if !readJSON(w, r, &req) {
+		return
+	}
+	if req.Name == "" || req.Region == "" || (req.SizeGigaBytes <= 0 && req.SnapshotID == "") {
+		writeError(w, http.StatusUnprocessableEntity, "name, region and size_gigabytes are required")
+		return
+	}
+
+	for _, v := range s.volumes.list() {
+		if vol := v.(*godo.Volume); vol.Name == req.Name && vol.Region.Slug == req.Region {
+			writeError(w, http.StatusConflict, "a volume with the name "+req.Name+" already exists in "+req.Region)
+			return
+		}
+	}
+
+	vol := &godo.Volume{
+		ID:              s.newUUID(),
+		Region:          &godo.Region{Slug: req.Region},
+		Name:            req.Name,
+		SizeGigaBytes:   req.SizeGigaBytes,
+		Description:     req.Description,
+		DropletIDs:      []int{},
+		CreatedAt:       time.Now().UTC().Truncate(time.Second),
+		FilesystemType:  req.FilesystemType,
+		FilesystemLabel: req.FilesystemLabel,
+		Tags:            append([]string{}, req.Tags...),
+	}
+	s.volumes.put(vol.ID, vol)
+	s.ensureTags(vol.Tags)
+	writeJSON(w, http.StatusCreated, map[string]interface{}{"volume": vol})
+}
+
+func (s *Server) getVolume(w http.ResponseWriter, r *http.Request, params []string) {
+	vol, ok := s.volume(params[0])
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"volume": vol})
+}
+
+func (s *Server) deleteVolume(w http.ResponseWriter, r *http.Request, params []string) {
+	vol, ok := s.volume(params[0])
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+	if len(vol.DropletIDs) > 0 {
+		writeError(w, http.StatusConflict, "volume is attached to a Droplet")
+		return
+	}
+
+	s.volumes.delete(vol.ID)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) listVolumeActions(w http.ResponseWriter, r *http.Request, params []string) {
+	if _, ok := s.volume(params[0]); !ok {
+		writeNotFound(w)
+		return
+	}
+
+	s.writeList(w, r, "actions", s.resourceActions("volume", params[0]))
+}
+
+func (s *Server) getVolumeAction(w http.ResponseWriter, r *http.Request, params []string) {
+	if _, ok := s.volume(params[0]); !ok {
+		writeNotFound(w)
+		return
+	}
+	s.writeAction(w, params[1], "volume")
+}
+
+func (s *Server) volumeAction(w http.ResponseWriter, r *http.Request, params []string) {
+	vol, ok := s.volume(params[0])
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+
+	var req godo.ActionRequest
+	if !readJSON(w, r, &req) {
+		return
+	}
+
+	var done func()
+	actionType, _ := req["type"].(string)
+	switch actionType {
+	case "attach":
+		d, ok := s.droplet(strconv.Itoa(intParam(req, "droplet_id")))
+		if !ok {
+			writeError(w, http.StatusUnprocessableEntity, "droplet not found")
+			return
+		}
+		done = func() {
+			if !containsInt(vol.DropletIDs, d.ID) {
+				vol.DropletIDs = append(vol.DropletIDs, d.ID)
+				d.VolumeIDs = append(d.VolumeIDs, vol.ID)
+			}
+		}
+	case "detach":
+		dropletID := intParam(req, "droplet_id")
+		if !containsInt(vol.DropletIDs, dropletID) {
+			writeError(w, http.StatusUnprocessableEntity, "volume is not attached to the Droplet")
+			return
+		}
+		done = func() {
+			vol.DropletIDs = removeInt(vol.DropletIDs, dropletID)
+			if d, ok := s.droplet(strconv.Itoa(dropletID)); ok {
+				d.VolumeIDs = remove(d.VolumeIDs, vol.ID)
+			}
+		}
+	case "resize":
+		size := int64(intParam(req, "size_gigabytes"))
+		if size <= vol.SizeGigaBytes {
+			writeError(w, http.StatusUnprocessableEntity, "volumes can only be resized to a larger size")
+			return
+		}
+		done = func() { vol.SizeGigaBytes = size }
+	default:
+		writeError(w, http.StatusUnprocessableEntity, "unsupported volume action "+actionType)
+		return
+	}
+
+	writeStartedAction(w, s.startAction(actionType, "volume", vol.ID, vol.Region.Slug, done))
+}
+
+// intParam returns the integer value of key in an action request.
+func intParam(req godo.ActionRequest, key string) int {
+	switch v := req[key].(type) {
+	case float64:
+		return int(v)
+	case string:
+		i, _ := strconv.Atoi(v)
+		return i
+	}
+	return 0
+}
@@ -0,0 +1,115 @@
+package godotest
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/digitalocean/godo"
+)
+
+func (s *Server) registerVPCs() {
+	s.handle(http.MethodGet, "/v2/vpcs", s.listVPCs)
+	s.handle(http.MethodPost, "/v2/vpcs", s.createVPC)
+	s.handle(http.MethodGet, "/v2/vpcs/*", s.getVPC)
+	s.handle(http.MethodPut, "/v2/vpcs/*", s.updateVPC)
+	s.handle(http.MethodPatch, "/v2/vpcs/*", s.updateVPC)
+	s.handle(http.MethodDelete, "/v2/vpcs/*", s.deleteVPC)
+}
+
+func (s *Server) vpc(id string) (*godo.VPC, bool) {
+	v, ok := s.vpcs.get(id)
+	if !ok {
+		return nil, false
+	}
+	return v.(*godo.VPC), true
+}
+
+func (s *Server) listVPCs(w http.ResponseWriter, r *http.Request, _ []string) {
+	s.writeList(w, r, "vpcs", s.vpcs.list())
+}
+
+func (s *Server) createVPC(w http.ResponseWriter, r *http.Request, _ []string) {
+	var req godo.VPCCreateRequest
+	if !readJSON(w, r, &req) {
+		return
+	}
+	if req.Name == "" || req.RegionSlug == "" {
+		writeError(w, http.StatusUnprocessableEntity, "name and region are required")
+		return
+	}
+
+	id := s.newUUID()
+	vpc := &godo.VPC{
+		ID:          id,
+		URN:         "do:vpc:" + id,
+		Name:        req.Name,
+		Description: req.Description,
+		IPRange:     req.IPRange,
+		RegionSlug:  req.RegionSlug,
+		CreatedAt:   time.Now().UTC().Truncate(time.Second),
+	}
+	if vpc.IPRange == "" {
+		vpc.IPRange = fmt.Sprintf("10.%d.0.0/20", s.vpcs.len()%256)
+	}
+
+	s.vpcs.put(id, vpc)
+	writeJSON(w, http.StatusCreated, map[string]interface{}{"vpc": vpc})
+}
+
+func (s *Server) getVPC(w http.ResponseWriter, r *http.Request, params []string) {
+	vpc, ok := s.vpc(params[0])
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"vpc": vpc})
+}
+
+// updateVPC serves both updates, which replace all fields, and partial
+// updates setting only the fields present.
+func (s *Server) updateVPC(w http.ResponseWriter, r *http.Request, params []string) {
+	vpc, ok := s.vpc(params[0])
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+
+	var req struct {
+		Name        *string `json:"name"`
+		Description *string `json:"description"`
+	}
+	if !readJSON(w, r, &req) {
+		return
+	}
+	if r.Method == http.MethodPut && (req.Name == nil || *req.Name == "") {
+		writeError(w, http.StatusUnprocessableEntity, "name is required")
+		return
+	}
+
+	if req.Name != nil {
+		vpc.Name = *req.Name
+	}
+	if req.Description != nil {
+		vpc.Description = *req.Description
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"vpc": vpc})
+}
+
+func (s *Server) deleteVPC(w http.ResponseWriter, r *http.Request, params []string) {
+	vpc, ok := s.vpc(params[0])
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+
+	for _, v := range s.droplets.list() {
+		if v.(*godo.Droplet).VPCUUID == vpc.ID {
+			writeError(w, http.StatusForbidden, "cannot delete a VPC with members")
+			return
+		}
+	}
+
+	s.vpcs.delete(vpc.ID)
+	w.WriteHeader(http.StatusNoContent)
+}
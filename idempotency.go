@@ -0,0 +1,307 @@
+package godo
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	idempotencyTagPrefix = "idempotency-key:"
+
+	defaultIdempotencyMaxAttempts = 3
+	defaultIdempotencyWait        = 2 * time.Second
+)
+
+// IdempotencyOptions configures the idempotent create helpers, such as
+// IdempotentCreateDroplet.
+type IdempotencyOptions struct {
+	// Key identifies the resource to create. It must only contain letters,
+	// numbers, dashes and underscores. Defaults to a random key.
+	Key string
+
+	// MaxAttempts is the total number of create requests made, and of
+	// lookups made after each of them. Defaults to 3.
+	MaxAttempts int
+
+	// AttemptTimeout, if set, bounds each create request and lookup, so that
+	// a request that hangs is retried instead of exhausting ctx.
+	AttemptTimeout time.Duration
+
+	// Wait is the delay before looking up the resource after an ambiguous
+	// failure, giving the API time to list a resource it has just created.
+	// Defaults to 2s.
+	Wait time.Duration
+}
+
+// IdempotencyTag returns the tag identifying the resources created with key.
+func IdempotencyTag(key string) string {
+	return idempotencyTagPrefix + key
+}
+
+// NewIdempotencyKey returns a random idempotency key.
+func NewIdempotencyKey() string {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return strconv.FormatInt(time.Now().UnixNano(), 16)
+	}
+	return hex.EncodeToString(b)
+}
+
+// IdempotentCreateDroplet creates a Droplet tagged with the IdempotencyTag of
+// opts.Key. When the create request fails in a way that leaves unknown
+// whether the Droplet was created, such as a timeout or a 5xx response, the
+// Droplets with that tag are listed before the request is made again, and the
+// existing Droplet is returned if there is one.
+func IdempotentCreateDroplet(ctx context.Context, s DropletsService, req *DropletCreateRequest, opts IdempotencyOptions) (*Droplet, *Response, error) {
+	if req == nil {
+		return nil, nil, NewArgError("req", "cannot be nil")
+	}
+	opts, err := opts.withDefaults()
+	if err != nil {
+		return nil, nil, err
+	}
+	tag := IdempotencyTag(opts.Key)
+
+	r := *req
+	r.Tags = appendTag(req.Tags, tag)
+
+	var droplet *Droplet
+	resp, err := opts.create(ctx,
+		func(ctx context.Context) (*Response, error) {
+			d, resp, err := s.Create(ctx, &r)
+			droplet = d
+			return resp, err
+		},
+		func(ctx context.Context) (bool, *Response, error) {
+			droplets, resp, err := s.ListByTag(ctx, tag, nil)
+			if err != nil || len(droplets) == 0 {
+				return false, resp, err
+			}
+			droplet = &droplets[0]
+			return true, resp, nil
+		},
+	)
+	if err != nil {
+		return nil, resp, err
+	}
+	return droplet, resp, nil
+}
+
+// IdempotentCreateVolume creates a volume tagged with the IdempotencyTag of
+// opts.Key, looking up the volumes with the name and region of req after an
+// ambiguous failure as described for IdempotentCreateDroplet. req.Name is
+// required.
+func IdempotentCreateVolume(ctx context.Context, s StorageService, req *VolumeCreateRequest, opts IdempotencyOptions) (*Volume, *Response, error) {
+	if req == nil {
+		return nil, nil, NewArgError("req", "cannot be nil")
+	}
+	if req.Name == "" {
+		return nil, nil, NewArgError("req.Name", "cannot be empty")
+	}
+	opts, err := opts.withDefaults()
+	if err != nil {
+		return nil, nil, err
+	}
+	tag := IdempotencyTag(opts.Key)
+
+	r := *req
+	r.Tags = appendTag(req.Tags, tag)
+
+	var volume *Volume
+	resp, err := opts.create(ctx,
+		func(ctx context.Context) (*Response, error) {
+			v, resp, err := s.CreateVolume(ctx, &r)
+			volume = v
+			return resp, err
+		},
+		func(ctx context.Context) (bool, *Response, error) {
+			volumes, resp, err := s.ListVolumes(ctx, &ListVolumeParams{Name: r.Name, Region: r.Region})
+			if err != nil {
+				return false, resp, err
+			}
+			for i := range volumes {
+				if contains(volumes[i].Tags, tag) {
+					volume = &volumes[i]
+					return true, resp, nil
+				}
+			}
+			return false, resp, nil
+		},
+	)
+	if err != nil {
+		return nil, resp, err
+	}
+	return volume, resp, nil
+}
+
+// IdempotentCreateLoadBalancer creates a load balancer tagged with the
+// IdempotencyTag of opts.Key, looking up the load balancers with that tag
+// after an ambiguous failure as described for IdempotentCreateDroplet.
+func IdempotentCreateLoadBalancer(ctx context.Context, s LoadBalancersService, req *LoadBalancerRequest, opts IdempotencyOptions) (*LoadBalancer, *Response, error) {
+	if req == nil {
+		return nil, nil, NewArgError("req", "cannot be nil")
+	}
+	opts, err := opts.withDefaults()
+	if err != nil {
+		return nil, nil, err
+	}
+	tag := IdempotencyTag(opts.Key)
+
+	r := *req
+	r.Tags = appendTag(req.Tags, tag)
+
+	var lb *LoadBalancer
+	resp, err := opts.create(ctx,
+		func(ctx context.Context) (*Response, error) {
+			l, resp, err := s.Create(ctx, &r)
+			lb = l
+			return resp, err
+		},
+		func(ctx context.Context) (bool, *Response, error) {
+			pager := NewPager(func(ctx context.Context, opt *ListOptions) (interface{}, *Response, error) {
+				return s.List(ctx, opt)
+			}, nil)
+			for pager.Next(ctx) {
+				for _, l := range pager.Items().([]LoadBalancer) {
+					if contains(l.Tags, tag) {
+						l := l
+						lb = &l
+						return true, pager.Response(), nil
+					}
+				}
+			}
+			return false, pager.Response(), pager.Err()
+		},
+	)
+	if err != nil {
+		return nil, resp, err
+	}
+	return lb, resp, nil
+}
+
+func (o IdempotencyOptions) withDefaults() (IdempotencyOptions, error) {
+	if o.Key == "" {
+		o.Key = NewIdempotencyKey()
+	}
+	for _, r := range o.Key {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-' || r == '_') {
+			return o, NewArgError("Key", "must only contain letters, numbers, dashes and underscores")
+		}
+	}
+	if o.MaxAttempts <= 0 {
+		o.MaxAttempts = defaultIdempotencyMaxAttempts
+	}
+	if o.Wait <= 0 {
+		o.Wait = defaultIdempotencyWait
+	}
+	return o, nil
+}
+
+// create calls create until it succeeds or fails unambiguously. After an
+// ambiguous failure, lookup is called to find the resource that create may
+// have created before calling create again.
+func (o IdempotencyOptions) create(
+	ctx context.Context,
+	create func(context.Context) (*Response, error),
+	lookup func(context.Context) (bool, *Response, error)) (*Response, error) {
+	for attempt := 1; ; attempt++ {
+		resp, err := o.attempt(ctx, create)
+		if err == nil || !isAmbiguous(ctx, err) {
+			return resp, err
+		}
+		createErr := err
+
+		for lookups := 1; ; lookups++ {
+			if err := sleep(ctx, o.Wait); err != nil {
+				return nil, err
+			}
+
+			var found bool
+			_, err := o.attempt(ctx, func(ctx context.Context) (*Response, error) {
+				var err error
+				found, resp, err = lookup(ctx)
+				return resp, err
+			})
+			if err == nil && found {
+				return resp, nil
+			}
+			if err == nil {
+				break
+			}
+			if !isAmbiguous(ctx, err) {
+				return resp, err
+			}
+			if lookups >= o.MaxAttempts {
+				// Creating the resource again could duplicate it.
+				return nil, createErr
+			}
+		}
+
+		if attempt >= o.MaxAttempts {
+			return nil, createErr
+		}
+	}
+}
+
+// attempt calls fn with a context bounded by o.AttemptTimeout.
+func (o IdempotencyOptions) attempt(ctx context.Context, fn func(context.Context) (*Response, error)) (*Response, error) {
+	if o.AttemptTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, o.AttemptTimeout)
+		defer cancel()
+	}
+	return fn(ctx)
+}
+
+// isAmbiguous reports whether a request that failed with err may have been
+// processed by the API: it timed out, failed in transport, was answered with
+// a 5xx response or with a body that could not be decoded. Failures caused by
+// the cancellation of ctx itself are not retried.
+func isAmbiguous(ctx context.Context, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+
+	var errResp *ErrorResponse
+	if errors.As(err, &errResp) {
+		return errResp.Response != nil && errResp.Response.StatusCode >= http.StatusInternalServerError
+	}
+
+	var argErr *ArgError
+	return !errors.As(err, &argErr)
+}
+
+func appendTag(tags []string, tag string) []string {
+	if contains(tags, tag) {
+		return tags
+	}
+	return append(append([]string{}, tags...), tag)
+}
+
+func contains(values []string, v string) bool {
+	for _, s := range values {
+		if s == v {
+			return true
+		}
+	}
+	return false
+}
+
+// sleep waits for d or until ctx is done, returning ctx.Err() in the latter
+// case.
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
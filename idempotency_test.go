@@ -0,0 +1,229 @@
+package godo
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+var testIdempotencyOptions = IdempotencyOptions{Key: "k1", Wait: time.Millisecond}
+
+func TestIdempotentCreateDroplet_findsCreatedDroplet(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var creates int32
+	mux.HandleFunc("/v2/droplets", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			atomic.AddInt32(&creates, 1)
+
+			var req DropletCreateRequest
+			json.NewDecoder(r.Body).Decode(&req)
+			if !contains(req.Tags, "web") || !contains(req.Tags, "idempotency-key:k1") {
+				t.Errorf("create request tags = %v", req.Tags)
+			}
+			w.WriteHeader(http.StatusGatewayTimeout)
+		case http.MethodGet:
+			if tag := r.URL.Query().Get("tag_name"); tag != "idempotency-key:k1" {
+				t.Errorf("listed tag %q", tag)
+			}
+			fmt.Fprint(w, `{"droplets": [{"id": 1, "name": "web-1", "tags": ["web", "idempotency-key:k1"]}]}`)
+		}
+	})
+
+	req := &DropletCreateRequest{Name: "web-1", Tags: []string{"web"}}
+	d, _, err := IdempotentCreateDroplet(ctx, client.Droplets, req, testIdempotencyOptions)
+	if err != nil {
+		t.Fatalf("IdempotentCreateDroplet() error = %v", err)
+	}
+	if d.ID != 1 {
+		t.Errorf("droplet = %+v, expected the existing droplet", d)
+	}
+	if creates != 1 {
+		t.Errorf("made %d create requests, expected 1", creates)
+	}
+	if len(req.Tags) != 1 {
+		t.Errorf("request tags modified to %v", req.Tags)
+	}
+}
+
+func TestIdempotentCreateDroplet_reissuesCreate(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var creates, lookups int32
+	mux.HandleFunc("/v2/droplets", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			if atomic.AddInt32(&creates, 1) == 1 {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			w.WriteHeader(http.StatusAccepted)
+			fmt.Fprint(w, `{"droplet": {"id": 2, "name": "web-1"}}`)
+		case http.MethodGet:
+			atomic.AddInt32(&lookups, 1)
+			fmt.Fprint(w, `{"droplets": []}`)
+		}
+	})
+
+	d, _, err := IdempotentCreateDroplet(ctx, client.Droplets, &DropletCreateRequest{Name: "web-1"}, testIdempotencyOptions)
+	if err != nil {
+		t.Fatalf("IdempotentCreateDroplet() error = %v", err)
+	}
+	if d.ID != 2 {
+		t.Errorf("droplet = %+v", d)
+	}
+	if creates != 2 || lookups != 1 {
+		t.Errorf("made %d creates and %d lookups, expected 2 and 1", creates, lookups)
+	}
+}
+
+func TestIdempotentCreateDroplet_unambiguousFailure(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var creates int32
+	mux.HandleFunc("/v2/droplets", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("unexpected %s request", r.Method)
+		}
+		atomic.AddInt32(&creates, 1)
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		fmt.Fprint(w, `{"id": "unprocessable_entity", "message": "invalid size"}`)
+	})
+
+	_, _, err := IdempotentCreateDroplet(ctx, client.Droplets, &DropletCreateRequest{Name: "web-1"}, testIdempotencyOptions)
+	if !errors.Is(err, ErrUnprocessableEntity) {
+		t.Errorf("error = %v, expected %v", err, ErrUnprocessableEntity)
+	}
+	if creates != 1 {
+		t.Errorf("made %d create requests, expected 1", creates)
+	}
+}
+
+func TestIdempotentCreateDroplet_exhaustsAttempts(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var creates int32
+	mux.HandleFunc("/v2/droplets", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			atomic.AddInt32(&creates, 1)
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		fmt.Fprint(w, `{"droplets": []}`)
+	})
+
+	_, _, err := IdempotentCreateDroplet(ctx, client.Droplets, &DropletCreateRequest{Name: "web-1"}, testIdempotencyOptions)
+	if !errors.Is(err, ErrServerError) {
+		t.Errorf("error = %v, expected %v", err, ErrServerError)
+	}
+	if creates != defaultIdempotencyMaxAttempts {
+		t.Errorf("made %d create requests, expected %d", creates, defaultIdempotencyMaxAttempts)
+	}
+}
+
+func TestIdempotentCreateVolume_attemptTimeout(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var creates int32
+	mux.HandleFunc("/v2/volumes", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			atomic.AddInt32(&creates, 1)
+			time.Sleep(100 * time.Millisecond)
+			w.WriteHeader(http.StatusCreated)
+		case http.MethodGet:
+			if q := r.URL.Query(); q.Get("name") != "data" || q.Get("region") != "nyc3" {
+				t.Errorf("lookup query = %v", q)
+			}
+			fmt.Fprint(w, `{"volumes": [
+				{"id": "other", "name": "data", "tags": []},
+				{"id": "vol", "name": "data", "tags": ["idempotency-key:k1"]}
+			]}`)
+		}
+	})
+
+	opts := testIdempotencyOptions
+	opts.AttemptTimeout = 10 * time.Millisecond
+	v, _, err := IdempotentCreateVolume(ctx, client.Storage, &VolumeCreateRequest{Name: "data", Region: "nyc3"}, opts)
+	if err != nil {
+		t.Fatalf("IdempotentCreateVolume() error = %v", err)
+	}
+	if v.ID != "vol" {
+		t.Errorf("volume = %+v, expected the tagged volume", v)
+	}
+	if n := atomic.LoadInt32(&creates); n != 1 {
+		t.Errorf("made %d create requests, expected 1", n)
+	}
+}
+
+func TestIdempotentCreateVolume_requiresName(t *testing.T) {
+	_, _, err := IdempotentCreateVolume(ctx, nil, &VolumeCreateRequest{Region: "nyc3"}, IdempotencyOptions{})
+	var argErr *ArgError
+	if !errors.As(err, &argErr) {
+		t.Errorf("error = %v, expected an *ArgError", err)
+	}
+}
+
+func TestIdempotentCreateDroplet_invalidKey(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v2/droplets", func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected %s request", r.Method)
+	})
+
+	for _, key := range []string{"k 1", "k:1", "k/1", "ключ"} {
+		_, _, err := IdempotentCreateDroplet(ctx, client.Droplets, &DropletCreateRequest{Name: "web-1"}, IdempotencyOptions{Key: key})
+		var argErr *ArgError
+		if !errors.As(err, &argErr) {
+			t.Errorf("key %q: error = %v, expected an *ArgError", key, err)
+		}
+	}
+}
+
+func TestNewIdempotencyKey(t *testing.T) {
+	if _, err := (IdempotencyOptions{Key: NewIdempotencyKey()}).withDefaults(); err != nil {
+		t.Errorf("withDefaults() error = %v", err)
+	}
+}
+
+func TestIdempotentCreateLoadBalancer(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var creates int32
+	mux.HandleFunc("/v2/load_balancers", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			atomic.AddInt32(&creates, 1)
+			w.WriteHeader(http.StatusBadGateway)
+		case http.MethodGet:
+			if r.URL.Query().Get("page") != "2" {
+				fmt.Fprintf(w, `{"load_balancers": [{"id": "a", "tags": []}], "links": {"pages": {"next": "%s/v2/load_balancers?page=2"}}}`, server.URL)
+				return
+			}
+			fmt.Fprint(w, `{"load_balancers": [{"id": "b", "tags": ["idempotency-key:k1"]}]}`)
+		}
+	})
+
+	lb, _, err := IdempotentCreateLoadBalancer(ctx, client.LoadBalancers, &LoadBalancerRequest{Name: "lb"}, testIdempotencyOptions)
+	if err != nil {
+		t.Fatalf("IdempotentCreateLoadBalancer() error = %v", err)
+	}
+	if lb.ID != "b" {
+		t.Errorf("load balancer = %+v, expected the tagged load balancer", lb)
+	}
+	if creates != 1 {
+		t.Errorf("made %d create requests, expected 1", creates)
+	}
+}
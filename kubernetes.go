@@ -147,6 +147,19 @@ type KubernetesCluster struct {
 	Status    *KubernetesClusterStatus `json:"status,omitempty"`
 	CreatedAt time.Time                `json:"created_at,omitempty"`
 	UpdatedAt time.Time                `json:"updated_at,omitempty"`
+
+	// Extra holds the fields returned by the API that KubernetesCluster does
+	// not have yet.
+	Extra ExtraFields `json:"-"`
+}
+
+// UnmarshalJSON decodes a KubernetesCluster, keeping the fields it does not
+// have in Extra.
+func (kc *KubernetesCluster) UnmarshalJSON(data []byte) error {
+	type kubernetesCluster KubernetesCluster
+	extra, err := unmarshalWithExtra(data, (*kubernetesCluster)(kc))
+	kc.Extra = extra
+	return err
 }
 
 // KubernetesClusterUser represents a Kubernetes cluster user.
@@ -3,6 +3,7 @@ package godo
 import (
 	"context"
 	"net/url"
+	"path"
 	"strconv"
 )
 
@@ -81,3 +82,67 @@ func pageForURL(urlText string) (int, error) {
 func (la *LinkAction) Get(ctx context.Context, client *Client) (*Action, *Response, error) {
 	return client.Actions.Get(ctx, la.ID)
 }
+
+// ActionIDs returns the IDs of the actions linked from the response, such as
+// those started by Droplets.CreateMultiple.
+func (r *Response) ActionIDs() []int {
+	if r == nil || r.Links == nil {
+		return nil
+	}
+
+	var ids []int
+	for _, la := range r.Links.Actions {
+		id := la.ID
+		if id == 0 {
+			// Fall back to the ID at the end of the action URL.
+			if u, err := url.Parse(la.HREF); err == nil {
+				id, _ = strconv.Atoi(path.Base(u.Path))
+			}
+		}
+		if id > 0 && !containsInt(ids, id) {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// LinkedActions fetches the actions linked from the response.
+func (r *Response) LinkedActions(ctx context.Context, s ActionsService) ([]Action, error) {
+	ids := r.ActionIDs()
+	actions := make([]Action, 0, len(ids))
+	for _, id := range ids {
+		a, _, err := s.Get(ctx, id)
+		if err != nil {
+			return actions, err
+		}
+		actions = append(actions, *a)
+	}
+	return actions, nil
+}
+
+// WaitForLinkedActions waits for the actions linked from the response to end,
+// as WaitForActions does, so that creating several resources and waiting for
+// them takes a single call:
+//
+//	_, resp, err := client.Droplets.CreateMultiple(ctx, req)
+//	if err != nil {
+//		return err
+//	}
+//	batch, err := resp.WaitForLinkedActions(ctx, client.Actions, godo.BatchWaitOptions{})
+func (r *Response) WaitForLinkedActions(ctx context.Context, s ActionsService, opts BatchWaitOptions) (*ActionBatch, error) {
+	ids := r.ActionIDs()
+	actions := make([]Action, len(ids))
+	for i, id := range ids {
+		actions[i] = Action{ID: id}
+	}
+	return WaitForActions(ctx, s, actions, opts)
+}
+
+func containsInt(values []int, v int) bool {
+	for _, value := range values {
+		if value == v {
+			return true
+		}
+	}
+	return false
+}
@@ -2,6 +2,8 @@ package godo
 
 import (
 	"encoding/json"
+	"fmt"
+	"net/http"
 	"testing"
 )
 
@@ -197,3 +199,61 @@ func TestLinks_ParseEmptyString(t *testing.T) {
 		}
 	}
 }
+
+func TestResponse_ActionIDs(t *testing.T) {
+	resp := &Response{Links: &Links{Actions: []LinkAction{
+		{ID: 1, Rel: "create", HREF: "https://api.digitalocean.com/v2/actions/1"},
+		{Rel: "create", HREF: "https://api.digitalocean.com/v2/actions/2"},
+		{ID: 1, Rel: "create"},
+		{Rel: "create", HREF: "https://api.digitalocean.com/v2/actions/invalid"},
+	}}}
+	if got := fmt.Sprint(resp.ActionIDs()); got != "[1 2]" {
+		t.Errorf("ActionIDs() = %s, expected [1 2]", got)
+	}
+
+	var none *Response
+	if ids := none.ActionIDs(); ids != nil {
+		t.Errorf("ActionIDs() = %v, expected none", ids)
+	}
+	if ids := (&Response{}).ActionIDs(); ids != nil {
+		t.Errorf("ActionIDs() = %v, expected none", ids)
+	}
+}
+
+func TestResponse_WaitForLinkedActions(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v2/droplets", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{
+			"droplets": [{"id": 1}, {"id": 2}],
+			"links": {"actions": [
+				{"id": 11, "rel": "create", "href": "%[1]s/v2/actions/11"},
+				{"id": 12, "rel": "create", "href": "%[1]s/v2/actions/12"}
+			]}
+		}`, server.URL)
+	})
+	handleActionStatuses(11, ActionInProgress, ActionCompleted)
+	handleActionStatuses(12, ActionInProgress, ActionInProgress, ActionCompleted)
+
+	_, resp, err := client.Droplets.CreateMultiple(ctx, &DropletMultiCreateRequest{Names: []string{"web-1", "web-2"}})
+	if err != nil {
+		t.Fatalf("Droplets.CreateMultiple() error = %v", err)
+	}
+
+	actions, err := resp.LinkedActions(ctx, client.Actions)
+	if err != nil {
+		t.Fatalf("LinkedActions() error = %v", err)
+	}
+	if ids := actionIDs(actions); ids != "[11 12]" || actions[0].Status != ActionInProgress {
+		t.Errorf("LinkedActions() = %+v", actions)
+	}
+
+	batch, err := resp.WaitForLinkedActions(ctx, client.Actions, BatchWaitOptions{ActionWaitOptions: ActionWaitOptions{WaitOptions: fastWait}})
+	if err != nil {
+		t.Fatalf("WaitForLinkedActions() error = %v", err)
+	}
+	if ids := actionIDs(batch.Completed); ids != "[11 12]" {
+		t.Errorf("completed = %s", ids)
+	}
+}
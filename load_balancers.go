@@ -11,6 +11,13 @@ const forwardingRulesPath = "forwarding_rules"
 
 const dropletsPath = "droplets"
 
+// Load balancer statuses
+const (
+	LoadBalancerStatusNew     = "new"
+	LoadBalancerStatusActive  = "active"
+	LoadBalancerStatusErrored = "errored"
+)
+
 // LoadBalancersService is an interface for managing load balancers with the DigitalOcean API.
 // See: https://developers.digitalocean.com/documentation/v2#load-balancers
 type LoadBalancersService interface {
@@ -45,6 +52,19 @@ type LoadBalancer struct {
 	EnableProxyProtocol    bool             `json:"enable_proxy_protocol,omitempty"`
 	EnableBackendKeepalive bool             `json:"enable_backend_keepalive,omitempty"`
 	VPCUUID                string           `json:"vpc_uuid,omitempty"`
+
+	// Extra holds the fields returned by the API that LoadBalancer does not
+	// have yet.
+	Extra ExtraFields `json:"-"`
+}
+
+// UnmarshalJSON decodes a LoadBalancer, keeping the fields it does not have
+// in Extra.
+func (l *LoadBalancer) UnmarshalJSON(data []byte) error {
+	type loadBalancer LoadBalancer
+	extra, err := unmarshalWithExtra(data, (*loadBalancer)(l))
+	l.Extra = extra
+	return err
 }
 
 // String creates a human-readable description of a LoadBalancer.
@@ -0,0 +1,181 @@
+package godo
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"time"
+)
+
+const headerRequestID = "X-Request-Id"
+
+// Logger is the interface used to log API requests. Its methods follow the
+// conventions of log/slog: args are alternating keys and values. A
+// *slog.Logger satisfies it.
+type Logger interface {
+	Debug(msg string, args ...interface{})
+	Info(msg string, args ...interface{})
+	Warn(msg string, args ...interface{})
+	Error(msg string, args ...interface{})
+}
+
+// LoggingOptions configures the request logging installed by SetLogger.
+type LoggingOptions struct {
+	// LogBodies enables logging of request headers and bodies, and response
+	// bodies, at debug level. Secrets are redacted.
+	LogBodies bool
+
+	// RedactKeys lists JSON keys whose values are redacted in logged bodies,
+	// in addition to the keys of known secrets such as "password" and
+	// "private_key".
+	RedactKeys []string
+}
+
+// SetLogger is a client option for logging the method, path, status, latency,
+// request ID and rate limit of every request with l. Failed requests are
+// logged at warn or error level, others at info level.
+func SetLogger(l Logger, opts LoggingOptions) ClientOpt {
+	return func(c *Client) error {
+		if l == nil {
+			return NewArgError("l", "cannot be nil")
+		}
+
+		c.Use(LoggingMiddleware(l, opts))
+		return nil
+	}
+}
+
+// LoggingMiddleware returns a Middleware logging requests as described by
+// SetLogger.
+func LoggingMiddleware(l Logger, opts LoggingOptions) Middleware {
+	redactor := NewRedactor(opts.RedactKeys...)
+
+	return func(next RequestHandler) RequestHandler {
+		return func(ctx context.Context, req *http.Request) (*http.Response, error) {
+			if opts.LogBodies {
+				l.Debug("godo request",
+					"method", req.Method,
+					"path", req.URL.RequestURI(),
+					"headers", redactHeaders(req.Header),
+					"body", requestBody(req, redactor),
+				)
+			}
+
+			start := time.Now()
+			resp, err := next(ctx, req)
+			latency := time.Since(start)
+
+			if err != nil {
+				l.Error("godo request failed",
+					"method", req.Method,
+					"path", req.URL.RequestURI(),
+					"latency", latency,
+					"error", err,
+				)
+				return resp, err
+			}
+
+			failed := resp.StatusCode < 200 || resp.StatusCode > 299
+			requestID := resp.Header.Get(headerRequestID)
+
+			var errResp *ErrorResponse
+			if failed {
+				errResp, _ = peekErrorResponse(resp).(*ErrorResponse)
+				if errResp != nil && requestID == "" {
+					requestID = errResp.RequestID
+				}
+			}
+
+			rate := newResponse(resp).Rate
+			args := []interface{}{
+				"method", req.Method,
+				"path", req.URL.RequestURI(),
+				"status", resp.StatusCode,
+				"latency", latency,
+				"request_id", requestID,
+				"rate_limit", rate.Limit,
+				"rate_remaining", rate.Remaining,
+				"rate_reset", rate.Reset.Time,
+			}
+
+			switch {
+			case !failed:
+				l.Info("godo request", args...)
+			case resp.StatusCode >= 500:
+				l.Error("godo request", append(args, "error", errorMessage(errResp))...)
+			default:
+				l.Warn("godo request", append(args, "error", errorMessage(errResp))...)
+			}
+
+			if opts.LogBodies {
+				l.Debug("godo response",
+					"method", req.Method,
+					"path", req.URL.RequestURI(),
+					"status", resp.StatusCode,
+					"body", responseBody(req, resp, redactor),
+				)
+			}
+
+			return resp, err
+		}
+	}
+}
+
+func errorMessage(errResp *ErrorResponse) string {
+	if errResp == nil {
+		return ""
+	}
+	return errResp.Message
+}
+
+// redactHeaders returns a copy of h with credentials redacted.
+func redactHeaders(h http.Header) http.Header {
+	out := h.Clone()
+	for _, k := range []string{"Authorization", "Proxy-Authorization", "Cookie"} {
+		if out.Get(k) != "" {
+			out.Set(k, redacted)
+		}
+	}
+	return out
+}
+
+func requestBody(req *http.Request, redactor *Redactor) string {
+	if req.GetBody == nil {
+		return ""
+	}
+
+	body, err := req.GetBody()
+	if err != nil {
+		return ""
+	}
+	defer body.Close()
+
+	data, err := ioutil.ReadAll(body)
+	if err != nil {
+		return ""
+	}
+	return redactBody(req.URL.Path, data, redactor)
+}
+
+// responseBody returns the redacted body of resp, leaving the body readable.
+func responseBody(req *http.Request, resp *http.Response, redactor *Redactor) string {
+	data, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = ioutil.NopCloser(bytes.NewReader(data))
+	if err != nil {
+		return ""
+	}
+	return redactBody(req.URL.Path, data, redactor)
+}
+
+// redactBody returns data as a string with secrets redacted. Bodies that are
+// not JSON are only described by their size.
+func redactBody(path string, data []byte, redactor *Redactor) string {
+	out, ok := redactor.Body(path, data)
+	if !ok {
+		return fmt.Sprintf("[%d bytes]", len(data))
+	}
+	return string(out)
+}
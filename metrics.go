@@ -0,0 +1,104 @@
+package godo
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// MetricsSink receives metrics about the API requests made by a client, for
+// instance to feed latency histograms, request counters by status class,
+// retry counters and rate limit gauges of a metrics library.
+//
+// ObserveRequest is called once per call to Client.Do, after all retries, and
+// may be called concurrently.
+type MetricsSink interface {
+	ObserveRequest(ctx context.Context, m RequestMetrics)
+}
+
+// MetricsSinkFunc is an adapter allowing the use of a function as a
+// MetricsSink.
+type MetricsSinkFunc func(ctx context.Context, m RequestMetrics)
+
+// ObserveRequest calls f(ctx, m).
+func (f MetricsSinkFunc) ObserveRequest(ctx context.Context, m RequestMetrics) {
+	f(ctx, m)
+}
+
+// RequestMetrics describes a completed API request.
+type RequestMetrics struct {
+	// Operation is the service method that made the request, such as
+	// "Droplets.Create". See RequestOperation.
+	Operation string
+
+	// Method is the HTTP method of the request.
+	Method string
+
+	// StatusCode is the HTTP status code of the last attempt, or zero if no
+	// response was received.
+	StatusCode int
+
+	// Latency is the time spent sending the request, including retries and
+	// waits for the rate limiter.
+	Latency time.Duration
+
+	// Attempts is the number of times the request was sent.
+	Attempts int
+
+	// Rate is the rate limit reported with the last response.
+	Rate Rate
+
+	// Err is the transport error of the last attempt, if any.
+	Err error
+}
+
+// StatusClass returns the class of the status code, such as "2xx" or "5xx",
+// or "error" if no response was received.
+func (m RequestMetrics) StatusClass() string {
+	if m.StatusCode < 100 || m.StatusCode > 599 {
+		return "error"
+	}
+	return strconv.Itoa(m.StatusCode/100) + "xx"
+}
+
+// Retries returns the number of times the request was retried.
+func (m RequestMetrics) Retries() int {
+	if m.Attempts < 1 {
+		return 0
+	}
+	return m.Attempts - 1
+}
+
+// SetMetricsSink is a client option for reporting metrics about every request
+// to s.
+func SetMetricsSink(s MetricsSink) ClientOpt {
+	return func(c *Client) error {
+		if s == nil {
+			return NewArgError("s", "cannot be nil")
+		}
+
+		c.metrics = s
+		return nil
+	}
+}
+
+// observeRequest reports a request sent by Client.Do to the metrics sink.
+func (c *Client) observeRequest(ctx context.Context, req *http.Request, resp *http.Response, attempts int, latency time.Duration, err error) {
+	if c.metrics == nil {
+		return
+	}
+
+	m := RequestMetrics{
+		Operation: RequestOperation(req).Name,
+		Method:    req.Method,
+		Latency:   latency,
+		Attempts:  attempts,
+		Err:       err,
+	}
+	if err == nil {
+		m.StatusCode = resp.StatusCode
+		m.Rate = newResponse(resp).Rate
+	}
+	c.metrics.ObserveRequest(ctx, m)
+}
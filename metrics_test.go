@@ -0,0 +1,118 @@
+package godo
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+)
+
+type testMetricsSink struct {
+	mu      sync.Mutex
+	metrics []RequestMetrics
+}
+
+func (s *testMetricsSink) ObserveRequest(ctx context.Context, m RequestMetrics) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.metrics = append(s.metrics, m)
+}
+
+func TestSetMetricsSink(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v2/droplets", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(headerRateLimit, "5000")
+		w.Header().Set(headerRateRemaining, "4321")
+		fmt.Fprint(w, `{"droplet":{"id":1}}`)
+	})
+	mux.HandleFunc("/v2/kubernetes/clusters/k8s/kubeconfig", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"id":"not_found","message":"missing"}`)
+	})
+
+	sink := &testMetricsSink{}
+	if err := SetMetricsSink(sink)(client); err != nil {
+		t.Fatalf("SetMetricsSink(): %v", err)
+	}
+
+	if _, _, err := client.Droplets.Create(ctx, &DropletCreateRequest{Name: "d"}); err != nil {
+		t.Fatalf("Droplets.Create(): %v", err)
+	}
+	if _, _, err := client.Kubernetes.GetKubeConfig(ctx, "k8s"); err == nil {
+		t.Fatal("expected error")
+	}
+
+	if len(sink.metrics) != 2 {
+		t.Fatalf("observed %d requests, expected 2", len(sink.metrics))
+	}
+
+	m := sink.metrics[0]
+	if m.Operation != "Droplets.Create" || m.Method != http.MethodPost || m.StatusClass() != "2xx" {
+		t.Errorf("metrics = %+v, expected a successful Droplets.Create", m)
+	}
+	if m.Attempts != 1 || m.Retries() != 0 {
+		t.Errorf("attempts = %d, retries = %d, expected 1 and 0", m.Attempts, m.Retries())
+	}
+	if m.Rate.Remaining != 4321 {
+		t.Errorf("rate remaining = %d, expected 4321", m.Rate.Remaining)
+	}
+	if m.Latency <= 0 {
+		t.Errorf("latency = %v, expected > 0", m.Latency)
+	}
+
+	m = sink.metrics[1]
+	if m.Operation != "Kubernetes.GetKubeConfig" || m.StatusCode != http.StatusNotFound || m.StatusClass() != "4xx" {
+		t.Errorf("metrics = %+v, expected a failed Kubernetes.GetKubeConfig", m)
+	}
+}
+
+func TestSetMetricsSink_retries(t *testing.T) {
+	setupRetry(t, RetryPolicy{MaxAttempts: 3, WaitMin: time.Millisecond, WaitMax: time.Millisecond})
+	defer teardown()
+
+	mux.HandleFunc("/v2/regions", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+
+	var observed []RequestMetrics
+	client.metrics = MetricsSinkFunc(func(ctx context.Context, m RequestMetrics) {
+		observed = append(observed, m)
+	})
+
+	if _, _, err := client.Regions.List(ctx, nil); err == nil {
+		t.Fatal("expected error")
+	}
+
+	if len(observed) != 1 {
+		t.Fatalf("observed %d requests, expected 1", len(observed))
+	}
+	if m := observed[0]; m.Operation != "Regions.List" || m.Retries() != 2 || m.StatusClass() != "5xx" {
+		t.Errorf("metrics = %+v, expected Regions.List with 2 retries", m)
+	}
+}
+
+func TestSetMetricsSink_transportError(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v2/account", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/v2/account", http.StatusFound)
+	})
+
+	var observed RequestMetrics
+	client.metrics = MetricsSinkFunc(func(ctx context.Context, m RequestMetrics) {
+		observed = m
+	})
+
+	_, _, err := client.Account.Get(ctx)
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	if observed.Operation != "Account.Get" || observed.StatusClass() != "error" || observed.Err != err {
+		t.Errorf("metrics = %+v, expected transport error %v", observed, err)
+	}
+}
@@ -0,0 +1,127 @@
+package godo
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http"
+)
+
+// RequestHandler sends an API request and returns the raw HTTP response.
+type RequestHandler func(ctx context.Context, req *http.Request) (*http.Response, error)
+
+// Middleware wraps a RequestHandler to observe or modify requests and
+// responses. A middleware is called once per attempt, so a request retried by
+// the client's retry policy passes through it several times. Middleware may
+// also retry a request itself by calling next more than once.
+type Middleware func(next RequestHandler) RequestHandler
+
+// WithMiddleware is a client option for adding middleware to the client. See
+// Client.Use.
+func WithMiddleware(mw ...Middleware) ClientOpt {
+	return func(c *Client) error {
+		c.Use(mw...)
+		return nil
+	}
+}
+
+// Use adds middleware to the client. Middleware added first is outermost: it
+// sees requests before and responses after middleware added later. Use must
+// not be called while requests are in flight.
+func (c *Client) Use(mw ...Middleware) {
+	c.middleware = append(c.middleware, mw...)
+}
+
+// BeforeRequest returns a Middleware calling fn before each request is sent.
+// fn may modify the request, for instance to add headers. If fn returns an
+// error, the request is not sent and the error is returned by Client.Do.
+func BeforeRequest(fn func(*http.Request) error) Middleware {
+	return func(next RequestHandler) RequestHandler {
+		return func(ctx context.Context, req *http.Request) (*http.Response, error) {
+			if err := fn(req); err != nil {
+				return nil, err
+			}
+			return next(ctx, req)
+		}
+	}
+}
+
+// AfterResponse returns a Middleware calling fn with each response received
+// from the API, whatever its status code.
+func AfterResponse(fn func(*http.Request, *http.Response)) Middleware {
+	return func(next RequestHandler) RequestHandler {
+		return func(ctx context.Context, req *http.Request) (*http.Response, error) {
+			resp, err := next(ctx, req)
+			if err == nil {
+				fn(req, resp)
+			}
+			return resp, err
+		}
+	}
+}
+
+// OnError returns a Middleware calling fn when a request fails, either with a
+// transport error or with an API error response. In the latter case resp is
+// the response and err is the *ErrorResponse that Client.Do will return.
+func OnError(fn func(req *http.Request, resp *http.Response, err error)) Middleware {
+	return func(next RequestHandler) RequestHandler {
+		return func(ctx context.Context, req *http.Request) (*http.Response, error) {
+			resp, err := next(ctx, req)
+			if err != nil {
+				fn(req, nil, err)
+				return resp, err
+			}
+
+			if c := resp.StatusCode; c < 200 || c > 299 {
+				fn(req, resp, peekErrorResponse(resp))
+			}
+			return resp, err
+		}
+	}
+}
+
+// peekErrorResponse parses the API error in resp while leaving its body
+// readable.
+func peekErrorResponse(resp *http.Response) error {
+	data, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return err
+	}
+	resp.Body = ioutil.NopCloser(bytes.NewReader(data))
+
+	copied := *resp
+	copied.Body = ioutil.NopCloser(bytes.NewReader(data))
+	errResp := CheckResponse(&copied).(*ErrorResponse)
+	errResp.Response = resp
+	return errResp
+}
+
+// handler returns the RequestHandler sending a single attempt of a request
+// through the client's middleware and response cache.
+func (c *Client) handler() RequestHandler {
+	h := c.roundTrip
+	if c.cache != nil {
+		h = c.cache.middleware(h)
+	}
+	for i := len(c.middleware) - 1; i >= 0; i-- {
+		h = c.middleware[i](h)
+	}
+	return h
+}
+
+// roundTrip paces a request with the client's rate limiter, sends it and
+// records the rate limit returned with the response.
+func (c *Client) roundTrip(ctx context.Context, req *http.Request) (*http.Response, error) {
+	if c.rateLimiter != nil {
+		if err := c.rateLimiter.Wait(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	resp, err := DoRequestWithClient(ctx, c.client, req)
+	if resp != nil {
+		c.updateRate(newResponse(resp).Rate)
+	}
+	return resp, err
+}
@@ -0,0 +1,173 @@
+package godo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestMiddleware_order(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{}`)
+	})
+
+	var calls []string
+	trace := func(name string) Middleware {
+		return func(next RequestHandler) RequestHandler {
+			return func(ctx context.Context, req *http.Request) (*http.Response, error) {
+				calls = append(calls, "before "+name)
+				resp, err := next(ctx, req)
+				calls = append(calls, "after "+name)
+				return resp, err
+			}
+		}
+	}
+	client.Use(trace("first"), trace("second"))
+
+	req, _ := client.NewRequest(ctx, http.MethodGet, "/", nil)
+	if _, err := client.Do(ctx, req, nil); err != nil {
+		t.Fatalf("Do(): %v", err)
+	}
+
+	expected := []string{"before first", "before second", "after second", "after first"}
+	if !reflect.DeepEqual(calls, expected) {
+		t.Errorf("middleware calls = %v, expected %v", calls, expected)
+	}
+}
+
+func TestMiddleware_beforeRequest(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("X-Audit"); got != "deploy-42" {
+			t.Errorf("X-Audit header = %q, expected %q", got, "deploy-42")
+		}
+	})
+
+	c, err := New(nil, SetBaseURL(server.URL), WithMiddleware(BeforeRequest(func(req *http.Request) error {
+		req.Header.Set("X-Audit", "deploy-42")
+		return nil
+	})))
+	if err != nil {
+		t.Fatalf("New(): %v", err)
+	}
+
+	req, _ := c.NewRequest(ctx, http.MethodGet, "/", nil)
+	if _, err := c.Do(ctx, req, nil); err != nil {
+		t.Fatalf("Do(): %v", err)
+	}
+}
+
+func TestMiddleware_beforeRequestError(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		t.Error("request should not have been sent")
+	})
+
+	denied := errors.New("denied")
+	client.Use(BeforeRequest(func(req *http.Request) error {
+		return denied
+	}))
+
+	req, _ := client.NewRequest(ctx, http.MethodDelete, "/", nil)
+	if _, err := client.Do(ctx, req, nil); err != denied {
+		t.Errorf("Do() error = %v, expected %v", err, denied)
+	}
+}
+
+func TestMiddleware_afterResponseAndOnError(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"id":"not_found","message":"missing"}`)
+	})
+
+	var statuses []int
+	var onErr error
+	client.Use(
+		AfterResponse(func(req *http.Request, resp *http.Response) {
+			statuses = append(statuses, resp.StatusCode)
+		}),
+		OnError(func(req *http.Request, resp *http.Response, err error) {
+			onErr = err
+		}),
+	)
+
+	req, _ := client.NewRequest(ctx, http.MethodGet, "/", nil)
+	_, err := client.Do(ctx, req, nil)
+
+	if !reflect.DeepEqual(statuses, []int{http.StatusNotFound}) {
+		t.Errorf("AfterResponse statuses = %v, expected [404]", statuses)
+	}
+	if !errors.Is(onErr, ErrNotFound) {
+		t.Errorf("OnError error = %v, expected not found", onErr)
+	}
+
+	var errResp *ErrorResponse
+	if !errors.As(err, &errResp) || errResp.Message != "missing" {
+		t.Errorf("Do() error = %v, expected message to be preserved", err)
+	}
+}
+
+func TestMiddleware_onTransportError(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/", http.StatusFound)
+	})
+
+	var onErr error
+	client.Use(OnError(func(req *http.Request, resp *http.Response, err error) {
+		if resp != nil {
+			t.Errorf("OnError response = %v, expected nil", resp)
+		}
+		onErr = err
+	}))
+
+	req, _ := client.NewRequest(ctx, http.MethodGet, "/", nil)
+	_, err := client.Do(ctx, req, nil)
+
+	if err == nil || onErr != err {
+		t.Errorf("OnError error = %v, expected %v", onErr, err)
+	}
+}
+
+func TestMiddleware_seesRetries(t *testing.T) {
+	setupRetry(t, RetryPolicy{MaxAttempts: 3, WaitMin: time.Millisecond, WaitMax: time.Millisecond})
+	defer teardown()
+
+	var calls int
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+		}
+	})
+
+	var attempts int
+	client.Use(BeforeRequest(func(req *http.Request) error {
+		attempts++
+		return nil
+	}))
+
+	req, _ := client.NewRequest(ctx, http.MethodGet, "/", nil)
+	if _, err := client.Do(ctx, req, nil); err != nil {
+		t.Fatalf("Do(): %v", err)
+	}
+	if attempts != 2 {
+		t.Errorf("middleware attempts = %d, expected 2", attempts)
+	}
+}
@@ -0,0 +1,429 @@
+package godo
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"strings"
+)
+
+// route maps requests matching an HTTP method and a path template to the
+// service method issuing them. Templates are built from the path constants of
+// each service, where "%d" matches a numeric segment and "%s" any segment.
+type route struct {
+	method   string
+	template string
+
+	// query, if set, is a "key" or "key=value" condition on the query string.
+	query string
+
+	// body, if set, is a key the JSON request body must have, for methods
+	// sharing an endpoint, such as CDNs.UpdateTTL and CDNs.UpdateCustomDomain,
+	// or Droplets.CreateMultiple and Droplets.Create.
+	body string
+
+	// operation is the service and method name, such as "Droplets.Create".
+	// For action endpoints it is the service name only, and the method is
+	// derived from the type of the action in the request body.
+	operation string
+
+	segments []string
+}
+
+var routes = compileRoutes([]route{
+	{method: http.MethodGet, template: accountBasePath, operation: "Account.Get"},
+
+	{method: http.MethodGet, template: actionsBasePath, operation: "Actions.List"},
+	{method: http.MethodGet, template: actionsBasePath + "/%d", operation: "Actions.Get"},
+
+	{method: http.MethodGet, template: balanceBasePath, operation: "Balance.Get"},
+
+	{method: http.MethodGet, template: billingHistoryBasePath, operation: "BillingHistory.List"},
+
+	{method: http.MethodGet, template: cdnBasePath, operation: "CDNs.List"},
+	{method: http.MethodPost, template: cdnBasePath, operation: "CDNs.Create"},
+	{method: http.MethodGet, template: cdnBasePath + "/%s", operation: "CDNs.Get"},
+	{method: http.MethodPut, template: cdnBasePath + "/%s", body: "ttl", operation: "CDNs.UpdateTTL"},
+	{method: http.MethodPut, template: cdnBasePath + "/%s", body: "custom_domain", operation: "CDNs.UpdateCustomDomain"},
+	{method: http.MethodPut, template: cdnBasePath + "/%s", body: "certificate_id", operation: "CDNs.UpdateCustomDomain"},
+	{method: http.MethodDelete, template: cdnBasePath + "/%s", operation: "CDNs.Delete"},
+	{method: http.MethodDelete, template: cdnBasePath + "/%s/cache", operation: "CDNs.FlushCache"},
+
+	{method: http.MethodGet, template: certificatesBasePath, operation: "Certificates.List"},
+	{method: http.MethodPost, template: certificatesBasePath, operation: "Certificates.Create"},
+	{method: http.MethodGet, template: certificatesBasePath + "/%s", operation: "Certificates.Get"},
+	{method: http.MethodDelete, template: certificatesBasePath + "/%s", operation: "Certificates.Delete"},
+
+	{method: http.MethodGet, template: databaseBasePath, operation: "Databases.List"},
+	{method: http.MethodPost, template: databaseBasePath, operation: "Databases.Create"},
+	{method: http.MethodGet, template: databaseSinglePath, operation: "Databases.Get"},
+	{method: http.MethodDelete, template: databaseSinglePath, operation: "Databases.Delete"},
+	{method: http.MethodPut, template: databaseResizePath, operation: "Databases.Resize"},
+	{method: http.MethodPut, template: databaseMigratePath, operation: "Databases.Migrate"},
+	{method: http.MethodPut, template: databaseMaintenancePath, operation: "Databases.UpdateMaintenance"},
+	{method: http.MethodGet, template: databaseBackupsPath, operation: "Databases.ListBackups"},
+	{method: http.MethodGet, template: databaseUsersPath, operation: "Databases.ListUsers"},
+	{method: http.MethodPost, template: databaseUsersPath, operation: "Databases.CreateUser"},
+	{method: http.MethodGet, template: databaseUserPath, operation: "Databases.GetUser"},
+	{method: http.MethodDelete, template: databaseUserPath, operation: "Databases.DeleteUser"},
+	{method: http.MethodPost, template: databaseResetUserAuthPath, operation: "Databases.ResetUserAuth"},
+	{method: http.MethodGet, template: databaseDBsPath, operation: "Databases.ListDBs"},
+	{method: http.MethodPost, template: databaseDBsPath, operation: "Databases.CreateDB"},
+	{method: http.MethodGet, template: databaseDBPath, operation: "Databases.GetDB"},
+	{method: http.MethodDelete, template: databaseDBPath, operation: "Databases.DeleteDB"},
+	{method: http.MethodGet, template: databasePoolsPath, operation: "Databases.ListPools"},
+	{method: http.MethodPost, template: databasePoolsPath, operation: "Databases.CreatePool"},
+	{method: http.MethodGet, template: databasePoolPath, operation: "Databases.GetPool"},
+	{method: http.MethodDelete, template: databasePoolPath, operation: "Databases.DeletePool"},
+	{method: http.MethodGet, template: databaseReplicasPath, operation: "Databases.ListReplicas"},
+	{method: http.MethodPost, template: databaseReplicasPath, operation: "Databases.CreateReplica"},
+	{method: http.MethodGet, template: databaseReplicaPath, operation: "Databases.GetReplica"},
+	{method: http.MethodDelete, template: databaseReplicaPath, operation: "Databases.DeleteReplica"},
+	{method: http.MethodGet, template: databaseEvictionPolicyPath, operation: "Databases.GetEvictionPolicy"},
+	{method: http.MethodPut, template: databaseEvictionPolicyPath, operation: "Databases.SetEvictionPolicy"},
+	{method: http.MethodGet, template: databaseSQLModePath, operation: "Databases.GetSQLMode"},
+	{method: http.MethodPut, template: databaseSQLModePath, operation: "Databases.SetSQLMode"},
+	{method: http.MethodGet, template: databaseFirewallRulesPath, operation: "Databases.GetFirewallRules"},
+	{method: http.MethodPut, template: databaseFirewallRulesPath, operation: "Databases.UpdateFirewallRules"},
+
+	{method: http.MethodGet, template: domainsBasePath, operation: "Domains.List"},
+	{method: http.MethodPost, template: domainsBasePath, operation: "Domains.Create"},
+	{method: http.MethodGet, template: domainsBasePath + "/%s", operation: "Domains.Get"},
+	{method: http.MethodDelete, template: domainsBasePath + "/%s", operation: "Domains.Delete"},
+	{method: http.MethodGet, template: domainsBasePath + "/%s/records", operation: "Domains.Records"},
+	{method: http.MethodPost, template: domainsBasePath + "/%s/records", operation: "Domains.CreateRecord"},
+	{method: http.MethodGet, template: domainsBasePath + "/%s/records/%d", operation: "Domains.Record"},
+	{method: http.MethodPut, template: domainsBasePath + "/%s/records/%d", operation: "Domains.EditRecord"},
+	{method: http.MethodDelete, template: domainsBasePath + "/%s/records/%d", operation: "Domains.DeleteRecord"},
+
+	{method: http.MethodPost, template: dropletActionsByTagPath, query: "tag_name", operation: "DropletActions"},
+	{method: http.MethodPost, template: dropletActionsPath, operation: "DropletActions"},
+	{method: http.MethodGet, template: dropletActionsPath + "/%d", operation: "DropletActions.Get"},
+
+	{method: http.MethodGet, template: dropletBasePath, query: "tag_name", operation: "Droplets.ListByTag"},
+	{method: http.MethodGet, template: dropletBasePath, operation: "Droplets.List"},
+	{method: http.MethodPost, template: dropletBasePath, body: "names", operation: "Droplets.CreateMultiple"},
+	{method: http.MethodPost, template: dropletBasePath, operation: "Droplets.Create"},
+	{method: http.MethodDelete, template: dropletBasePath, query: "tag_name", operation: "Droplets.DeleteByTag"},
+	{method: http.MethodGet, template: dropletBasePath + "/%d", operation: "Droplets.Get"},
+	{method: http.MethodDelete, template: dropletBasePath + "/%d", operation: "Droplets.Delete"},
+	{method: http.MethodGet, template: dropletBasePath + "/%d/kernels", operation: "Droplets.Kernels"},
+	{method: http.MethodGet, template: dropletBasePath + "/%d/snapshots", operation: "Droplets.Snapshots"},
+	{method: http.MethodGet, template: dropletBasePath + "/%d/backups", operation: "Droplets.Backups"},
+	{method: http.MethodGet, template: dropletActionsPath, operation: "Droplets.Actions"},
+	{method: http.MethodGet, template: dropletBasePath + "/%d/neighbors", operation: "Droplets.Neighbors"},
+	{method: http.MethodGet, template: dropletBasePath + "/%d/firewalls", operation: "Firewalls.ListByDroplet"},
+
+	{method: http.MethodGet, template: firewallsBasePath, operation: "Firewalls.List"},
+	{method: http.MethodPost, template: firewallsBasePath, operation: "Firewalls.Create"},
+	{method: http.MethodGet, template: firewallsBasePath + "/%s", operation: "Firewalls.Get"},
+	{method: http.MethodPut, template: firewallsBasePath + "/%s", operation: "Firewalls.Update"},
+	{method: http.MethodDelete, template: firewallsBasePath + "/%s", operation: "Firewalls.Delete"},
+	{method: http.MethodPost, template: firewallsBasePath + "/%s/droplets", operation: "Firewalls.AddDroplets"},
+	{method: http.MethodDelete, template: firewallsBasePath + "/%s/droplets", operation: "Firewalls.RemoveDroplets"},
+	{method: http.MethodPost, template: firewallsBasePath + "/%s/tags", operation: "Firewalls.AddTags"},
+	{method: http.MethodDelete, template: firewallsBasePath + "/%s/tags", operation: "Firewalls.RemoveTags"},
+	{method: http.MethodPost, template: firewallsBasePath + "/%s/rules", operation: "Firewalls.AddRules"},
+	{method: http.MethodDelete, template: firewallsBasePath + "/%s/rules", operation: "Firewalls.RemoveRules"},
+
+	{method: http.MethodGet, template: floatingBasePath, operation: "FloatingIPs.List"},
+	{method: http.MethodPost, template: floatingBasePath, operation: "FloatingIPs.Create"},
+	{method: http.MethodGet, template: floatingBasePath + "/%s", operation: "FloatingIPs.Get"},
+	{method: http.MethodDelete, template: floatingBasePath + "/%s", operation: "FloatingIPs.Delete"},
+	{method: http.MethodGet, template: floatingBasePath + "/%s/actions", operation: "FloatingIPActions.List"},
+	{method: http.MethodPost, template: floatingBasePath + "/%s/actions", operation: "FloatingIPActions"},
+	{method: http.MethodGet, template: floatingBasePath + "/%s/actions/%d", operation: "FloatingIPActions.Get"},
+
+	{method: http.MethodPost, template: imageBasePath + "/%d/actions", operation: "ImageActions"},
+	{method: http.MethodGet, template: imageBasePath + "/%d/actions/%d", operation: "ImageActions.Get"},
+
+	{method: http.MethodGet, template: imageBasePath, query: "type=distribution", operation: "Images.ListDistribution"},
+	{method: http.MethodGet, template: imageBasePath, query: "type=application", operation: "Images.ListApplication"},
+	{method: http.MethodGet, template: imageBasePath, query: "private=true", operation: "Images.ListUser"},
+	{method: http.MethodGet, template: imageBasePath, query: "tag_name", operation: "Images.ListByTag"},
+	{method: http.MethodGet, template: imageBasePath, operation: "Images.List"},
+	{method: http.MethodPost, template: imageBasePath, operation: "Images.Create"},
+	{method: http.MethodGet, template: imageBasePath + "/%d", operation: "Images.GetByID"},
+	{method: http.MethodGet, template: imageBasePath + "/%s", operation: "Images.GetBySlug"},
+	{method: http.MethodPut, template: imageBasePath + "/%d", operation: "Images.Update"},
+	{method: http.MethodDelete, template: imageBasePath + "/%d", operation: "Images.Delete"},
+
+	{method: http.MethodGet, template: invoicesBasePath, operation: "Invoices.List"},
+	{method: http.MethodGet, template: invoicesBasePath + "/%s", operation: "Invoices.Get"},
+	{method: http.MethodGet, template: invoicesBasePath + "/%s/summary", operation: "Invoices.GetSummary"},
+	{method: http.MethodGet, template: invoicesBasePath + "/%s/pdf", operation: "Invoices.GetPDF"},
+	{method: http.MethodGet, template: invoicesBasePath + "/%s/csv", operation: "Invoices.GetCSV"},
+
+	{method: http.MethodGet, template: keysBasePath, operation: "Keys.List"},
+	{method: http.MethodPost, template: keysBasePath, operation: "Keys.Create"},
+	{method: http.MethodGet, template: keysBasePath + "/%d", operation: "Keys.GetByID"},
+	{method: http.MethodGet, template: keysBasePath + "/%s", operation: "Keys.GetByFingerprint"},
+	{method: http.MethodPut, template: keysBasePath + "/%d", operation: "Keys.UpdateByID"},
+	{method: http.MethodPut, template: keysBasePath + "/%s", operation: "Keys.UpdateByFingerprint"},
+	{method: http.MethodDelete, template: keysBasePath + "/%d", operation: "Keys.DeleteByID"},
+	{method: http.MethodDelete, template: keysBasePath + "/%s", operation: "Keys.DeleteByFingerprint"},
+
+	{method: http.MethodGet, template: kubernetesOptionsPath, operation: "Kubernetes.GetOptions"},
+	{method: http.MethodGet, template: kubernetesClustersPath, operation: "Kubernetes.List"},
+	{method: http.MethodPost, template: kubernetesClustersPath, operation: "Kubernetes.Create"},
+	{method: http.MethodGet, template: kubernetesClustersPath + "/%s", operation: "Kubernetes.Get"},
+	{method: http.MethodPut, template: kubernetesClustersPath + "/%s", operation: "Kubernetes.Update"},
+	{method: http.MethodDelete, template: kubernetesClustersPath + "/%s", operation: "Kubernetes.Delete"},
+	{method: http.MethodGet, template: kubernetesClustersPath + "/%s/user", operation: "Kubernetes.GetUser"},
+	{method: http.MethodGet, template: kubernetesClustersPath + "/%s/upgrades", operation: "Kubernetes.GetUpgrades"},
+	{method: http.MethodPost, template: kubernetesClustersPath + "/%s/upgrade", operation: "Kubernetes.Upgrade"},
+	{method: http.MethodGet, template: kubernetesClustersPath + "/%s/kubeconfig", operation: "Kubernetes.GetKubeConfig"},
+	{method: http.MethodGet, template: kubernetesClustersPath + "/%s/credentials", operation: "Kubernetes.GetCredentials"},
+	{method: http.MethodGet, template: kubernetesClustersPath + "/%s/node_pools", operation: "Kubernetes.ListNodePools"},
+	{method: http.MethodPost, template: kubernetesClustersPath + "/%s/node_pools", operation: "Kubernetes.CreateNodePool"},
+	{method: http.MethodGet, template: kubernetesClustersPath + "/%s/node_pools/%s", operation: "Kubernetes.GetNodePool"},
+	{method: http.MethodPut, template: kubernetesClustersPath + "/%s/node_pools/%s", operation: "Kubernetes.UpdateNodePool"},
+	{method: http.MethodDelete, template: kubernetesClustersPath + "/%s/node_pools/%s", operation: "Kubernetes.DeleteNodePool"},
+	{method: http.MethodPost, template: kubernetesClustersPath + "/%s/node_pools/%s/recycle", operation: "Kubernetes.RecycleNodePoolNodes"},
+	{method: http.MethodDelete, template: kubernetesClustersPath + "/%s/node_pools/%s/nodes/%s", operation: "Kubernetes.DeleteNode"},
+
+	{method: http.MethodGet, template: loadBalancersBasePath, operation: "LoadBalancers.List"},
+	{method: http.MethodPost, template: loadBalancersBasePath, operation: "LoadBalancers.Create"},
+	{method: http.MethodGet, template: loadBalancersBasePath + "/%s", operation: "LoadBalancers.Get"},
+	{method: http.MethodPut, template: loadBalancersBasePath + "/%s", operation: "LoadBalancers.Update"},
+	{method: http.MethodDelete, template: loadBalancersBasePath + "/%s", operation: "LoadBalancers.Delete"},
+	{method: http.MethodPost, template: loadBalancersBasePath + "/%s/" + dropletsPath, operation: "LoadBalancers.AddDroplets"},
+	{method: http.MethodDelete, template: loadBalancersBasePath + "/%s/" + dropletsPath, operation: "LoadBalancers.RemoveDroplets"},
+	{method: http.MethodPost, template: loadBalancersBasePath + "/%s/" + forwardingRulesPath, operation: "LoadBalancers.AddForwardingRules"},
+	{method: http.MethodDelete, template: loadBalancersBasePath + "/%s/" + forwardingRulesPath, operation: "LoadBalancers.RemoveForwardingRules"},
+
+	{method: http.MethodGet, template: projectsBasePath, operation: "Projects.List"},
+	{method: http.MethodPost, template: projectsBasePath, operation: "Projects.Create"},
+	{method: http.MethodGet, template: projectsBasePath + "/default", operation: "Projects.GetDefault"},
+	{method: http.MethodGet, template: projectsBasePath + "/%s", operation: "Projects.Get"},
+	{method: http.MethodPatch, template: projectsBasePath + "/%s", operation: "Projects.Update"},
+	{method: http.MethodDelete, template: projectsBasePath + "/%s", operation: "Projects.Delete"},
+	{method: http.MethodGet, template: projectsBasePath + "/%s/resources", operation: "Projects.ListResources"},
+	{method: http.MethodPost, template: projectsBasePath + "/%s/resources", operation: "Projects.AssignResources"},
+
+	{method: http.MethodGet, template: regionsBasePath, operation: "Regions.List"},
+
+	{method: http.MethodGet, template: registryPath, operation: "Registry.Get"},
+	{method: http.MethodPost, template: registryPath, operation: "Registry.Create"},
+	{method: http.MethodDelete, template: registryPath, operation: "Registry.Delete"},
+	{method: http.MethodGet, template: registryPath + "/docker-credentials", operation: "Registry.DockerCredentials"},
+	{method: http.MethodGet, template: registryPath + "/%s/repositories", operation: "Registry.ListRepositories"},
+	{method: http.MethodGet, template: registryPath + "/%s/repositories/%s/tags", operation: "Registry.ListRepositoryTags"},
+	{method: http.MethodDelete, template: registryPath + "/%s/repositories/%s/tags/%s", operation: "Registry.DeleteTag"},
+	{method: http.MethodDelete, template: registryPath + "/%s/repositories/%s/digests/%s", operation: "Registry.DeleteManifest"},
+
+	{method: http.MethodGet, template: sizesBasePath, operation: "Sizes.List"},
+
+	{method: http.MethodGet, template: snapshotBasePath, query: "resource_type=droplet", operation: "Snapshots.ListDroplet"},
+	{method: http.MethodGet, template: snapshotBasePath, query: "resource_type=volume", operation: "Snapshots.ListVolume"},
+	{method: http.MethodGet, template: snapshotBasePath, operation: "Snapshots.List"},
+
+	{method: http.MethodGet, template: storageAllocPath, operation: "Storage.ListVolumes"},
+	{method: http.MethodPost, template: storageAllocPath, operation: "Storage.CreateVolume"},
+	{method: http.MethodGet, template: storageAllocPath + "/%s", operation: "Storage.GetVolume"},
+	{method: http.MethodDelete, template: storageAllocPath + "/%s", operation: "Storage.DeleteVolume"},
+	{method: http.MethodGet, template: storageAllocPath + "/%s/snapshots", operation: "Storage.ListSnapshots"},
+	{method: http.MethodPost, template: storageAllocPath + "/%s/snapshots", operation: "Storage.CreateSnapshot"},
+	{method: http.MethodGet, template: storageAllocPath + "/%s/actions", operation: "StorageActions.List"},
+	{method: http.MethodPost, template: storageAllocPath + "/%s/actions", operation: "StorageActions"},
+	{method: http.MethodGet, template: storageAllocPath + "/%s/actions/%d", operation: "StorageActions.Get"},
+
+	// Volume and Droplet snapshots share the snapshots endpoints.
+	{method: http.MethodGet, template: storageSnapPath + "/%s", operation: "Snapshots.Get"},
+	{method: http.MethodDelete, template: storageSnapPath + "/%s", operation: "Snapshots.Delete"},
+
+	{method: http.MethodGet, template: tagsBasePath, operation: "Tags.List"},
+	{method: http.MethodPost, template: tagsBasePath, operation: "Tags.Create"},
+	{method: http.MethodGet, template: tagsBasePath + "/%s", operation: "Tags.Get"},
+	{method: http.MethodDelete, template: tagsBasePath + "/%s", operation: "Tags.Delete"},
+	{method: http.MethodPost, template: tagsBasePath + "/%s/resources", operation: "Tags.TagResources"},
+	{method: http.MethodDelete, template: tagsBasePath + "/%s/resources", operation: "Tags.UntagResources"},
+
+	{method: http.MethodGet, template: vpcsBasePath, operation: "VPCs.List"},
+	{method: http.MethodPost, template: vpcsBasePath, operation: "VPCs.Create"},
+	{method: http.MethodGet, template: vpcsBasePath + "/%s", operation: "VPCs.Get"},
+	{method: http.MethodPut, template: vpcsBasePath + "/%s", operation: "VPCs.Update"},
+	{method: http.MethodPatch, template: vpcsBasePath + "/%s", operation: "VPCs.Set"},
+	{method: http.MethodDelete, template: vpcsBasePath + "/%s", operation: "VPCs.Delete"},
+})
+
+func compileRoutes(rs []route) []route {
+	for i := range rs {
+		rs[i].segments = strings.Split(strings.Trim(rs[i].template, "/"), "/")
+	}
+	return rs
+}
+
+// match reports whether the route matches the request with the given method,
+// path segments and query, returning the values of the placeholders.
+func (r *route) match(method string, segments []string, req *http.Request) ([]string, bool) {
+	if r.method != method || len(r.segments) != len(segments) {
+		return nil, false
+	}
+
+	var params []string
+	for i, s := range r.segments {
+		switch s {
+		case "%d":
+			if !isNumeric(segments[i]) {
+				return nil, false
+			}
+			params = append(params, segments[i])
+		case "%s":
+			if segments[i] == "" {
+				return nil, false
+			}
+			params = append(params, segments[i])
+		default:
+			if s != segments[i] {
+				return nil, false
+			}
+		}
+	}
+
+	if r.query != "" {
+		q := req.URL.Query()
+		if kv := strings.SplitN(r.query, "=", 2); len(kv) == 2 {
+			if q.Get(kv[0]) != kv[1] {
+				return nil, false
+			}
+		} else if q.Get(r.query) == "" {
+			return nil, false
+		}
+	}
+
+	if r.body != "" {
+		if _, ok := requestFields(req)[r.body]; !ok {
+			return nil, false
+		}
+	}
+
+	return params, true
+}
+
+func isNumeric(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// Operation describes the service method that issued an API request.
+type Operation struct {
+	// Name is the service and method name, such as "Droplets.Create" or
+	// "Kubernetes.GetKubeConfig". Requests that do not match a known
+	// endpoint are named after their method and the collection in their
+	// path, such as "GET v2/unknown" for "GET v2/unknown/1", keeping
+	// resource identifiers out of metric labels and span names.
+	Name string
+
+	// ResourceIDs are the identifiers found in the request path, outermost
+	// first, such as the cluster and node pool IDs of
+	// "Kubernetes.GetNodePool".
+	ResourceIDs []string
+}
+
+// RequestOperation returns the service method that issued req, derived from
+// the path templates of the services.
+func RequestOperation(req *http.Request) Operation {
+	path := apiPath(req)
+	segments := strings.Split(path, "/")
+
+	for i := range routes {
+		r := &routes[i]
+		params, ok := r.match(req.Method, segments, req)
+		if !ok {
+			continue
+		}
+
+		name := r.operation
+		if !strings.Contains(name, ".") {
+			name += "." + actionMethod(req)
+		}
+		return Operation{Name: name, ResourceIDs: params}
+	}
+
+	return Operation{Name: req.Method + " " + unknownPath(segments)}
+}
+
+// unknownPath returns the API version and collection segments of the path of
+// a request matching no route, such as "v2/unknown". A collection segment that
+// looks like an identifier is replaced with "{id}".
+func unknownPath(segments []string) string {
+	n := 1
+	if segments[0] == "v2" && len(segments) > 1 {
+		n = 2
+	}
+	path := append([]string(nil), segments[:n]...)
+
+	for _, r := range path[n-1] {
+		if (r < 'a' || r > 'z') && r != '_' {
+			path[n-1] = "{id}"
+			break
+		}
+	}
+	return strings.Join(path, "/")
+}
+
+// apiPath returns the path of req relative to the API root, such as
+// "v2/droplets/1", whatever the path of the client's BaseURL.
+func apiPath(req *http.Request) string {
+	path := req.URL.Path
+	if i := strings.Index(path, "/v2/"); i >= 0 {
+		path = path[i+1:]
+	}
+	return strings.Trim(path, "/")
+}
+
+// actionMethod derives the method name of an action request, such as
+// "PowerOffByTag", from the action type in the request body.
+func actionMethod(req *http.Request) string {
+	actionType := "unknown"
+	if data, ok := requestFields(req)["type"]; ok {
+		var t string
+		if err := json.Unmarshal(data, &t); err == nil && t != "" {
+			actionType = t
+		}
+	}
+
+	var name strings.Builder
+	for _, word := range strings.Split(actionType, "_") {
+		switch word {
+		case "":
+		case "ipv6":
+			name.WriteString("IPv6")
+		default:
+			name.WriteString(strings.ToUpper(word[:1]) + word[1:])
+		}
+	}
+	if req.URL.Query().Get("tag_name") != "" {
+		name.WriteString("ByTag")
+	}
+	return name.String()
+}
+
+// requestFields returns the fields of the JSON object sent as the body of req,
+// or nil if it has none.
+func requestFields(req *http.Request) map[string]json.RawMessage {
+	if req.GetBody == nil {
+		return nil
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return nil
+	}
+	data, _ := ioutil.ReadAll(body)
+	body.Close()
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(bytes.TrimSpace(data), &fields); err != nil {
+		return nil
+	}
+	return fields
+}
@@ -0,0 +1,108 @@
+package godo
+
+import (
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestRequestOperation(t *testing.T) {
+	setup()
+	defer teardown()
+
+	cases := []struct {
+		method   string
+		path     string
+		body     interface{}
+		expected string
+		ids      []string
+	}{
+		{http.MethodPost, "v2/droplets", &DropletCreateRequest{Name: "d"}, "Droplets.Create", nil},
+		{http.MethodPost, "v2/droplets", &DropletMultiCreateRequest{Names: []string{"d1", "d2"}}, "Droplets.CreateMultiple", nil},
+		{http.MethodGet, "v2/droplets?page=2&tag_name=web", nil, "Droplets.ListByTag", nil},
+		{http.MethodDelete, "v2/droplets?tag_name=web", nil, "Droplets.DeleteByTag", nil},
+		{http.MethodGet, "v2/droplets/123", nil, "Droplets.Get", []string{"123"}},
+		{http.MethodGet, "v2/droplets/123/firewalls", nil, "Firewalls.ListByDroplet", []string{"123"}},
+		{http.MethodPost, "v2/droplets/123/actions", &ActionRequest{"type": "enable_ipv6"}, "DropletActions.EnableIPv6", []string{"123"}},
+		{http.MethodPost, "v2/droplets/actions?tag_name=web", &ActionRequest{"type": "power_off"}, "DropletActions.PowerOffByTag", nil},
+		{http.MethodGet, "v2/droplets/123/actions/456", nil, "DropletActions.Get", []string{"123", "456"}},
+		{http.MethodPost, "v2/volumes/vol/actions", &ActionRequest{"type": "attach"}, "StorageActions.Attach", []string{"vol"}},
+		{http.MethodGet, "v2/images?page=1&type=distribution", nil, "Images.ListDistribution", nil},
+		{http.MethodGet, "v2/images/123", nil, "Images.GetByID", []string{"123"}},
+		{http.MethodGet, "v2/images/ubuntu-20-04-x64", nil, "Images.GetBySlug", []string{"ubuntu-20-04-x64"}},
+		{http.MethodGet, "v2/account/keys/aa:bb", nil, "Keys.GetByFingerprint", []string{"aa:bb"}},
+		{http.MethodGet, "v2/projects/default", nil, "Projects.GetDefault", nil},
+		{http.MethodPatch, "v2/projects/p", nil, "Projects.Update", []string{"p"}},
+		{http.MethodGet, "v2/kubernetes/clusters/k8s/kubeconfig", nil, "Kubernetes.GetKubeConfig", []string{"k8s"}},
+		{http.MethodDelete, "v2/kubernetes/clusters/k8s/node_pools/np/nodes/n?skip_drain=1", nil, "Kubernetes.DeleteNode", []string{"k8s", "np", "n"}},
+		{http.MethodPut, "v2/databases/db/eviction_policy", nil, "Databases.SetEvictionPolicy", []string{"db"}},
+		{http.MethodGet, "v2/snapshots?resource_type=volume", nil, "Snapshots.ListVolume", nil},
+		{http.MethodPut, "v2/cdn/endpoints/cdn", &CDNUpdateTTLRequest{TTL: 60}, "CDNs.UpdateTTL", []string{"cdn"}},
+		{http.MethodPut, "v2/cdn/endpoints/cdn", &CDNUpdateCustomDomainRequest{CustomDomain: "static.example.com"}, "CDNs.UpdateCustomDomain", []string{"cdn"}},
+		{http.MethodGet, "v2/unknown/1", nil, "GET v2/unknown", nil},
+		{http.MethodDelete, "v2/unknown/1/things/a-b-c", nil, "DELETE v2/unknown", nil},
+		{http.MethodGet, "v2/8f2b1c4e-5d6a", nil, "GET v2/{id}", nil},
+	}
+
+	for _, c := range cases {
+		req, err := client.NewRequest(ctx, c.method, c.path, c.body)
+		if err != nil {
+			t.Fatalf("NewRequest(%s %s): %v", c.method, c.path, err)
+		}
+
+		op := RequestOperation(req)
+		if op.Name != c.expected {
+			t.Errorf("%s %s: operation = %q, expected %q", c.method, c.path, op.Name, c.expected)
+		}
+		if len(op.ResourceIDs) != len(c.ids) {
+			t.Errorf("%s %s: resource IDs = %v, expected %v", c.method, c.path, op.ResourceIDs, c.ids)
+			continue
+		}
+		for i := range c.ids {
+			if op.ResourceIDs[i] != c.ids[i] {
+				t.Errorf("%s %s: resource IDs = %v, expected %v", c.method, c.path, op.ResourceIDs, c.ids)
+			}
+		}
+	}
+}
+
+func TestRequestOperation_baseURLWithPrefix(t *testing.T) {
+	c, err := New(nil, SetBaseURL("https://proxy.example.com/digitalocean/"))
+	if err != nil {
+		t.Fatalf("New(): %v", err)
+	}
+
+	req, _ := c.NewRequest(ctx, http.MethodGet, "v2/regions", nil)
+	if got := RequestOperation(req).Name; got != "Regions.List" {
+		t.Errorf("operation = %q, expected %q", got, "Regions.List")
+	}
+}
+
+func TestRoutes_unique(t *testing.T) {
+	seen := make(map[string]bool)
+	for _, r := range routes {
+		key := r.method + " " + r.template + "?" + r.query + " " + r.body
+		if seen[key] {
+			t.Errorf("duplicate route %s", key)
+		}
+		seen[key] = true
+	}
+}
+
+func TestRoutes_methodsExist(t *testing.T) {
+	client := reflect.TypeOf(Client{})
+	for _, r := range routes {
+		parts := strings.SplitN(r.operation, ".", 2)
+		service, ok := client.FieldByName(parts[0])
+		if !ok || service.Type.Kind() != reflect.Interface {
+			t.Errorf("%s: no %s service", r.operation, parts[0])
+			continue
+		}
+		if len(parts) == 2 {
+			if _, ok := service.Type.MethodByName(parts[1]); !ok {
+				t.Errorf("%s: %s has no method %s", r.operation, service.Type, parts[1])
+			}
+		}
+	}
+}
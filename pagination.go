@@ -0,0 +1,237 @@
+package godo
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// PageFunc fetches the page of a list described by opt. It returns the items
+// of the page, which must be a slice, along with the API response. Any List
+// method can be adapted to a PageFunc with a closure, for example:
+//
+//	func(ctx context.Context, opt *godo.ListOptions) (interface{}, *godo.Response, error) {
+//		return client.Droplets.ListByTag(ctx, "web", opt)
+//	}
+type PageFunc func(ctx context.Context, opt *ListOptions) (interface{}, *Response, error)
+
+// Pager iterates over the pages of a paginated list, following the next page
+// link returned with each page.
+type Pager struct {
+	fetch PageFunc
+	opt   ListOptions
+	items interface{}
+	resp  *Response
+	err   error
+	done  bool
+}
+
+// NewPager returns a Pager fetching pages with fetch, starting with the page
+// described by opt. A nil opt starts at the first page with the default page
+// size.
+func NewPager(fetch PageFunc, opt *ListOptions) *Pager {
+	p := &Pager{fetch: fetch}
+	if opt != nil {
+		p.opt = *opt
+	}
+	return p
+}
+
+// Next fetches the next page, returning false once all pages have been read,
+// an error occurs or ctx is done.
+func (p *Pager) Next(ctx context.Context) bool {
+	if p.done {
+		return false
+	}
+	if err := ctx.Err(); err != nil {
+		p.err = err
+		p.done = true
+		return false
+	}
+
+	opt := p.opt
+	items, resp, err := p.fetch(ctx, &opt)
+	if err != nil {
+		p.err = err
+		p.done = true
+		return false
+	}
+
+	p.items, p.resp = items, resp
+	if resp == nil || resp.Links == nil || resp.Links.IsLastPage() {
+		p.done = true
+		return true
+	}
+
+	next, err := pageForURL(resp.Links.Pages.Next)
+	if err != nil {
+		p.err = err
+		p.done = true
+		return true
+	}
+	p.opt.Page = next
+
+	return true
+}
+
+// Items returns the items of the current page as returned by the PageFunc.
+func (p *Pager) Items() interface{} {
+	return p.items
+}
+
+// Response returns the API response for the current page.
+func (p *Pager) Response() *Response {
+	return p.resp
+}
+
+// Err returns the error, if any, that stopped the iteration.
+func (p *Pager) Err() error {
+	return p.err
+}
+
+// ListAll fetches every page of a list, starting with the page described by
+// opt, and appends the items to the slice pointed to by dst.
+//
+//	var droplets []godo.Droplet
+//	err := godo.ListAll(ctx, &droplets, func(ctx context.Context, opt *godo.ListOptions) (interface{}, *godo.Response, error) {
+//		return client.Droplets.List(ctx, opt)
+//	}, nil)
+func ListAll(ctx context.Context, dst interface{}, fetch PageFunc, opt *ListOptions) error {
+	dv, err := sliceDest(dst)
+	if err != nil {
+		return err
+	}
+
+	pager := NewPager(fetch, opt)
+	for pager.Next(ctx) {
+		if err := appendItems(dv, pager.Items()); err != nil {
+			return err
+		}
+	}
+
+	return pager.Err()
+}
+
+// ListAllConcurrently behaves like ListAll, but once the first page reports
+// the total number of items in its meta, the remaining pages are fetched
+// concurrently by up to workers goroutines. Lists without meta are fetched
+// sequentially. Items are appended to dst in page order.
+func ListAllConcurrently(ctx context.Context, dst interface{}, fetch PageFunc, opt *ListOptions, workers int) error {
+	if workers < 1 {
+		return NewArgError("workers", "cannot be less than 1")
+	}
+
+	dv, err := sliceDest(dst)
+	if err != nil {
+		return err
+	}
+
+	pager := NewPager(fetch, opt)
+	if !pager.Next(ctx) {
+		return pager.Err()
+	}
+	if err := appendItems(dv, pager.Items()); err != nil {
+		return err
+	}
+
+	resp := pager.Response()
+	if pager.done || resp.Meta == nil || resp.Meta.Total == 0 {
+		for pager.Next(ctx) {
+			if err := appendItems(dv, pager.Items()); err != nil {
+				return err
+			}
+		}
+		return pager.Err()
+	}
+
+	first := pager.opt.Page - 1
+	perPage := pager.opt.PerPage
+	if perPage == 0 {
+		perPage = reflect.ValueOf(pager.Items()).Len()
+	}
+	if perPage == 0 {
+		return nil
+	}
+	last := (resp.Meta.Total + perPage - 1) / perPage
+	if last <= first {
+		return nil
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	pages := make([]interface{}, last-first)
+	queue := make(chan int)
+
+	var (
+		errOnce  sync.Once
+		firstErr error
+	)
+
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for page := range queue {
+				opt := ListOptions{Page: page, PerPage: pager.opt.PerPage}
+				items, _, err := fetch(ctx, &opt)
+				if err != nil {
+					errOnce.Do(func() {
+						firstErr = err
+						cancel()
+					})
+					continue
+				}
+				pages[page-first-1] = items
+			}
+		}()
+	}
+
+	for page := first + 1; page <= last; page++ {
+		select {
+		case queue <- page:
+		case <-ctx.Done():
+		}
+	}
+	close(queue)
+	wg.Wait()
+
+	if firstErr != nil {
+		return firstErr
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	for _, items := range pages {
+		if err := appendItems(dv, items); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func sliceDest(dst interface{}) (reflect.Value, error) {
+	v := reflect.ValueOf(dst)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Slice {
+		return reflect.Value{}, NewArgError("dst", "must be a non-nil pointer to a slice")
+	}
+	return v.Elem(), nil
+}
+
+func appendItems(dst reflect.Value, items interface{}) error {
+	if items == nil {
+		return nil
+	}
+
+	iv := reflect.ValueOf(items)
+	if iv.Kind() != reflect.Slice || !iv.Type().AssignableTo(dst.Type()) {
+		return NewArgError("dst", fmt.Sprintf("cannot hold page items of type %T", items))
+	}
+
+	dst.Set(reflect.AppendSlice(dst, iv))
+	return nil
+}
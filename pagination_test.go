@@ -0,0 +1,218 @@
+package godo
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// handlePagedDroplets serves total droplets over pages of perPage items,
+// counting the requests made for each page.
+func handlePagedDroplets(t *testing.T, total, perPage int, withMeta bool) map[int]int {
+	var mu sync.Mutex
+	requests := make(map[int]int)
+
+	mux.HandleFunc("/v2/droplets", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+
+		page := 1
+		if p := r.URL.Query().Get("page"); p != "" {
+			page, _ = strconv.Atoi(p)
+		}
+		if pp := r.URL.Query().Get("per_page"); pp != "" && pp != strconv.Itoa(perPage) {
+			t.Errorf("per_page = %s, expected %d", pp, perPage)
+		}
+
+		mu.Lock()
+		requests[page]++
+		mu.Unlock()
+
+		var droplets []string
+		for id := (page-1)*perPage + 1; id <= page*perPage && id <= total; id++ {
+			droplets = append(droplets, fmt.Sprintf(`{"id":%d}`, id))
+		}
+
+		last := (total + perPage - 1) / perPage
+		pages := ""
+		if page > 1 {
+			pages = fmt.Sprintf(`"prev":"http://example.com/v2/droplets/?page=%d&per_page=%d"`, page-1, perPage)
+		}
+		if page < last {
+			if pages != "" {
+				pages += ","
+			}
+			pages += fmt.Sprintf(`"next":"http://example.com/v2/droplets/?page=%d&per_page=%d"`, page+1, perPage)
+		}
+
+		meta := ""
+		if withMeta {
+			meta = fmt.Sprintf(`, "meta": {"total": %d}`, total)
+		}
+
+		fmt.Fprintf(w, `{"droplets": [%s], "links": {"pages": {%s}}%s}`, strings.Join(droplets, ","), pages, meta)
+	})
+
+	return requests
+}
+
+func listDroplets(ctx context.Context, opt *ListOptions) (interface{}, *Response, error) {
+	return client.Droplets.List(ctx, opt)
+}
+
+func dropletIDs(droplets []Droplet) []int {
+	ids := make([]int, len(droplets))
+	for i, d := range droplets {
+		ids[i] = d.ID
+	}
+	return ids
+}
+
+func expectedIDs(n int) []int {
+	ids := make([]int, n)
+	for i := range ids {
+		ids[i] = i + 1
+	}
+	return ids
+}
+
+func TestPager(t *testing.T) {
+	setup()
+	defer teardown()
+
+	requests := handlePagedDroplets(t, 5, 2, false)
+
+	pager := NewPager(listDroplets, &ListOptions{PerPage: 2})
+	var pages [][]int
+	for pager.Next(ctx) {
+		pages = append(pages, dropletIDs(pager.Items().([]Droplet)))
+	}
+	if err := pager.Err(); err != nil {
+		t.Fatalf("Pager.Err(): %v", err)
+	}
+
+	expected := [][]int{{1, 2}, {3, 4}, {5}}
+	if !reflect.DeepEqual(pages, expected) {
+		t.Errorf("pages = %v, expected %v", pages, expected)
+	}
+	if len(requests) != 3 {
+		t.Errorf("requested pages = %v, expected 3", requests)
+	}
+	if pager.Next(ctx) {
+		t.Error("Next() after last page = true, expected false")
+	}
+}
+
+func TestPager_contextCanceled(t *testing.T) {
+	setup()
+	defer teardown()
+
+	handlePagedDroplets(t, 5, 2, false)
+
+	cctx, cancel := context.WithCancel(ctx)
+	pager := NewPager(listDroplets, &ListOptions{PerPage: 2})
+	if !pager.Next(cctx) {
+		t.Fatalf("Next() = false, error: %v", pager.Err())
+	}
+	cancel()
+
+	if pager.Next(cctx) {
+		t.Error("Next() = true after cancel, expected false")
+	}
+	if pager.Err() != context.Canceled {
+		t.Errorf("Pager.Err() = %v, expected %v", pager.Err(), context.Canceled)
+	}
+}
+
+func TestListAll(t *testing.T) {
+	setup()
+	defer teardown()
+
+	handlePagedDroplets(t, 7, 3, false)
+
+	var droplets []Droplet
+	if err := ListAll(ctx, &droplets, listDroplets, &ListOptions{PerPage: 3}); err != nil {
+		t.Fatalf("ListAll(): %v", err)
+	}
+
+	if got, expected := dropletIDs(droplets), expectedIDs(7); !reflect.DeepEqual(got, expected) {
+		t.Errorf("droplets = %v, expected %v", got, expected)
+	}
+}
+
+func TestListAll_badDest(t *testing.T) {
+	var droplets []Droplet
+	if err := ListAll(ctx, droplets, listDroplets, nil); err == nil {
+		t.Error("expected error for non-pointer destination")
+	}
+
+	setup()
+	defer teardown()
+	handlePagedDroplets(t, 1, 20, false)
+
+	var images []Image
+	if err := ListAll(ctx, &images, listDroplets, nil); err == nil {
+		t.Error("expected error for mismatched destination")
+	}
+}
+
+func TestListAllConcurrently(t *testing.T) {
+	setup()
+	defer teardown()
+
+	requests := handlePagedDroplets(t, 23, 4, true)
+
+	var droplets []Droplet
+	if err := ListAllConcurrently(ctx, &droplets, listDroplets, &ListOptions{PerPage: 4}, 3); err != nil {
+		t.Fatalf("ListAllConcurrently(): %v", err)
+	}
+
+	if got, expected := dropletIDs(droplets), expectedIDs(23); !reflect.DeepEqual(got, expected) {
+		t.Errorf("droplets = %v, expected %v", got, expected)
+	}
+	for page := 1; page <= 6; page++ {
+		if requests[page] != 1 {
+			t.Errorf("page %d requested %d times, expected once", page, requests[page])
+		}
+	}
+}
+
+func TestListAllConcurrently_withoutMeta(t *testing.T) {
+	setup()
+	defer teardown()
+
+	handlePagedDroplets(t, 9, 4, false)
+
+	var droplets []Droplet
+	if err := ListAllConcurrently(ctx, &droplets, listDroplets, &ListOptions{PerPage: 4}, 3); err != nil {
+		t.Fatalf("ListAllConcurrently(): %v", err)
+	}
+
+	if got, expected := dropletIDs(droplets), expectedIDs(9); !reflect.DeepEqual(got, expected) {
+		t.Errorf("droplets = %v, expected %v", got, expected)
+	}
+}
+
+func TestListAllConcurrently_error(t *testing.T) {
+	setup()
+	defer teardown()
+
+	handlePagedDroplets(t, 9, 4, true)
+
+	fetch := func(ctx context.Context, opt *ListOptions) (interface{}, *Response, error) {
+		if opt.Page == 3 {
+			return nil, nil, fmt.Errorf("page 3 failed")
+		}
+		return listDroplets(ctx, opt)
+	}
+
+	var droplets []Droplet
+	err := ListAllConcurrently(ctx, &droplets, fetch, &ListOptions{PerPage: 4}, 2)
+	if err == nil || err.Error() != "page 3 failed" {
+		t.Errorf("ListAllConcurrently() error = %v, expected page 3 failed", err)
+	}
+}
@@ -0,0 +1,214 @@
+package godo
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Pool holds one client per account, for platforms managing several teams.
+// Each client tracks the rate limit of its own account. A Pool is safe for
+// concurrent use.
+type Pool struct {
+	mu      sync.RWMutex
+	clients map[string]*Client
+}
+
+// PoolFunc is an operation run by Pool.Do on the client of an account. Its
+// first result is the value of the operation, such as a list of Droplets.
+type PoolFunc func(ctx context.Context, account string, client *Client) (interface{}, *Response, error)
+
+// PoolResult is the result of an operation run on the client of an account.
+type PoolResult struct {
+	Value    interface{}
+	Response *Response
+	Err      error
+}
+
+// PoolResults are the results of an operation run on all accounts of a pool,
+// keyed by account.
+type PoolResults map[string]PoolResult
+
+// PoolError is returned by fan-out operations that failed for some accounts.
+type PoolError struct {
+	// Errors are the errors of the accounts that failed, keyed by account.
+	Errors map[string]error
+}
+
+func (e *PoolError) Error() string {
+	accounts := make([]string, 0, len(e.Errors))
+	for account := range e.Errors {
+		accounts = append(accounts, account)
+	}
+	sort.Strings(accounts)
+
+	msgs := make([]string, len(accounts))
+	for i, account := range accounts {
+		msgs[i] = fmt.Sprintf("%s: %v", account, e.Errors[account])
+	}
+	return fmt.Sprintf("godo: %d of the accounts failed: %s", len(accounts), strings.Join(msgs, "; "))
+}
+
+// NewPool returns an empty pool.
+func NewPool() *Pool {
+	return &Pool{clients: make(map[string]*Client)}
+}
+
+// Add adds the client of an account to the pool.
+func (p *Pool) Add(account string, c *Client) error {
+	if account == "" {
+		return NewArgError("account", "cannot be empty")
+	}
+	if c == nil {
+		return NewArgError("c", "cannot be nil")
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if _, ok := p.clients[account]; ok {
+		return NewArgError("account", fmt.Sprintf("%q is already in the pool", account))
+	}
+	p.clients[account] = c
+	return nil
+}
+
+// AddToken adds a client for an account accessed with token to the pool, and
+// returns it.
+func (p *Pool) AddToken(account, token string, opts ...ClientOpt) (*Client, error) {
+	c, err := NewFromCredentials(&Credentials{Token: token}, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.Add(account, c); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// Remove removes the client of an account from the pool.
+func (p *Pool) Remove(account string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	delete(p.clients, account)
+}
+
+// Client returns the client of an account.
+func (p *Pool) Client(account string) (*Client, bool) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	c, ok := p.clients[account]
+	return c, ok
+}
+
+// Accounts returns the accounts of the pool, sorted.
+func (p *Pool) Accounts() []string {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	accounts := make([]string, 0, len(p.clients))
+	for account := range p.clients {
+		accounts = append(accounts, account)
+	}
+	sort.Strings(accounts)
+	return accounts
+}
+
+// Rates returns the current rate limit of each account.
+func (p *Pool) Rates() map[string]Rate {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	rates := make(map[string]Rate, len(p.clients))
+	for account, c := range p.clients {
+		rates[account] = c.GetRate()
+	}
+	return rates
+}
+
+// Do runs fn on the clients of all accounts concurrently, and returns the
+// results keyed by account once all of them are done.
+func (p *Pool) Do(ctx context.Context, fn PoolFunc) PoolResults {
+	p.mu.RLock()
+	clients := make(map[string]*Client, len(p.clients))
+	for account, c := range p.clients {
+		clients[account] = c
+	}
+	p.mu.RUnlock()
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	results := make(PoolResults, len(clients))
+	for account, c := range clients {
+		wg.Add(1)
+		go func(account string, c *Client) {
+			defer wg.Done()
+
+			v, resp, err := fn(ctx, account, c)
+
+			mu.Lock()
+			results[account] = PoolResult{Value: v, Response: resp, Err: err}
+			mu.Unlock()
+		}(account, c)
+	}
+	wg.Wait()
+
+	return results
+}
+
+// Err returns a *PoolError with the errors of the accounts that failed, or
+// nil if none did.
+func (r PoolResults) Err() error {
+	errs := make(map[string]error)
+	for account, result := range r {
+		if result.Err != nil {
+			errs[account] = result.Err
+		}
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	return &PoolError{Errors: errs}
+}
+
+// ListDroplets lists all the Droplets of each account. The Droplets of the
+// accounts that succeeded are returned even if others failed, along with a
+// *PoolError.
+func (p *Pool) ListDroplets(ctx context.Context) (map[string][]Droplet, error) {
+	results := p.Do(ctx, func(ctx context.Context, _ string, c *Client) (interface{}, *Response, error) {
+		var droplets []Droplet
+		err := ListAll(ctx, &droplets, func(ctx context.Context, opt *ListOptions) (interface{}, *Response, error) {
+			return c.Droplets.List(ctx, opt)
+		}, nil)
+		return droplets, nil, err
+	})
+
+	droplets := make(map[string][]Droplet, len(results))
+	for account, result := range results {
+		if result.Err == nil {
+			droplets[account] = result.Value.([]Droplet)
+		}
+	}
+	return droplets, results.Err()
+}
+
+// GetBalances returns the balance of each account. The balances of the
+// accounts that succeeded are returned even if others failed, along with a
+// *PoolError.
+func (p *Pool) GetBalances(ctx context.Context) (map[string]*Balance, error) {
+	results := p.Do(ctx, func(ctx context.Context, _ string, c *Client) (interface{}, *Response, error) {
+		return c.Balance.Get(ctx)
+	})
+
+	balances := make(map[string]*Balance, len(results))
+	for account, result := range results {
+		if result.Err == nil {
+			balances[account] = result.Value.(*Balance)
+		}
+	}
+	return balances, results.Err()
+}
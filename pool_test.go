@@ -0,0 +1,156 @@
+package godo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// newPoolServer returns a server answering as the account name, with its own
+// rate limit.
+func newPoolServer(name string, remaining int) *httptest.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v2/droplets", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(headerRateLimit, "5000")
+		w.Header().Set(headerRateRemaining, fmt.Sprint(remaining))
+		fmt.Fprintf(w, `{"droplets": [{"id": 1, "name": "%s-1"}, {"id": 2, "name": "%s-2"}]}`, name, name)
+	})
+	mux.HandleFunc("/v2/customers/my/balance", func(w http.ResponseWriter, r *http.Request) {
+		if name == "broken" {
+			w.WriteHeader(http.StatusInternalServerError)
+			fmt.Fprint(w, `{"id": "server_error", "message": "boom"}`)
+			return
+		}
+		fmt.Fprintf(w, `{"account_balance": "%d.00"}`, remaining)
+	})
+
+	return httptest.NewServer(mux)
+}
+
+// newTestPool returns a pool of the given accounts, along with a function
+// closing their servers.
+func newTestPool(t *testing.T, accounts map[string]int) (*Pool, func()) {
+	pool := NewPool()
+	var servers []*httptest.Server
+	closeAll := func() {
+		for _, server := range servers {
+			server.Close()
+		}
+	}
+	for name, remaining := range accounts {
+		server := newPoolServer(name, remaining)
+		servers = append(servers, server)
+		if _, err := pool.AddToken(name, "token-"+name, SetBaseURL(server.URL)); err != nil {
+			closeAll()
+			t.Fatalf("AddToken(%q) error = %v", name, err)
+		}
+	}
+	return pool, closeAll
+}
+
+func TestPool_accounts(t *testing.T) {
+	pool := NewPool()
+	if err := pool.Add("team-b", NewClient(nil)); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	if _, err := pool.AddToken("team-a", "token"); err != nil {
+		t.Fatalf("AddToken() error = %v", err)
+	}
+
+	if err := pool.Add("team-a", NewClient(nil)); err == nil {
+		t.Error("expected an error adding an account twice")
+	}
+	if err := pool.Add("", NewClient(nil)); err == nil {
+		t.Error("expected an error adding an empty account")
+	}
+	if _, err := pool.AddToken("team-c", ""); err == nil {
+		t.Error("expected an error adding an empty token")
+	}
+
+	if got := pool.Accounts(); !reflect.DeepEqual(got, []string{"team-a", "team-b"}) {
+		t.Errorf("Accounts() = %v", got)
+	}
+
+	pool.Remove("team-b")
+	if _, ok := pool.Client("team-b"); ok {
+		t.Error("expected team-b to be removed")
+	}
+	if _, ok := pool.Client("team-a"); !ok {
+		t.Error("expected team-a to be in the pool")
+	}
+}
+
+func TestPool_ListDroplets(t *testing.T) {
+	pool, closeAll := newTestPool(t, map[string]int{"team-a": 100, "team-b": 200})
+	defer closeAll()
+
+	droplets, err := pool.ListDroplets(ctx)
+	if err != nil {
+		t.Fatalf("ListDroplets() error = %v", err)
+	}
+	for _, account := range []string{"team-a", "team-b"} {
+		if d := droplets[account]; len(d) != 2 || d[0].Name != account+"-1" {
+			t.Errorf("droplets of %s = %+v", account, d)
+		}
+	}
+
+	rates := pool.Rates()
+	if rates["team-a"].Remaining != 100 || rates["team-b"].Remaining != 200 {
+		t.Errorf("Rates() = %+v", rates)
+	}
+}
+
+func TestPool_GetBalances_partialFailure(t *testing.T) {
+	pool, closeAll := newTestPool(t, map[string]int{"team-a": 100, "broken": 200})
+	defer closeAll()
+
+	balances, err := pool.GetBalances(ctx)
+
+	var poolErr *PoolError
+	if !errors.As(err, &poolErr) {
+		t.Fatalf("error = %v, expected a *PoolError", err)
+	}
+	if len(poolErr.Errors) != 1 || !errors.Is(poolErr.Errors["broken"], ErrServerError) {
+		t.Errorf("errors = %v", poolErr.Errors)
+	}
+	if !strings.Contains(err.Error(), "broken:") {
+		t.Errorf("error = %q, expected it to name the account", err)
+	}
+
+	if len(balances) != 1 || balances["team-a"].AccountBalance != "100.00" {
+		t.Errorf("balances = %+v", balances)
+	}
+}
+
+func TestPool_Do(t *testing.T) {
+	pool, closeAll := newTestPool(t, map[string]int{"team-a": 1, "team-b": 2, "team-c": 3})
+	defer closeAll()
+
+	results := pool.Do(ctx, func(ctx context.Context, account string, c *Client) (interface{}, *Response, error) {
+		if account == "team-c" {
+			return nil, nil, errors.New("skipped")
+		}
+		droplets, resp, err := c.Droplets.List(ctx, nil)
+		return len(droplets), resp, err
+	})
+
+	if len(results) != 3 {
+		t.Fatalf("got %d results, expected 3", len(results))
+	}
+	if r := results["team-a"]; r.Err != nil || r.Value != 2 || r.Response.Rate.Remaining != 1 {
+		t.Errorf("team-a result = %+v", r)
+	}
+	if r := results["team-c"]; r.Err == nil || r.Err.Error() != "skipped" {
+		t.Errorf("team-c result = %+v", r)
+	}
+
+	var poolErr *PoolError
+	if !errors.As(results.Err(), &poolErr) || len(poolErr.Errors) != 1 {
+		t.Errorf("Err() = %v", results.Err())
+	}
+}
@@ -0,0 +1,128 @@
+package godo
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// RateLimiter paces requests using the rate limit reported by the API. While
+// the remaining budget is above its threshold requests are sent immediately.
+// Once it drops below the threshold, the remaining requests are spread evenly
+// over the time left until the limit resets, and requests block until then
+// when the budget is exhausted.
+//
+// A RateLimiter is safe for concurrent use and may be shared by several
+// clients using the same token.
+type RateLimiter struct {
+	threshold int
+	onLow     func(Rate)
+
+	mu   sync.Mutex
+	rate Rate
+	low  bool
+	next time.Time
+}
+
+// NewRateLimiter returns a RateLimiter that starts pacing requests once fewer
+// than threshold requests remain. A threshold of zero uses a tenth of the
+// reported limit. If onLow is not nil, it is called each time the remaining
+// budget drops below the threshold.
+func NewRateLimiter(threshold int, onLow func(Rate)) *RateLimiter {
+	return &RateLimiter{threshold: threshold, onLow: onLow}
+}
+
+// SetRateLimiter is a client option for pacing requests with the given
+// RateLimiter.
+func SetRateLimiter(l *RateLimiter) ClientOpt {
+	return func(c *Client) error {
+		if l == nil {
+			return NewArgError("l", "cannot be nil")
+		}
+
+		c.rateLimiter = l
+		return nil
+	}
+}
+
+func (l *RateLimiter) thresholdFor(r Rate) int {
+	if l.threshold > 0 {
+		return l.threshold
+	}
+	return r.Limit / 10
+}
+
+// delay reserves a slot for the next request and returns how long the caller
+// must wait before sending it.
+func (l *RateLimiter) delay(now time.Time) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	r := l.rate
+	if r.Limit == 0 || r.Reset.IsZero() || !now.Before(r.Reset.Time) {
+		return 0
+	}
+	if r.Remaining > 0 && r.Remaining >= l.thresholdFor(r) {
+		return 0
+	}
+
+	slot := now
+	if l.next.After(slot) {
+		slot = l.next
+	}
+
+	if r.Remaining <= 0 {
+		// The budget is exhausted; nothing can be sent before the reset.
+		if r.Reset.After(slot) {
+			slot = r.Reset.Time
+		}
+		l.next = slot
+		return slot.Sub(now)
+	}
+
+	if slot.Before(r.Reset.Time) {
+		l.next = slot.Add(r.Reset.Sub(slot) / time.Duration(r.Remaining))
+	}
+	l.rate.Remaining--
+	return slot.Sub(now)
+}
+
+// Wait blocks until the next request may be sent or ctx is done.
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	d := l.delay(time.Now())
+	if d <= 0 {
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// Update records the rate limit returned with the latest response. Responses
+// without rate limit headers are ignored.
+func (l *RateLimiter) Update(r Rate) {
+	if r.Limit == 0 {
+		return
+	}
+
+	l.mu.Lock()
+	wasLow := l.low
+	l.rate = r
+	l.low = r.Remaining < l.thresholdFor(r)
+	if !l.low {
+		l.next = time.Time{}
+	}
+	notify := l.low && !wasLow && l.onLow != nil
+	l.mu.Unlock()
+
+	if notify {
+		l.onLow(r)
+	}
+}
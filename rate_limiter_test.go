@@ -0,0 +1,127 @@
+package godo
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestRateLimiter_delay(t *testing.T) {
+	now := time.Now()
+	reset := Timestamp{now.Add(10 * time.Second)}
+
+	cases := []struct {
+		name     string
+		rate     Rate
+		expected []time.Duration
+	}{
+		{
+			name:     "unknown rate",
+			rate:     Rate{},
+			expected: []time.Duration{0, 0},
+		},
+		{
+			name:     "above threshold",
+			rate:     Rate{Limit: 100, Remaining: 50, Reset: reset},
+			expected: []time.Duration{0, 0},
+		},
+		{
+			name:     "below threshold",
+			rate:     Rate{Limit: 100, Remaining: 5, Reset: reset},
+			expected: []time.Duration{0, 2 * time.Second, 4 * time.Second},
+		},
+		{
+			name:     "exhausted",
+			rate:     Rate{Limit: 100, Remaining: 0, Reset: reset},
+			expected: []time.Duration{10 * time.Second, 10 * time.Second},
+		},
+		{
+			name:     "reset passed",
+			rate:     Rate{Limit: 100, Remaining: 0, Reset: Timestamp{now.Add(-time.Second)}},
+			expected: []time.Duration{0},
+		},
+	}
+
+	for _, c := range cases {
+		l := NewRateLimiter(0, nil)
+		l.Update(c.rate)
+
+		for i, expected := range c.expected {
+			if got := l.delay(now); got != expected {
+				t.Errorf("%s: delay #%d = %v, expected %v", c.name, i+1, got, expected)
+			}
+		}
+	}
+}
+
+func TestRateLimiter_onLow(t *testing.T) {
+	var calls []Rate
+	l := NewRateLimiter(10, func(r Rate) {
+		calls = append(calls, r)
+	})
+
+	reset := Timestamp{time.Now().Add(time.Minute)}
+	for _, remaining := range []int{20, 11, 10, 9, 8, 50, 3} {
+		l.Update(Rate{Limit: 100, Remaining: remaining, Reset: reset})
+	}
+
+	if len(calls) != 2 {
+		t.Fatalf("onLow calls = %d, expected 2", len(calls))
+	}
+	if calls[0].Remaining != 9 || calls[1].Remaining != 3 {
+		t.Errorf("onLow remaining = %d, %d, expected 9, 3", calls[0].Remaining, calls[1].Remaining)
+	}
+}
+
+func TestRateLimiter_waitContext(t *testing.T) {
+	l := NewRateLimiter(0, nil)
+	l.Update(Rate{Limit: 100, Remaining: 0, Reset: Timestamp{time.Now().Add(time.Hour)}})
+
+	cctx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+
+	if err := l.Wait(cctx); err != context.DeadlineExceeded {
+		t.Errorf("Wait() error = %v, expected %v", err, context.DeadlineExceeded)
+	}
+}
+
+func TestDo_rateLimiter(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var low Rate
+	if err := SetRateLimiter(NewRateLimiter(10, func(r Rate) { low = r }))(client); err != nil {
+		t.Fatalf("SetRateLimiter(): %v", err)
+	}
+
+	reset := time.Now().Add(time.Hour).Unix()
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add(headerRateLimit, "5000")
+		w.Header().Add(headerRateRemaining, "0")
+		w.Header().Add(headerRateReset, strconv.FormatInt(reset, 10))
+	})
+
+	req, _ := client.NewRequest(ctx, http.MethodGet, "/", nil)
+	if _, err := client.Do(ctx, req, nil); err != nil {
+		t.Fatalf("Do(): %v", err)
+	}
+	if low.Limit != 5000 || low.Reset.Unix() != reset {
+		t.Errorf("onLow rate = %v, expected limit 5000 and reset %d", low, reset)
+	}
+
+	cctx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+
+	req, _ = client.NewRequest(cctx, http.MethodGet, "/", nil)
+	if _, err := client.Do(cctx, req, nil); err != context.DeadlineExceeded {
+		t.Errorf("Do() error = %v, expected %v", err, context.DeadlineExceeded)
+	}
+}
+
+func TestSetRateLimiter_nil(t *testing.T) {
+	if _, err := New(nil, SetRateLimiter(nil)); err == nil {
+		t.Error("expected error")
+	}
+}
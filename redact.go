@@ -0,0 +1,117 @@
+package godo
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/url"
+	"strings"
+)
+
+const redacted = "REDACTED"
+
+// redactedKeys are the JSON keys holding secrets in request and response
+// bodies, such as DatabaseUser.Password, CertificateRequest.PrivateKey,
+// KubernetesClusterCredentials.Token, the auths of DockerCredentials or
+// DropletCreateRequest.UserData.
+var redactedKeys = []string{
+	"password",
+	"private_key",
+	"token",
+	"access_token",
+	"refresh_token",
+	"client_key_data",
+	"client_certificate_data",
+	"auth",
+	"auths",
+	"user_data",
+}
+
+// redactedPathSuffixes identifies endpoints returning bodies that are secret
+// as a whole, such as KubernetesClusterConfig.KubeconfigYAML.
+var redactedPathSuffixes = []string{
+	"/kubeconfig",
+}
+
+// Redactor removes secrets from the bodies of API requests and responses, as
+// the request logging, the dry-run mode and the godotest recorder do.
+type Redactor struct {
+	keys map[string]bool
+}
+
+// NewRedactor returns a Redactor of the values of known secrets, such as
+// passwords, tokens and user data, and of the given JSON keys.
+func NewRedactor(keys ...string) *Redactor {
+	r := &Redactor{keys: make(map[string]bool)}
+	for _, k := range append(redactedKeys, keys...) {
+		r.keys[strings.ToLower(k)] = true
+	}
+	return r
+}
+
+// Body returns the body of a request to or a response from the API path
+// with secrets redacted, along with whether it could be. Bodies that are
+// secret as a whole, such as kubeconfigs, are replaced by "REDACTED", and
+// bodies that are not JSON cannot be redacted. JSON bodies are re-encoded
+// with sorted keys, keeping numbers as they were.
+func (r *Redactor) Body(path string, data []byte) ([]byte, bool) {
+	if len(bytes.TrimSpace(data)) == 0 {
+		return nil, true
+	}
+	for _, suffix := range redactedPathSuffixes {
+		if strings.HasSuffix(path, suffix) {
+			return []byte(redacted), true
+		}
+	}
+
+	var v interface{}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if err := dec.Decode(&v); err != nil {
+		return nil, false
+	}
+
+	out, err := json.Marshal(r.value(v))
+	if err != nil {
+		return nil, false
+	}
+	return out, true
+}
+
+func (r *Redactor) value(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, val := range v {
+			switch {
+			case r.keys[strings.ToLower(k)]:
+				v[k] = redacted
+			case strings.EqualFold(k, "uri"):
+				v[k] = redactURI(val)
+			default:
+				v[k] = r.value(val)
+			}
+		}
+	case []interface{}:
+		for i, val := range v {
+			v[i] = r.value(val)
+		}
+	}
+	return v
+}
+
+// redactURI removes the password from connection URIs such as
+// DatabaseConnection.URI.
+func redactURI(v interface{}) interface{} {
+	s, ok := v.(string)
+	if !ok {
+		return v
+	}
+
+	u, err := url.Parse(s)
+	if err != nil || u.User == nil {
+		return v
+	}
+	if _, ok := u.User.Password(); ok {
+		u.User = url.UserPassword(u.User.Username(), redacted)
+	}
+	return u.String()
+}
@@ -5,6 +5,8 @@ import (
 	"net/http"
 )
 
+const regionsBasePath = "v2/regions"
+
 // RegionsService is an interface for interfacing with the regions
 // endpoints of the DigitalOcean API
 // See: https://developers.digitalocean.com/documentation/v2#regions
@@ -41,7 +43,7 @@ func (r Region) String() string {
 
 // List all regions
 func (s *RegionsServiceOp) List(ctx context.Context, opt *ListOptions) ([]Region, *Response, error) {
-	path := "v2/regions"
+	path := regionsBasePath
 	path, err := addOptions(path, opt)
 	if err != nil {
 		return nil, nil, err
@@ -0,0 +1,76 @@
+package godo
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// RequestOption customizes the requests made with a context returned by
+// WithRequestOptions.
+type RequestOption func(*requestOptions)
+
+type requestOptions struct {
+	header  http.Header
+	timeout time.Duration
+	baseURL string
+}
+
+type requestOptionsKey struct{}
+
+// WithRequestOptions returns a context applying opts to the requests made
+// with it by any service method, in addition to the options of ctx:
+//
+//	ctx := godo.WithRequestOptions(ctx, godo.WithRequestTimeout(10*time.Second))
+//	droplet, _, err := client.Droplets.Get(ctx, id)
+func WithRequestOptions(ctx context.Context, opts ...RequestOption) context.Context {
+	o := requestOptions{header: make(http.Header)}
+	if parent, ok := ctx.Value(requestOptionsKey{}).(*requestOptions); ok {
+		o = *parent
+		o.header = parent.header.Clone()
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return context.WithValue(ctx, requestOptionsKey{}, &o)
+}
+
+// WithRequestHeader sets a header of the requests.
+func WithRequestHeader(key, value string) RequestOption {
+	return func(o *requestOptions) {
+		o.header.Set(key, value)
+	}
+}
+
+// WithRequestTimeout bounds the time taken by each request, including its
+// retries.
+func WithRequestTimeout(d time.Duration) RequestOption {
+	return func(o *requestOptions) {
+		o.timeout = d
+	}
+}
+
+// WithRequestBaseURL sends the requests to another base URL than the one of
+// the client, as set with SetBaseURL.
+func WithRequestBaseURL(baseURL string) RequestOption {
+	return func(o *requestOptions) {
+		o.baseURL = baseURL
+	}
+}
+
+func requestOptionsFrom(ctx context.Context) *requestOptions {
+	if ctx == nil {
+		return nil
+	}
+	o, _ := ctx.Value(requestOptionsKey{}).(*requestOptions)
+	return o
+}
+
+// baseURL returns the base URL of the requests made with ctx.
+func (c *Client) baseURL(ctx context.Context) (*url.URL, error) {
+	if o := requestOptionsFrom(ctx); o != nil && o.baseURL != "" {
+		return url.Parse(o.baseURL)
+	}
+	return c.BaseURL, nil
+}
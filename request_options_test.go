@@ -0,0 +1,109 @@
+package godo
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestWithRequestOptions_header(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v2/droplets/1", func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("X-Trace"); got != "abc" {
+			t.Errorf("X-Trace = %q, expected abc", got)
+		}
+		if got := r.Header.Get("X-Team"); got != "platform" {
+			t.Errorf("X-Team = %q, expected platform", got)
+		}
+		if got := r.Header.Get("Accept"); got != mediaType {
+			t.Errorf("Accept = %q, expected the default header to be kept", got)
+		}
+		fmt.Fprint(w, `{"droplet": {"id": 1}}`)
+	})
+
+	reqCtx := WithRequestOptions(ctx, WithRequestHeader("X-Trace", "abc"))
+	reqCtx = WithRequestOptions(reqCtx, WithRequestHeader("X-Team", "platform"))
+	if _, _, err := client.Droplets.Get(reqCtx, 1); err != nil {
+		t.Fatalf("Droplets.Get() error = %v", err)
+	}
+}
+
+func TestWithRequestOptions_doesNotModifyParent(t *testing.T) {
+	parent := WithRequestOptions(ctx, WithRequestHeader("X-A", "1"))
+	WithRequestOptions(parent, WithRequestHeader("X-A", "2"), WithRequestTimeout(time.Second))
+
+	o := requestOptionsFrom(parent)
+	if o.header.Get("X-A") != "1" || o.timeout != 0 {
+		t.Errorf("parent options = %+v", o)
+	}
+}
+
+func TestWithRequestOptions_timeout(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v2/account", func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(time.Second):
+		}
+	})
+
+	start := time.Now()
+	reqCtx := WithRequestOptions(ctx, WithRequestTimeout(20*time.Millisecond))
+	_, _, err := client.Account.Get(reqCtx)
+	if err == nil {
+		t.Fatal("expected a timeout error")
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("request took %v, expected the timeout to cancel it", elapsed)
+	}
+	if reqCtx.Err() != nil {
+		t.Error("the timeout must not cancel the context of the caller")
+	}
+}
+
+func TestWithRequestOptions_baseURL(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v2/account", func(w http.ResponseWriter, r *http.Request) {
+		t.Error("request sent to the base URL of the client")
+	})
+
+	other := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/staging/v2/account" {
+			t.Errorf("path = %q", r.URL.Path)
+		}
+		fmt.Fprint(w, `{"account": {"droplet_limit": 5}}`)
+	}))
+	defer other.Close()
+
+	reqCtx := WithRequestOptions(ctx, WithRequestBaseURL(other.URL+"/staging/"))
+	account, _, err := client.Account.Get(reqCtx)
+	if err != nil {
+		t.Fatalf("Account.Get() error = %v", err)
+	}
+	if account.DropletLimit != 5 {
+		t.Errorf("account = %+v", account)
+	}
+
+	_, err = client.NewRequest(WithRequestOptions(ctx, WithRequestBaseURL(":")), http.MethodGet, "v2/account", nil)
+	testURLParseError(t, err)
+}
+
+func TestWithRequestOptions_none(t *testing.T) {
+	c := NewClient(nil)
+	req, err := c.NewRequest(context.Background(), http.MethodGet, "v2/account", nil)
+	if err != nil {
+		t.Fatalf("NewRequest() error = %v", err)
+	}
+	if got := req.URL.String(); got != defaultBaseURL+"v2/account" {
+		t.Errorf("URL = %q", got)
+	}
+}
@@ -0,0 +1,174 @@
+package godo
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"net/http"
+	"time"
+)
+
+const (
+	defaultRetryMaxAttempts = 4
+	defaultRetryWaitMin     = 500 * time.Millisecond
+	defaultRetryWaitMax     = 30 * time.Second
+)
+
+// RetryPolicy configures how the client retries failed requests. Retries are
+// disabled unless a policy is installed with the SetRetryPolicy client option.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts made for a request,
+	// including the first one. Defaults to 4.
+	MaxAttempts int
+
+	// WaitMin is the base delay used for the exponential backoff between
+	// attempts. Defaults to 500ms.
+	WaitMin time.Duration
+
+	// WaitMax caps the exponential backoff between attempts. Defaults to 30s.
+	// It does not cap the wait for a rate limit reset after a 429 response.
+	WaitMax time.Duration
+
+	// Retryable reports whether a request should be retried given the
+	// response or transport error of the last attempt. Defaults to
+	// DefaultRetryable.
+	Retryable func(*http.Response, error) bool
+}
+
+// SetRetryPolicy is a client option for retrying requests that fail with a
+// transport error, a 429 or a 5xx response. Idempotent requests (GET, HEAD,
+// OPTIONS, PUT and DELETE) are retried on any retryable failure. Other
+// requests are only retried after a 429, which means the API did not process
+// them.
+func SetRetryPolicy(p RetryPolicy) ClientOpt {
+	return func(c *Client) error {
+		if p.MaxAttempts == 0 {
+			p.MaxAttempts = defaultRetryMaxAttempts
+		}
+		if p.WaitMin <= 0 {
+			p.WaitMin = defaultRetryWaitMin
+		}
+		if p.WaitMax <= 0 {
+			p.WaitMax = defaultRetryWaitMax
+		}
+		if p.WaitMax < p.WaitMin {
+			return NewArgError("WaitMax", "cannot be less than WaitMin")
+		}
+		if p.Retryable == nil {
+			p.Retryable = DefaultRetryable
+		}
+
+		c.retryPolicy = &p
+		return nil
+	}
+}
+
+// DefaultRetryable reports whether a request should be retried. Timeouts,
+// connection errors, 429 Too Many Requests and 5xx responses are retryable.
+func DefaultRetryable(resp *http.Response, err error) bool {
+	if err != nil {
+		return isRetryableTransportError(err)
+	}
+
+	return isRetryableStatus(resp.StatusCode)
+}
+
+// shouldRetry reports whether another attempt should be made after the given
+// attempt number finished with resp or err.
+func (p *RetryPolicy) shouldRetry(ctx context.Context, req *http.Request, resp *http.Response, err error, attempt int) bool {
+	if attempt >= p.MaxAttempts || ctx.Err() != nil {
+		return false
+	}
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return false
+	}
+	if !p.Retryable(resp, err) {
+		return false
+	}
+	if resp != nil && resp.StatusCode == http.StatusTooManyRequests {
+		return true
+	}
+
+	return isIdempotent(req.Method)
+}
+
+// backoff returns how long to wait before the attempt following the given
+// one. After a 429 response the wait lasts at least until the rate limit
+// resets.
+func (p *RetryPolicy) backoff(resp *http.Response, attempt int) time.Duration {
+	wait := p.WaitMin << uint(attempt-1)
+	if wait <= 0 || wait > p.WaitMax {
+		wait = p.WaitMax
+	}
+
+	// Full jitter over the upper half of the window keeps concurrent clients
+	// from retrying in lockstep.
+	wait = wait/2 + time.Duration(rand.Int63n(int64(wait/2)+1))
+
+	if resp != nil && resp.StatusCode == http.StatusTooManyRequests {
+		rate := newResponse(resp).Rate
+		if !rate.Reset.IsZero() {
+			if untilReset := time.Until(rate.Reset.Time); untilReset > wait {
+				wait = untilReset
+			}
+		}
+	}
+
+	return wait
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// rewindBody resets the body of req so that it can be sent again.
+func rewindBody(req *http.Request) error {
+	if req.GetBody == nil {
+		return nil
+	}
+
+	body, err := req.GetBody()
+	if err != nil {
+		return err
+	}
+	req.Body = body
+	return nil
+}
+
+// send submits req through the client's middleware, retrying it according to
+// the client's retry policy. It returns the last response along with the
+// number of attempts made.
+func (c *Client) send(ctx context.Context, req *http.Request) (*http.Response, int, error) {
+	handler := c.handler()
+	for attempt := 1; ; attempt++ {
+		resp, err := handler(ctx, req)
+
+		p := c.retryPolicy
+		if p == nil || !p.shouldRetry(ctx, req, resp, err, attempt) {
+			return resp, attempt, err
+		}
+
+		wait := p.backoff(resp, attempt)
+		if resp != nil {
+			_, _ = io.Copy(ioutil.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		if err := rewindBody(req); err != nil {
+			return nil, attempt, err
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, attempt, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
@@ -0,0 +1,278 @@
+package godo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func setupRetry(t *testing.T, p RetryPolicy) {
+	setup()
+
+	if p.WaitMin == 0 {
+		p.WaitMin = time.Millisecond
+	}
+	if p.WaitMax == 0 {
+		p.WaitMax = 5 * time.Millisecond
+	}
+	if err := SetRetryPolicy(p)(client); err != nil {
+		t.Fatalf("SetRetryPolicy(): %v", err)
+	}
+}
+
+func TestDo_retryServerError(t *testing.T) {
+	setupRetry(t, RetryPolicy{MaxAttempts: 3})
+	defer teardown()
+
+	var calls int
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls < 3 {
+			http.Error(w, `{"message":"unavailable"}`, http.StatusServiceUnavailable)
+			return
+		}
+		fmt.Fprint(w, `{"A":"a"}`)
+	})
+
+	req, _ := client.NewRequest(ctx, http.MethodGet, "/", nil)
+	body := new(struct{ A string })
+	resp, err := client.Do(ctx, req, body)
+	if err != nil {
+		t.Fatalf("Do(): %v", err)
+	}
+
+	if calls != 3 {
+		t.Errorf("server calls = %d, expected 3", calls)
+	}
+	if resp.Attempts != 3 {
+		t.Errorf("Response.Attempts = %d, expected 3", resp.Attempts)
+	}
+	if body.A != "a" {
+		t.Errorf("Response body = %v, expected a", body.A)
+	}
+}
+
+func TestDo_retryExhausted(t *testing.T) {
+	setupRetry(t, RetryPolicy{MaxAttempts: 2})
+	defer teardown()
+
+	var calls int
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		http.Error(w, `{"message":"broken"}`, http.StatusInternalServerError)
+	})
+
+	req, _ := client.NewRequest(ctx, http.MethodDelete, "/", nil)
+	resp, err := client.Do(ctx, req, nil)
+	if err == nil {
+		t.Fatal("expected error")
+	}
+
+	if calls != 2 {
+		t.Errorf("server calls = %d, expected 2", calls)
+	}
+	if resp.Attempts != 2 {
+		t.Errorf("Response.Attempts = %d, expected 2", resp.Attempts)
+	}
+	if resp.StatusCode != http.StatusInternalServerError {
+		t.Errorf("Response.StatusCode = %d, expected 500", resp.StatusCode)
+	}
+}
+
+func TestDo_retryNonIdempotent(t *testing.T) {
+	setupRetry(t, RetryPolicy{MaxAttempts: 3})
+	defer teardown()
+
+	var calls int
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		http.Error(w, `{"message":"broken"}`, http.StatusInternalServerError)
+	})
+
+	req, _ := client.NewRequest(ctx, http.MethodPost, "/", &DropletCreateRequest{Name: "l"})
+	_, err := client.Do(ctx, req, nil)
+	if err == nil {
+		t.Fatal("expected error")
+	}
+
+	if calls != 1 {
+		t.Errorf("server calls = %d, expected 1", calls)
+	}
+}
+
+func TestDo_retryRateLimitedRewindsBody(t *testing.T) {
+	setupRetry(t, RetryPolicy{MaxAttempts: 3})
+	defer teardown()
+
+	var bodies []string
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		b, _ := ioutil.ReadAll(r.Body)
+		bodies = append(bodies, string(b))
+		if len(bodies) == 1 {
+			w.Header().Add(headerRateLimit, "5000")
+			w.Header().Add(headerRateRemaining, "0")
+			w.Header().Add(headerRateReset, strconv.FormatInt(time.Now().Add(-time.Second).Unix(), 10))
+			http.Error(w, `{"message":"too many requests"}`, http.StatusTooManyRequests)
+			return
+		}
+		fmt.Fprint(w, `{}`)
+	})
+
+	req, _ := client.NewRequest(ctx, http.MethodPost, "/", &DropletCreateRequest{Name: "l"})
+	resp, err := client.Do(ctx, req, nil)
+	if err != nil {
+		t.Fatalf("Do(): %v", err)
+	}
+
+	if len(bodies) != 2 {
+		t.Fatalf("server calls = %d, expected 2", len(bodies))
+	}
+	if bodies[0] == "" || bodies[0] != bodies[1] {
+		t.Errorf("retried body = %q, expected %q", bodies[1], bodies[0])
+	}
+	if resp.Attempts != 2 {
+		t.Errorf("Response.Attempts = %d, expected 2", resp.Attempts)
+	}
+}
+
+func TestDo_retryContextCanceled(t *testing.T) {
+	setupRetry(t, RetryPolicy{MaxAttempts: 3, WaitMin: time.Hour, WaitMax: time.Hour})
+	defer teardown()
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"message":"unavailable"}`, http.StatusServiceUnavailable)
+	})
+
+	cctx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+
+	req, _ := client.NewRequest(cctx, http.MethodGet, "/", nil)
+	_, err := client.Do(cctx, req, nil)
+	if err != context.DeadlineExceeded {
+		t.Errorf("Do() error = %v, expected %v", err, context.DeadlineExceeded)
+	}
+}
+
+func TestDo_retryTransportErrors(t *testing.T) {
+	tlsServer := httptest.NewUnstartedServer(http.NotFoundHandler())
+	tlsServer.Config.ErrorLog = log.New(ioutil.Discard, "", 0) // the handshake fails
+	tlsServer.StartTLS()
+	defer tlsServer.Close()
+
+	redirectServer := httptest.NewServer(http.RedirectHandler("/elsewhere", http.StatusFound))
+	defer redirectServer.Close()
+	noRedirects := &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error {
+		return errors.New("redirects are not allowed")
+	}}
+
+	closingServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, _, err := w.(http.Hijacker).Hijack()
+		if err != nil {
+			t.Errorf("Hijack(): %v", err)
+			return
+		}
+		conn.Close()
+	}))
+	defer closingServer.Close()
+
+	closedServer := httptest.NewServer(http.NotFoundHandler())
+	closedServer.Close()
+
+	cases := []struct {
+		name     string
+		client   *http.Client
+		baseURL  string
+		attempts int
+	}{
+		{"unsupported scheme", nil, "ftp://example.com/", 1},
+		{"unknown authority", nil, tlsServer.URL, 1},
+		{"redirect refused", noRedirects, redirectServer.URL, 1},
+		{"connection closed", nil, closingServer.URL, 3},
+		{"connection refused", nil, closedServer.URL, 3},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			client, err := New(c.client, SetBaseURL(c.baseURL), SetRetryPolicy(RetryPolicy{
+				MaxAttempts: 3,
+				WaitMin:     time.Millisecond,
+				WaitMax:     time.Millisecond,
+			}))
+			if err != nil {
+				t.Fatalf("New(): %v", err)
+			}
+
+			var attempts int
+			client.Use(func(next RequestHandler) RequestHandler {
+				return func(ctx context.Context, req *http.Request) (*http.Response, error) {
+					attempts++
+					return next(ctx, req)
+				}
+			})
+
+			_, _, err = client.Regions.List(ctx, nil)
+			if err == nil {
+				t.Fatal("Regions.List(): expected an error")
+			}
+			if attempts != c.attempts {
+				t.Errorf("attempts = %d, expected %d (error %v)", attempts, c.attempts, err)
+			}
+			if retryable := c.attempts > 1; IsRetryable(err) != retryable {
+				t.Errorf("IsRetryable(%v) = %v, expected %v", err, !retryable, retryable)
+			}
+		})
+	}
+}
+
+func TestDo_noRetryByDefault(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var calls int
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		http.Error(w, `{"message":"unavailable"}`, http.StatusServiceUnavailable)
+	})
+
+	req, _ := client.NewRequest(ctx, http.MethodGet, "/", nil)
+	resp, _ := client.Do(ctx, req, nil)
+
+	if calls != 1 {
+		t.Errorf("server calls = %d, expected 1", calls)
+	}
+	if resp.Attempts != 1 {
+		t.Errorf("Response.Attempts = %d, expected 1", resp.Attempts)
+	}
+}
+
+func TestRetryPolicy_backoff(t *testing.T) {
+	p := &RetryPolicy{WaitMin: 100 * time.Millisecond, WaitMax: time.Second}
+
+	for attempt, max := range []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 400 * time.Millisecond, 800 * time.Millisecond, time.Second, time.Second} {
+		wait := p.backoff(nil, attempt+1)
+		if wait < max/2 || wait > max {
+			t.Errorf("backoff(%d) = %v, expected between %v and %v", attempt+1, wait, max/2, max)
+		}
+	}
+
+	reset := time.Now().Add(time.Minute)
+	resp := &http.Response{StatusCode: http.StatusTooManyRequests, Header: http.Header{}}
+	resp.Header.Set(headerRateReset, strconv.FormatInt(reset.Unix(), 10))
+	if wait := p.backoff(resp, 1); wait < 58*time.Second {
+		t.Errorf("backoff() = %v, expected to wait until rate limit reset", wait)
+	}
+}
+
+func TestSetRetryPolicy_invalid(t *testing.T) {
+	_, err := New(nil, SetRetryPolicy(RetryPolicy{WaitMin: time.Second, WaitMax: time.Millisecond}))
+	if err == nil {
+		t.Error("expected error")
+	}
+}
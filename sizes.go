@@ -5,6 +5,8 @@ import (
 	"net/http"
 )
 
+const sizesBasePath = "v2/sizes"
+
 // SizesService is an interface for interfacing with the size
 // endpoints of the DigitalOcean API
 // See: https://developers.digitalocean.com/documentation/v2#sizes
@@ -45,7 +47,7 @@ type sizesRoot struct {
 
 // List all images
 func (s *SizesServiceOp) List(ctx context.Context, opt *ListOptions) ([]Size, *Response, error) {
-	path := "v2/sizes"
+	path := sizesBasePath
 	path, err := addOptions(path, opt)
 	if err != nil {
 		return nil, nil, err
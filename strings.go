@@ -86,6 +86,9 @@ func stringifyStruct(w io.Writer, v reflect.Value) {
 		if fv.Kind() == reflect.Slice && fv.IsNil() {
 			continue
 		}
+		if fv.Kind() == reflect.Map && fv.IsNil() {
+			continue
+		}
 
 		if sep {
 			_, _ = w.Write([]byte(", "))
@@ -0,0 +1,97 @@
+package godo
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+)
+
+// Tracer starts spans for API requests. It allows tracing godo calls with
+// any tracing library, for instance by adapting an OpenTelemetry or
+// OpenTracing tracer.
+type Tracer interface {
+	// StartSpan starts a span with the given name as a child of the span in
+	// ctx, if any, and returns a context holding the new span. The returned
+	// context is used to send the request, so spans started by the HTTP
+	// transport nest under it.
+	StartSpan(ctx context.Context, name string) (context.Context, Span)
+}
+
+// Span is a span started by a Tracer.
+type Span interface {
+	// SetTag annotates the span.
+	SetTag(key string, value interface{})
+
+	// Finish ends the span. err is the error returned to the caller, if any.
+	Finish(err error)
+}
+
+// Tags set on the spans of API requests.
+const (
+	SpanTagMethod      = "http.method"
+	SpanTagPath        = "http.path"
+	SpanTagStatusCode  = "http.status_code"
+	SpanTagResourceIDs = "godo.resource_ids"
+	SpanTagAttempts    = "godo.attempts"
+	SpanTagRequestID   = "godo.request_id"
+)
+
+// SetTracer is a client option for tracing every call to the API with t.
+// Spans are named after the service method, such as "Droplets.Create" (see
+// RequestOperation), and tagged with the resource IDs found in the path, the
+// HTTP status and the request ID returned by the API.
+func SetTracer(t Tracer) ClientOpt {
+	return func(c *Client) error {
+		if t == nil {
+			return NewArgError("t", "cannot be nil")
+		}
+
+		c.tracer = t
+		return nil
+	}
+}
+
+// startSpan starts the span of a request sent by Client.Do.
+func (c *Client) startSpan(ctx context.Context, req *http.Request) (context.Context, Span) {
+	op := RequestOperation(req)
+
+	ctx, span := c.tracer.StartSpan(ctx, op.Name)
+	span.SetTag(SpanTagMethod, req.Method)
+	span.SetTag(SpanTagPath, req.URL.Path)
+	if len(op.ResourceIDs) > 0 {
+		span.SetTag(SpanTagResourceIDs, strings.Join(op.ResourceIDs, ","))
+	}
+	return ctx, span
+}
+
+// finishSpan annotates the span of a request with its outcome and ends it.
+func finishSpan(span Span, resp *Response, err error) {
+	var errResp *ErrorResponse
+	errors.As(err, &errResp)
+
+	var decodeErr *DecodeError
+	switch {
+	case resp != nil:
+	case errResp != nil:
+		resp = &Response{Response: errResp.Response}
+	case errors.As(err, &decodeErr):
+		resp = &Response{Response: decodeErr.Response}
+	}
+	if resp != nil && resp.Response != nil {
+		span.SetTag(SpanTagStatusCode, resp.StatusCode)
+		if resp.Attempts > 0 {
+			span.SetTag(SpanTagAttempts, resp.Attempts)
+		}
+
+		requestID := resp.Header.Get(headerRequestID)
+		if requestID == "" && errResp != nil {
+			requestID = errResp.RequestID
+		}
+		if requestID != "" {
+			span.SetTag(SpanTagRequestID, requestID)
+		}
+	}
+
+	span.Finish(err)
+}
@@ -0,0 +1,126 @@
+package godo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+)
+
+type testSpanKey struct{}
+
+type testSpan struct {
+	name     string
+	parent   *testSpan
+	tags     map[string]interface{}
+	finished bool
+	err      error
+}
+
+func (s *testSpan) SetTag(key string, value interface{}) { s.tags[key] = value }
+
+func (s *testSpan) Finish(err error) {
+	s.finished = true
+	s.err = err
+}
+
+type testTracer struct {
+	mu    sync.Mutex
+	spans []*testSpan
+}
+
+func (t *testTracer) StartSpan(ctx context.Context, name string) (context.Context, Span) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	parent, _ := ctx.Value(testSpanKey{}).(*testSpan)
+	span := &testSpan{name: name, parent: parent, tags: make(map[string]interface{})}
+	t.spans = append(t.spans, span)
+	return context.WithValue(ctx, testSpanKey{}, span), span
+}
+
+func TestSetTracer(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var transportSpan *testSpan
+	client.Use(BeforeRequest(func(req *http.Request) error {
+		transportSpan, _ = req.Context().Value(testSpanKey{}).(*testSpan)
+		return nil
+	}))
+
+	mux.HandleFunc("/v2/kubernetes/clusters/k8s/node_pools/np", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(headerRequestID, "req-1")
+		fmt.Fprint(w, `{"node_pool":{"id":"np"}}`)
+	})
+
+	tracer := &testTracer{}
+	if err := SetTracer(tracer)(client); err != nil {
+		t.Fatalf("SetTracer(): %v", err)
+	}
+
+	root := &testSpan{name: "root"}
+	parentCtx := context.WithValue(ctx, testSpanKey{}, root)
+	if _, _, err := client.Kubernetes.GetNodePool(parentCtx, "k8s", "np"); err != nil {
+		t.Fatalf("Kubernetes.GetNodePool(): %v", err)
+	}
+
+	if len(tracer.spans) != 1 {
+		t.Fatalf("spans = %d, expected 1", len(tracer.spans))
+	}
+	span := tracer.spans[0]
+	if span.name != "Kubernetes.GetNodePool" {
+		t.Errorf("span name = %q, expected %q", span.name, "Kubernetes.GetNodePool")
+	}
+	if span.parent != root {
+		t.Errorf("span parent = %v, expected root span", span.parent)
+	}
+	if transportSpan != span {
+		t.Errorf("request context span = %v, expected the call span", transportSpan)
+	}
+	if !span.finished || span.err != nil {
+		t.Errorf("span finished = %v with %v, expected finished without error", span.finished, span.err)
+	}
+
+	expected := map[string]interface{}{
+		SpanTagMethod:      http.MethodGet,
+		SpanTagPath:        "/v2/kubernetes/clusters/k8s/node_pools/np",
+		SpanTagStatusCode:  http.StatusOK,
+		SpanTagResourceIDs: "k8s,np",
+		SpanTagAttempts:    1,
+		SpanTagRequestID:   "req-1",
+	}
+	for k, v := range expected {
+		if span.tags[k] != v {
+			t.Errorf("tag %s = %v, expected %v", k, span.tags[k], v)
+		}
+	}
+}
+
+func TestSetTracer_errorResponse(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v2/droplets/1", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"id":"not_found","message":"missing","request_id":"req-404"}`)
+	})
+
+	tracer := &testTracer{}
+	client.tracer = tracer
+
+	_, _, err := client.Droplets.Get(ctx, 1)
+	if err == nil {
+		t.Fatal("expected error")
+	}
+
+	span := tracer.spans[0]
+	if span.name != "Droplets.Get" || !errors.Is(span.err, ErrNotFound) {
+		t.Errorf("span = %s with %v, expected Droplets.Get with not found", span.name, span.err)
+	}
+	if span.tags[SpanTagStatusCode] != http.StatusNotFound || span.tags[SpanTagRequestID] != "req-404" {
+		t.Errorf("tags = %v, expected status and request ID from error", span.tags)
+	}
+}
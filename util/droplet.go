@@ -15,7 +15,13 @@ const (
 	activeFailure = 3
 )
 
-// WaitForActive waits for a droplet to become active
+// activeInterval is the delay between checks of an action in progress.
+var activeInterval = 5 * time.Second
+
+// WaitForActive waits for a droplet to become active. It returns a
+// *godo.ActionError if the action monitored by monitorURI errors, and the
+// error of ctx if it is done first. godo.WaitForAction waits for any action
+// with a configurable backoff.
 func WaitForActive(ctx context.Context, client *godo.Client, monitorURI string) error {
 	if len(monitorURI) == 0 {
 		return fmt.Errorf("create had no monitor uri")
@@ -27,10 +33,8 @@ func WaitForActive(ctx context.Context, client *godo.Client, monitorURI string)
 		action, _, err := client.DropletActions.GetByURI(ctx, monitorURI)
 
 		if err != nil {
-			select {
-			case <-ctx.Done():
-				return err
-			default:
+			if ctx.Err() != nil {
+				return ctx.Err()
 			}
 			if failCount <= activeFailure {
 				failCount++
@@ -42,12 +46,14 @@ func WaitForActive(ctx context.Context, client *godo.Client, monitorURI string)
 		switch action.Status {
 		case godo.ActionInProgress:
 			select {
-			case <-time.After(5 * time.Second):
+			case <-time.After(activeInterval):
 			case <-ctx.Done():
-				return err
+				return ctx.Err()
 			}
 		case godo.ActionCompleted:
 			completed = true
+		case godo.ActionErrored:
+			return &godo.ActionError{Action: action}
 		default:
 			return fmt.Errorf("unknown status: [%s]", action.Status)
 		}
@@ -2,6 +2,12 @@ package util
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
 
 	"golang.org/x/oauth2"
 
@@ -27,3 +33,107 @@ func ExampleWaitForActive() {
 		panic(err)
 	}
 }
+
+// actionServer answers the requests for action 1 with the statuses in turn,
+// failing with a 500 response for empty statuses, and repeats the last one.
+func actionServer(t *testing.T, statuses ...string) (*godo.Client, *int, func()) {
+	requests := new(int)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v2/actions/1" {
+			t.Errorf("unexpected request for %s", r.URL.Path)
+		}
+		status := statuses[len(statuses)-1]
+		if *requests < len(statuses) {
+			status = statuses[*requests]
+		}
+		*requests++
+
+		if status == "" {
+			w.WriteHeader(http.StatusInternalServerError)
+			fmt.Fprint(w, `{"id": "server_error", "message": "unavailable"}`)
+			return
+		}
+		fmt.Fprintf(w, `{"action": {"id": 1, "status": %q, "type": "create"}}`, status)
+	}))
+
+	client, err := godo.New(nil, godo.SetBaseURL(server.URL))
+	if err != nil {
+		t.Fatalf("New(): %v", err)
+	}
+	return client, requests, server.Close
+}
+
+func setActiveInterval(d time.Duration) func() {
+	old := activeInterval
+	activeInterval = d
+	return func() { activeInterval = old }
+}
+
+func TestWaitForActive(t *testing.T) {
+	defer setActiveInterval(time.Millisecond)()
+
+	client, requests, closeServer := actionServer(t, godo.ActionInProgress, "", godo.ActionInProgress, godo.ActionCompleted)
+	defer closeServer()
+
+	if err := WaitForActive(context.Background(), client, "https://api.digitalocean.com/v2/actions/1"); err != nil {
+		t.Fatalf("WaitForActive() error = %v", err)
+	}
+	if *requests != 4 {
+		t.Errorf("sent %d requests, expected 4", *requests)
+	}
+}
+
+func TestWaitForActive_errored(t *testing.T) {
+	client, _, closeServer := actionServer(t, godo.ActionErrored)
+	defer closeServer()
+
+	err := WaitForActive(context.Background(), client, "https://api.digitalocean.com/v2/actions/1")
+	var actionErr *godo.ActionError
+	if !errors.As(err, &actionErr) || actionErr.Action.ID != 1 {
+		t.Errorf("WaitForActive() error = %v, expected a *godo.ActionError", err)
+	}
+}
+
+func TestWaitForActive_failures(t *testing.T) {
+	client, requests, closeServer := actionServer(t, "", "", "", "", godo.ActionCompleted)
+	defer closeServer()
+
+	if err := WaitForActive(context.Background(), client, "https://api.digitalocean.com/v2/actions/1"); err != nil {
+		t.Fatalf("WaitForActive() error = %v after %d failures", err, activeFailure+1)
+	}
+	if *requests != activeFailure+2 {
+		t.Errorf("sent %d requests, expected %d", *requests, activeFailure+2)
+	}
+
+	client, requests, closeServer = actionServer(t, "")
+	defer closeServer()
+
+	err := WaitForActive(context.Background(), client, "https://api.digitalocean.com/v2/actions/1")
+	var errResp *godo.ErrorResponse
+	if !errors.As(err, &errResp) {
+		t.Errorf("WaitForActive() error = %v, expected a *godo.ErrorResponse", err)
+	}
+	if *requests != activeFailure+2 {
+		t.Errorf("sent %d requests, expected %d", *requests, activeFailure+2)
+	}
+}
+
+func TestWaitForActive_contextDone(t *testing.T) {
+	defer setActiveInterval(time.Hour)()
+
+	client, _, closeServer := actionServer(t, godo.ActionInProgress)
+	defer closeServer()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	if err := WaitForActive(ctx, client, "https://api.digitalocean.com/v2/actions/1"); err != context.DeadlineExceeded {
+		t.Errorf("WaitForActive() error = %v, expected %v", err, context.DeadlineExceeded)
+	}
+}
+
+func TestWaitForActive_noMonitorURI(t *testing.T) {
+	if err := WaitForActive(context.Background(), godo.NewClient(nil), ""); err == nil {
+		t.Error("WaitForActive() expected an error")
+	}
+}
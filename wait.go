@@ -0,0 +1,281 @@
+package godo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+const (
+	defaultWaitInterval    = 2 * time.Second
+	defaultWaitMaxInterval = 30 * time.Second
+	defaultWaitMultiplier  = 1.5
+	defaultWaitMaxErrors   = 3
+	defaultWaitConcurrency = 5
+)
+
+// WaitOptions configures how the wait helpers, such as WaitForAction, poll the
+// API.
+type WaitOptions struct {
+	// Interval is the delay before the second poll. Defaults to 2s.
+	Interval time.Duration
+
+	// MaxInterval caps the delay between polls. Defaults to 30s.
+	MaxInterval time.Duration
+
+	// Multiplier is the factor applied to the delay after each poll.
+	// Defaults to 1.5; a value of 1 polls at a fixed interval.
+	Multiplier float64
+
+	// Timeout, if set, bounds the whole wait in addition to ctx.
+	Timeout time.Duration
+
+	// MaxErrors is the number of consecutive retryable errors, such as 5xx
+	// responses, tolerated before giving up. Defaults to 3.
+	MaxErrors int
+}
+
+// ActionWaitOptions configures WaitForAction.
+type ActionWaitOptions struct {
+	WaitOptions
+
+	// OnProgress, if set, is called with the action after each poll.
+	OnProgress func(*Action)
+}
+
+// ActionError is returned when an action being waited for ends with the
+// errored status.
+type ActionError struct {
+	Action *Action
+}
+
+func (e *ActionError) Error() string {
+	return fmt.Sprintf("godo: %s action %d on %s %d errored",
+		e.Action.Type, e.Action.ID, e.Action.ResourceType, e.Action.ResourceID)
+}
+
+// WaitForAction polls the action with the given ID until it completes, and
+// returns it. An *ActionError is returned if the action errors, and the error
+// of ctx if it is done first.
+//
+//	action, err := godo.WaitForAction(ctx, client.Actions, id, godo.ActionWaitOptions{})
+func WaitForAction(ctx context.Context, s ActionsService, id int, opts ActionWaitOptions) (*Action, error) {
+	if id < 1 {
+		return nil, NewArgError("id", "cannot be less than 1")
+	}
+
+	var action *Action
+	err := opts.poll(ctx, func(ctx context.Context) (bool, error) {
+		a, _, err := s.Get(ctx, id)
+		if err != nil {
+			return false, err
+		}
+		action = a
+		if opts.OnProgress != nil {
+			opts.OnProgress(a)
+		}
+
+		switch a.Status {
+		case ActionCompleted:
+			return true, nil
+		case ActionErrored:
+			return true, &ActionError{Action: a}
+		}
+		return false, nil
+	})
+	return action, err
+}
+
+// BatchWaitOptions configures WaitForActions.
+type BatchWaitOptions struct {
+	// ActionWaitOptions configures the wait for each action, except for its
+	// Timeout, which bounds the whole batch. OnProgress may be called
+	// concurrently.
+	ActionWaitOptions
+
+	// Concurrency is the number of actions polled at once. Defaults to 5.
+	Concurrency int
+}
+
+// ActionBatch is the outcome of waiting for a batch of actions with
+// WaitForActions. Actions are listed in the order they were given.
+type ActionBatch struct {
+	Completed []Action
+	Errored   []Action
+
+	// Running holds the last known state of the actions that had not ended
+	// when the wait was cut short.
+	Running []Action
+
+	// Failed holds the errors of the actions that could not be polled, such
+	// as a 404 response, keyed by action ID.
+	Failed map[int]error
+}
+
+// ActionBatchError is returned by ActionBatch.Err when not all the actions of
+// a batch completed.
+type ActionBatchError struct {
+	Batch *ActionBatch
+}
+
+func (e *ActionBatchError) Error() string {
+	b := e.Batch
+	total := len(b.Completed) + len(b.Errored) + len(b.Running) + len(b.Failed)
+	return fmt.Sprintf("godo: %d of %d actions did not complete: %d errored, %d still running, %d could not be polled",
+		total-len(b.Completed), total, len(b.Errored), len(b.Running), len(b.Failed))
+}
+
+// Err returns an *ActionBatchError if not all the actions completed, or nil.
+func (b *ActionBatch) Err() error {
+	if len(b.Errored) == 0 && len(b.Running) == 0 && len(b.Failed) == 0 {
+		return nil
+	}
+	return &ActionBatchError{Batch: b}
+}
+
+// WaitForActions waits for actions concurrently, such as those returned by
+// DropletActionsService.PowerOffByTag, and reports how each of them ended.
+// The batch is returned even on error, which is the error of ctx if it is
+// done before all the actions end, or that of ActionBatch.Err otherwise.
+//
+//	actions, _, err := client.DropletActions.SnapshotByTag(ctx, "web", "nightly")
+//	// ...
+//	batch, err := godo.WaitForActions(ctx, client.Actions, actions, godo.BatchWaitOptions{})
+func WaitForActions(ctx context.Context, s ActionsService, actions []Action, opts BatchWaitOptions) (*ActionBatch, error) {
+	if _, err := opts.withDefaults(); err != nil {
+		return nil, err
+	}
+	if opts.Concurrency < 0 {
+		return nil, NewArgError("Concurrency", "cannot be less than 0")
+	}
+	if opts.Concurrency == 0 {
+		opts.Concurrency = defaultWaitConcurrency
+	}
+	if opts.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, opts.Timeout)
+		defer cancel()
+		opts.Timeout = 0
+	}
+
+	states := make([]Action, len(actions))
+	errs := make([]error, len(actions))
+	copy(states, actions)
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < opts.Concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				a, err := WaitForAction(ctx, s, actions[i].ID, opts.ActionWaitOptions)
+				if a != nil {
+					states[i] = *a
+				}
+				errs[i] = err
+			}
+		}()
+	}
+
+queue:
+	for i, a := range actions {
+		if a.Status == ActionCompleted || a.Status == ActionErrored {
+			continue
+		}
+		select {
+		case jobs <- i:
+		case <-ctx.Done():
+			break queue
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	batch := &ActionBatch{Failed: make(map[int]error)}
+	for i, a := range states {
+		var actionErr *ActionError
+		switch err := errs[i]; {
+		case a.Status == ActionCompleted:
+			batch.Completed = append(batch.Completed, a)
+		case a.Status == ActionErrored || errors.As(err, &actionErr):
+			batch.Errored = append(batch.Errored, a)
+		case err == nil || errors.Is(err, ctx.Err()):
+			// Actions not polled before ctx was done have no error.
+			batch.Running = append(batch.Running, a)
+		default:
+			batch.Failed[a.ID] = err
+		}
+	}
+
+	if len(batch.Running) > 0 && ctx.Err() != nil {
+		return batch, ctx.Err()
+	}
+	return batch, batch.Err()
+}
+
+func (o WaitOptions) withDefaults() (WaitOptions, error) {
+	if o.Interval <= 0 {
+		o.Interval = defaultWaitInterval
+	}
+	if o.MaxInterval <= 0 {
+		o.MaxInterval = defaultWaitMaxInterval
+	}
+	if o.MaxInterval < o.Interval {
+		return o, NewArgError("MaxInterval", "cannot be less than Interval")
+	}
+	if o.Multiplier == 0 {
+		o.Multiplier = defaultWaitMultiplier
+	}
+	if o.Multiplier < 1 {
+		return o, NewArgError("Multiplier", "cannot be less than 1")
+	}
+	if o.MaxErrors == 0 {
+		o.MaxErrors = defaultWaitMaxErrors
+	}
+	return o, nil
+}
+
+// poll calls check until it reports done or fails, backing off between calls.
+// Retryable errors are tolerated up to MaxErrors times in a row.
+func (o WaitOptions) poll(ctx context.Context, check func(context.Context) (bool, error)) error {
+	o, err := o.withDefaults()
+	if err != nil {
+		return err
+	}
+	if o.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, o.Timeout)
+		defer cancel()
+	}
+
+	interval := o.Interval
+	errorCount := 0
+	for {
+		done, err := check(ctx)
+		switch {
+		case done:
+			return err
+		case err != nil && ctx.Err() != nil:
+			// The error of a request cut short by ctx is only a symptom.
+			return ctx.Err()
+		case err != nil:
+			if !IsRetryable(err) || errorCount >= o.MaxErrors {
+				return err
+			}
+			errorCount++
+		default:
+			errorCount = 0
+		}
+
+		if err := sleep(ctx, interval); err != nil {
+			return err
+		}
+		interval = time.Duration(float64(interval) * o.Multiplier)
+		if interval > o.MaxInterval {
+			interval = o.MaxInterval
+		}
+	}
+}
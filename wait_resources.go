@@ -0,0 +1,160 @@
+package godo
+
+import (
+	"context"
+	"fmt"
+)
+
+// ResourceWaitOptions configures the waiters of resources that report their
+// state instead of returning actions, such as WaitForKubernetesCluster.
+type ResourceWaitOptions struct {
+	WaitOptions
+
+	// OnProgress, if set, is called with the state of the resource after each
+	// poll.
+	OnProgress func(state string)
+}
+
+// ResourceStateError is returned when a resource being waited for reaches a
+// state it cannot become ready from, such as a degraded Kubernetes cluster.
+type ResourceStateError struct {
+	// ResourceType is the type of the resource, such as "load balancer".
+	ResourceType string
+	ID           string
+	State        string
+}
+
+func (e *ResourceStateError) Error() string {
+	return fmt.Sprintf("godo: %s %s is %s", e.ResourceType, e.ID, e.State)
+}
+
+// WaitForKubernetesCluster polls the cluster with the given ID until it is
+// running, and returns it. A *ResourceStateError is returned if the cluster
+// is degraded, errored, invalid or deleted.
+func WaitForKubernetesCluster(ctx context.Context, s KubernetesService, id string, opts ResourceWaitOptions) (*KubernetesCluster, error) {
+	var cluster *KubernetesCluster
+	err := opts.waitForState(ctx, "kubernetes cluster", id,
+		func(ctx context.Context) (string, error) {
+			c, _, err := s.Get(ctx, id)
+			if err != nil {
+				return "", err
+			}
+			cluster = c
+			if c.Status == nil {
+				return "", nil
+			}
+			return string(c.Status.State), nil
+		},
+		string(KubernetesClusterStatusRunning),
+		string(KubernetesClusterStatusDegraded),
+		string(KubernetesClusterStatusError),
+		string(KubernetesClusterStatusInvalid),
+		string(KubernetesClusterStatusDeleted),
+	)
+	return cluster, err
+}
+
+// WaitForDatabase polls the database cluster with the given ID until it is
+// online, and returns it.
+func WaitForDatabase(ctx context.Context, s DatabasesService, id string, opts ResourceWaitOptions) (*Database, error) {
+	var db *Database
+	err := opts.waitForState(ctx, "database", id,
+		func(ctx context.Context) (string, error) {
+			d, _, err := s.Get(ctx, id)
+			if err != nil {
+				return "", err
+			}
+			db = d
+			return d.Status, nil
+		},
+		DatabaseStatusOnline,
+	)
+	return db, err
+}
+
+// WaitForLoadBalancer polls the load balancer with the given ID until it is
+// active, and returns it. A *ResourceStateError is returned if the load
+// balancer errors.
+func WaitForLoadBalancer(ctx context.Context, s LoadBalancersService, id string, opts ResourceWaitOptions) (*LoadBalancer, error) {
+	var lb *LoadBalancer
+	err := opts.waitForState(ctx, "load balancer", id,
+		func(ctx context.Context) (string, error) {
+			l, _, err := s.Get(ctx, id)
+			if err != nil {
+				return "", err
+			}
+			lb = l
+			return l.Status, nil
+		},
+		LoadBalancerStatusActive,
+		LoadBalancerStatusErrored,
+	)
+	return lb, err
+}
+
+// WaitForCertificate polls the certificate with the given ID until it is
+// verified, and returns it. A *ResourceStateError is returned if the
+// certificate could not be issued.
+func WaitForCertificate(ctx context.Context, s CertificatesService, id string, opts ResourceWaitOptions) (*Certificate, error) {
+	var cert *Certificate
+	err := opts.waitForState(ctx, "certificate", id,
+		func(ctx context.Context) (string, error) {
+			c, _, err := s.Get(ctx, id)
+			if err != nil {
+				return "", err
+			}
+			cert = c
+			return c.State, nil
+		},
+		CertificateStateVerified,
+		CertificateStateError,
+	)
+	return cert, err
+}
+
+// WaitForCDN waits for the CDN endpoint with the given ID to be usable, and
+// returns it. CDN endpoints have no state, so this waits for the certificate
+// of their custom domain, if they have one, to be verified, with the same
+// options and errors as WaitForCertificate.
+func WaitForCDN(ctx context.Context, cdns CDNService, certs CertificatesService, id string, opts ResourceWaitOptions) (*CDN, error) {
+	if id == "" {
+		return nil, NewArgError("id", "cannot be empty")
+	}
+
+	cdn, _, err := cdns.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if cdn.CertificateID == "" {
+		return cdn, nil
+	}
+
+	_, err = WaitForCertificate(ctx, certs, cdn.CertificateID, opts)
+	return cdn, err
+}
+
+// waitForState polls the state of a resource with get until it is ready,
+// failing if it reaches one of the failed states.
+func (o ResourceWaitOptions) waitForState(ctx context.Context, resourceType, id string, get func(context.Context) (string, error), ready string, failed ...string) error {
+	if id == "" {
+		return NewArgError("id", "cannot be empty")
+	}
+
+	return o.poll(ctx, func(ctx context.Context) (bool, error) {
+		state, err := get(ctx)
+		if err != nil {
+			return false, err
+		}
+		if o.OnProgress != nil {
+			o.OnProgress(state)
+		}
+
+		switch {
+		case state == ready:
+			return true, nil
+		case contains(failed, state):
+			return true, &ResourceStateError{ResourceType: resourceType, ID: id, State: state}
+		}
+		return false, nil
+	})
+}
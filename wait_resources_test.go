@@ -0,0 +1,164 @@
+package godo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// handleStates serves the resource at path with a state per poll, repeating
+// the last one. format is the body with a %q verb for the state.
+func handleStates(path, format string, states ...string) {
+	polls := 0
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		state := states[len(states)-1]
+		if polls < len(states) {
+			state = states[polls]
+		}
+		polls++
+		fmt.Fprintf(w, format, state)
+	})
+}
+
+func TestResourceWaiters(t *testing.T) {
+	waiters := map[string]struct {
+		path   string
+		format string
+		wait   func(context.Context, ResourceWaitOptions) (interface{}, error)
+	}{
+		"kubernetes cluster": {
+			path:   "/v2/kubernetes/clusters/id",
+			format: `{"kubernetes_cluster": {"id": "id", "status": {"state": %q}}}`,
+			wait: func(ctx context.Context, opts ResourceWaitOptions) (interface{}, error) {
+				return WaitForKubernetesCluster(ctx, client.Kubernetes, "id", opts)
+			},
+		},
+		"database": {
+			path:   "/v2/databases/id",
+			format: `{"database": {"id": "id", "status": %q}}`,
+			wait: func(ctx context.Context, opts ResourceWaitOptions) (interface{}, error) {
+				return WaitForDatabase(ctx, client.Databases, "id", opts)
+			},
+		},
+		"load balancer": {
+			path:   "/v2/load_balancers/id",
+			format: `{"load_balancer": {"id": "id", "status": %q}}`,
+			wait: func(ctx context.Context, opts ResourceWaitOptions) (interface{}, error) {
+				return WaitForLoadBalancer(ctx, client.LoadBalancers, "id", opts)
+			},
+		},
+		"certificate": {
+			path:   "/v2/certificates/id",
+			format: `{"certificate": {"id": "id", "state": %q}}`,
+			wait: func(ctx context.Context, opts ResourceWaitOptions) (interface{}, error) {
+				return WaitForCertificate(ctx, client.Certificates, "id", opts)
+			},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		resource string
+		states   []string
+
+		// failed is the state expected in a *ResourceStateError, if any.
+		failed string
+	}{
+		{name: "running", resource: "kubernetes cluster", states: []string{"provisioning", "running"}},
+		{name: "degraded", resource: "kubernetes cluster", states: []string{"provisioning", "degraded"}, failed: "degraded"},
+		{name: "online", resource: "database", states: []string{"creating", "online"}},
+		{name: "active", resource: "load balancer", states: []string{"new", "active"}},
+		{name: "errored", resource: "load balancer", states: []string{"new", "errored"}, failed: "errored"},
+		{name: "verified", resource: "certificate", states: []string{"pending", "verified"}},
+		{name: "error", resource: "certificate", states: []string{"pending", "error"}, failed: "error"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.resource+" "+tt.name, func(t *testing.T) {
+			setup()
+			defer teardown()
+
+			w := waiters[tt.resource]
+			handleStates(w.path, w.format, tt.states...)
+
+			var progress []string
+			v, err := w.wait(ctx, ResourceWaitOptions{
+				WaitOptions: fastWait,
+				OnProgress:  func(state string) { progress = append(progress, state) },
+			})
+			if v == nil {
+				t.Error("expected the resource to be returned")
+			}
+			if fmt.Sprint(progress) != fmt.Sprint(tt.states) {
+				t.Errorf("progress = %v, expected %v", progress, tt.states)
+			}
+
+			if tt.failed == "" {
+				if err != nil {
+					t.Errorf("error = %v", err)
+				}
+				return
+			}
+			var stateErr *ResourceStateError
+			if !errors.As(err, &stateErr) {
+				t.Fatalf("error = %v, expected a *ResourceStateError", err)
+			}
+			expected := ResourceStateError{ResourceType: tt.resource, ID: "id", State: tt.failed}
+			if *stateErr != expected {
+				t.Errorf("error = %+v, expected %+v", *stateErr, expected)
+			}
+		})
+	}
+}
+
+func TestWaitForDatabase_timeout(t *testing.T) {
+	setup()
+	defer teardown()
+
+	handleStates("/v2/databases/id", `{"database": {"id": "id", "status": %q}}`, "creating")
+
+	opts := ResourceWaitOptions{WaitOptions: fastWait}
+	opts.Timeout = 20 * time.Millisecond
+	db, err := WaitForDatabase(ctx, client.Databases, "id", opts)
+	if err != context.DeadlineExceeded {
+		t.Errorf("error = %v, expected context.DeadlineExceeded", err)
+	}
+	if db == nil || db.Status != "creating" {
+		t.Errorf("database = %+v, expected the last polled state", db)
+	}
+
+	if _, err := WaitForDatabase(ctx, client.Databases, "", opts); err == nil {
+		t.Error("expected an error for an empty ID")
+	}
+}
+
+func TestWaitForCDN(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v2/cdn/endpoints/plain", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"endpoint": {"id": "plain", "origin": "static.nyc3.digitaloceanspaces.com"}}`)
+	})
+	mux.HandleFunc("/v2/cdn/endpoints/custom", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"endpoint": {"id": "custom", "certificate_id": "cert", "custom_domain": "static.example.com"}}`)
+	})
+	handleStates("/v2/certificates/cert", `{"certificate": {"id": "cert", "state": %q}}`, "pending", "pending", "verified")
+
+	opts := ResourceWaitOptions{WaitOptions: fastWait}
+	if cdn, err := WaitForCDN(ctx, client.CDNs, client.Certificates, "plain", opts); err != nil || cdn.ID != "plain" {
+		t.Errorf("WaitForCDN(plain) = %+v, %v", cdn, err)
+	}
+
+	polls := 0
+	opts.OnProgress = func(string) { polls++ }
+	cdn, err := WaitForCDN(ctx, client.CDNs, client.Certificates, "custom", opts)
+	if err != nil || cdn.CustomDomain != "static.example.com" {
+		t.Errorf("WaitForCDN(custom) = %+v, %v", cdn, err)
+	}
+	if polls != 3 {
+		t.Errorf("certificate polled %d times, expected 3", polls)
+	}
+}
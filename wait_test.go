@@ -0,0 +1,279 @@
+package godo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fastWait polls without delay so that tests run quickly.
+var fastWait = WaitOptions{Interval: time.Millisecond, MaxInterval: time.Millisecond}
+
+// handleActionStatuses serves the action with the given ID with a status per
+// poll, repeating the last one.
+func handleActionStatuses(id int, statuses ...string) *int {
+	polls := new(int)
+	mux.HandleFunc(fmt.Sprintf("/v2/actions/%d", id), func(w http.ResponseWriter, r *http.Request) {
+		status := statuses[len(statuses)-1]
+		if *polls < len(statuses) {
+			status = statuses[*polls]
+		}
+		*polls++
+		if status == "" {
+			w.WriteHeader(http.StatusInternalServerError)
+			fmt.Fprint(w, `{"id": "server_error", "message": "boom"}`)
+			return
+		}
+		fmt.Fprintf(w, `{"action": {"id": %d, "status": %q, "type": "power_off", "resource_type": "droplet", "resource_id": 7}}`, id, status)
+	})
+	return polls
+}
+
+func TestWaitForAction(t *testing.T) {
+	setup()
+	defer teardown()
+
+	polls := handleActionStatuses(1, ActionInProgress, "", ActionInProgress, ActionCompleted)
+
+	var progress []string
+	action, err := WaitForAction(ctx, client.Actions, 1, ActionWaitOptions{
+		WaitOptions: fastWait,
+		OnProgress:  func(a *Action) { progress = append(progress, a.Status) },
+	})
+	if err != nil {
+		t.Fatalf("WaitForAction() error = %v", err)
+	}
+	if action.Status != ActionCompleted {
+		t.Errorf("action = %+v", action)
+	}
+	if *polls != 4 {
+		t.Errorf("polled %d times, expected 4", *polls)
+	}
+	if expected := []string{ActionInProgress, ActionInProgress, ActionCompleted}; fmt.Sprint(progress) != fmt.Sprint(expected) {
+		t.Errorf("progress = %v, expected %v", progress, expected)
+	}
+}
+
+func TestWaitForAction_errored(t *testing.T) {
+	setup()
+	defer teardown()
+
+	handleActionStatuses(1, ActionInProgress, ActionErrored)
+
+	action, err := WaitForAction(ctx, client.Actions, 1, ActionWaitOptions{WaitOptions: fastWait})
+
+	var actionErr *ActionError
+	if !errors.As(err, &actionErr) {
+		t.Fatalf("error = %v, expected an *ActionError", err)
+	}
+	if actionErr.Action.ID != 1 || action != actionErr.Action {
+		t.Errorf("action = %+v", actionErr.Action)
+	}
+	if expected := "godo: power_off action 1 on droplet 7 errored"; err.Error() != expected {
+		t.Errorf("Error() = %q, expected %q", err, expected)
+	}
+}
+
+func TestWaitForAction_tooManyErrors(t *testing.T) {
+	setup()
+	defer teardown()
+
+	polls := handleActionStatuses(1, "")
+
+	opts := fastWait
+	opts.MaxErrors = 2
+	_, err := WaitForAction(ctx, client.Actions, 1, ActionWaitOptions{WaitOptions: opts})
+	if !errors.Is(err, ErrServerError) {
+		t.Errorf("error = %v, expected ErrServerError", err)
+	}
+	if *polls != 3 {
+		t.Errorf("polled %d times, expected 3", *polls)
+	}
+}
+
+func TestWaitForAction_notFound(t *testing.T) {
+	setup()
+	defer teardown()
+
+	polls := 0
+	mux.HandleFunc("/v2/actions/1", func(w http.ResponseWriter, r *http.Request) {
+		polls++
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"id": "not_found", "message": "not found"}`)
+	})
+
+	_, err := WaitForAction(ctx, client.Actions, 1, ActionWaitOptions{WaitOptions: fastWait})
+	if !errors.Is(err, ErrNotFound) || polls != 1 {
+		t.Errorf("error = %v after %d polls, expected ErrNotFound after one", err, polls)
+	}
+}
+
+func TestWaitForAction_context(t *testing.T) {
+	setup()
+	defer teardown()
+
+	handleActionStatuses(1, ActionInProgress)
+
+	cancelCtx, cancel := context.WithCancel(ctx)
+	action, err := WaitForAction(cancelCtx, client.Actions, 1, ActionWaitOptions{
+		WaitOptions: fastWait,
+		OnProgress:  func(*Action) { cancel() },
+	})
+	if err != context.Canceled {
+		t.Errorf("error = %v, expected context.Canceled", err)
+	}
+	if action == nil || action.Status != ActionInProgress {
+		t.Errorf("action = %+v, expected the last polled action", action)
+	}
+
+	opts := fastWait
+	opts.Timeout = 20 * time.Millisecond
+	_, err = WaitForAction(ctx, client.Actions, 1, ActionWaitOptions{WaitOptions: opts})
+	if err != context.DeadlineExceeded {
+		t.Errorf("error = %v, expected context.DeadlineExceeded", err)
+	}
+}
+
+func TestWaitForAction_invalidOptions(t *testing.T) {
+	tests := map[string]WaitOptions{
+		"MaxInterval": {Interval: time.Minute, MaxInterval: time.Second},
+		"Multiplier":  {Multiplier: 0.5},
+	}
+	for arg, opts := range tests {
+		_, err := WaitForAction(ctx, NewClient(nil).Actions, 1, ActionWaitOptions{WaitOptions: opts})
+		var argErr *ArgError
+		if !errors.As(err, &argErr) || argErr.arg != arg {
+			t.Errorf("%s: error = %v, expected an *ArgError", arg, err)
+		}
+	}
+
+	if _, err := WaitForAction(ctx, NewClient(nil).Actions, 0, ActionWaitOptions{}); err == nil {
+		t.Error("expected an error for an invalid ID")
+	}
+}
+
+func TestWaitForActions(t *testing.T) {
+	setup()
+	defer teardown()
+
+	handleActionStatuses(1, ActionInProgress, ActionCompleted)
+	handleActionStatuses(2, ActionInProgress, ActionErrored)
+	handleActionStatuses(3, ActionCompleted)
+	mux.HandleFunc("/v2/actions/4", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"id": "not_found", "message": "not found"}`)
+	})
+
+	var mu sync.Mutex
+	progress := make(map[int]int)
+	opts := BatchWaitOptions{
+		ActionWaitOptions: ActionWaitOptions{
+			WaitOptions: fastWait,
+			OnProgress: func(a *Action) {
+				mu.Lock()
+				progress[a.ID]++
+				mu.Unlock()
+			},
+		},
+		Concurrency: 2,
+	}
+	actions := []Action{
+		{ID: 1, Status: ActionInProgress},
+		{ID: 2, Status: ActionInProgress},
+		{ID: 3, Status: ActionInProgress},
+		{ID: 4, Status: ActionInProgress},
+		{ID: 5, Status: ActionCompleted},
+	}
+	batch, err := WaitForActions(ctx, client.Actions, actions, opts)
+
+	var batchErr *ActionBatchError
+	if !errors.As(err, &batchErr) || batchErr.Batch != batch {
+		t.Fatalf("error = %v, expected an *ActionBatchError", err)
+	}
+	if expected := "godo: 2 of 5 actions did not complete: 1 errored, 0 still running, 1 could not be polled"; err.Error() != expected {
+		t.Errorf("Error() = %q, expected %q", err, expected)
+	}
+	if ids := actionIDs(batch.Completed); ids != "[1 3 5]" {
+		t.Errorf("completed = %s", ids)
+	}
+	if ids := actionIDs(batch.Errored); ids != "[2]" {
+		t.Errorf("errored = %s", ids)
+	}
+	if len(batch.Running) != 0 || len(batch.Failed) != 1 || !errors.Is(batch.Failed[4], ErrNotFound) {
+		t.Errorf("running = %v, failed = %v", batch.Running, batch.Failed)
+	}
+	if progress[1] != 2 || progress[3] != 1 || progress[5] != 0 {
+		t.Errorf("progress = %v", progress)
+	}
+}
+
+func TestWaitForActions_concurrency(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var mu sync.Mutex
+	inFlight, maxInFlight := 0, 0
+	mux.HandleFunc("/v2/actions/", func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		inFlight++
+		if inFlight > maxInFlight {
+			maxInFlight = inFlight
+		}
+		mu.Unlock()
+
+		time.Sleep(5 * time.Millisecond)
+		fmt.Fprint(w, `{"action": {"id": 1, "status": "completed"}}`)
+
+		mu.Lock()
+		inFlight--
+		mu.Unlock()
+	})
+
+	actions := make([]Action, 10)
+	for i := range actions {
+		actions[i] = Action{ID: i + 1, Status: ActionInProgress}
+	}
+	batch, err := WaitForActions(ctx, client.Actions, actions, BatchWaitOptions{Concurrency: 3})
+	if err != nil {
+		t.Fatalf("WaitForActions() error = %v", err)
+	}
+	if len(batch.Completed) != 10 {
+		t.Errorf("completed = %d actions, expected 10", len(batch.Completed))
+	}
+	if maxInFlight > 3 {
+		t.Errorf("%d actions polled at once, expected at most 3", maxInFlight)
+	}
+}
+
+func TestWaitForActions_timeout(t *testing.T) {
+	setup()
+	defer teardown()
+
+	handleActionStatuses(1, ActionCompleted)
+	handleActionStatuses(2, ActionInProgress)
+
+	opts := BatchWaitOptions{ActionWaitOptions: ActionWaitOptions{WaitOptions: fastWait}}
+	opts.Timeout = 20 * time.Millisecond
+	batch, err := WaitForActions(ctx, client.Actions, []Action{{ID: 1}, {ID: 2}}, opts)
+	if err != context.DeadlineExceeded {
+		t.Errorf("error = %v, expected context.DeadlineExceeded", err)
+	}
+	if ids := actionIDs(batch.Completed); ids != "[1]" {
+		t.Errorf("completed = %s", ids)
+	}
+	if len(batch.Running) != 1 || batch.Running[0].Status != ActionInProgress {
+		t.Errorf("running = %+v, expected the last state of action 2", batch.Running)
+	}
+}
+
+func actionIDs(actions []Action) string {
+	ids := make([]int, len(actions))
+	for i, a := range actions {
+		ids[i] = a.ID
+	}
+	return fmt.Sprint(ids)
+}
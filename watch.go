@@ -0,0 +1,225 @@
+package godo
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+)
+
+const (
+	defaultWatchInterval = 10 * time.Second
+	defaultWatchPerPage  = 50
+	defaultWatchMaxPages = 5
+)
+
+// ActionFilter selects actions by their attributes. An empty list matches any
+// value.
+type ActionFilter struct {
+	// ResourceTypes are resource types such as "droplet" or "volume".
+	ResourceTypes []string
+
+	// Types are action types such as "resize", "rebuild" or "destroy".
+	Types []string
+
+	// Regions are region slugs such as "nyc3".
+	Regions []string
+}
+
+// Match reports whether the filter selects a.
+func (f ActionFilter) Match(a *Action) bool {
+	return matchAny(f.ResourceTypes, a.ResourceType) &&
+		matchAny(f.Types, a.Type) &&
+		matchAny(f.Regions, actionRegion(a))
+}
+
+func matchAny(values []string, v string) bool {
+	return len(values) == 0 || contains(values, v)
+}
+
+func actionRegion(a *Action) string {
+	if a.RegionSlug != "" {
+		return a.RegionSlug
+	}
+	if a.Region != nil {
+		return a.Region.Slug
+	}
+	return ""
+}
+
+// WatchOptions configures WatchActions.
+type WatchOptions struct {
+	// Interval is the delay between polls of the action feed. Defaults to 10s.
+	Interval time.Duration
+
+	// Filter selects the actions for which events are sent.
+	Filter ActionFilter
+
+	// IncludeExisting sends events for the actions already in the feed when
+	// the watch starts. By default only their later status changes are.
+	IncludeExisting bool
+
+	// PerPage is the number of actions listed per page. Defaults to 50.
+	PerPage int
+
+	// MaxPages caps the number of pages listed per poll when many actions
+	// were started since the previous one. Defaults to 5.
+	MaxPages int
+
+	// OnError, if set, is called with the errors of the polls. The watch
+	// carries on after them.
+	OnError func(error)
+}
+
+// ActionEvent reports an action that is new or whose status changed.
+type ActionEvent struct {
+	Action Action
+
+	// PreviousStatus is the status of the action at the previous poll, or
+	// empty if the action is new.
+	PreviousStatus string
+}
+
+// WatchActions polls the action feed of the account and sends an event on the
+// returned channel for every action that is started or changes status, such
+// as from in-progress to completed, oldest first. The channel is closed once
+// ctx is done.
+//
+//	events, err := godo.WatchActions(ctx, client.Actions, godo.WatchOptions{
+//		Filter: godo.ActionFilter{Types: []string{"resize", "rebuild", "destroy"}},
+//	})
+//	if err != nil {
+//		return err
+//	}
+//	for e := range events {
+//		log.Printf("%s %d: %s %s", e.Action.ResourceType, e.Action.ResourceID, e.Action.Type, e.Action.Status)
+//	}
+func WatchActions(ctx context.Context, s ActionsService, opts WatchOptions) (<-chan ActionEvent, error) {
+	if opts.Interval < 0 {
+		return nil, NewArgError("Interval", "cannot be less than 0")
+	}
+	if opts.Interval == 0 {
+		opts.Interval = defaultWatchInterval
+	}
+	if opts.PerPage <= 0 {
+		opts.PerPage = defaultWatchPerPage
+	}
+	if opts.MaxPages <= 0 {
+		opts.MaxPages = defaultWatchMaxPages
+	}
+
+	w := &actionWatcher{
+		actions: s,
+		opts:    opts,
+		events:  make(chan ActionEvent),
+		known:   make(map[int]string),
+	}
+	go w.run(ctx)
+	return w.events, nil
+}
+
+type actionWatcher struct {
+	actions ActionsService
+	opts    WatchOptions
+	events  chan ActionEvent
+
+	// known holds the last seen status of the actions, by ID.
+	known map[int]string
+
+	// latest is the highest action ID seen, zero before the first poll.
+	latest int
+}
+
+func (w *actionWatcher) run(ctx context.Context) {
+	defer close(w.events)
+
+	emit := w.opts.IncludeExisting
+	for {
+		err := w.poll(ctx, emit)
+		if ctx.Err() != nil {
+			return
+		}
+		if err != nil {
+			if w.opts.OnError != nil {
+				w.opts.OnError(err)
+			}
+		} else {
+			emit = true
+		}
+
+		if sleep(ctx, w.opts.Interval) != nil {
+			return
+		}
+	}
+}
+
+// poll fetches the actions started since the previous poll and those still
+// in progress, sending events for their changes if emit is set.
+func (w *actionWatcher) poll(ctx context.Context, emit bool) error {
+	seen := make(map[int]Action)
+
+	opt := &ListOptions{Page: 1, PerPage: w.opts.PerPage}
+	for page := 0; page < w.opts.MaxPages; page++ {
+		actions, resp, err := w.actions.List(ctx, opt)
+		if err != nil {
+			return err
+		}
+
+		// The feed lists the most recent actions first, so the next page only
+		// has new actions if all of this one are.
+		onlyNew := true
+		for _, a := range actions {
+			seen[a.ID] = a
+			if a.ID <= w.latest {
+				onlyNew = false
+			}
+		}
+		if !onlyNew || w.latest == 0 || resp == nil || resp.Links == nil || resp.Links.IsLastPage() {
+			break
+		}
+		opt.Page++
+	}
+
+	// Actions in progress that left the listed pages are fetched one by one
+	// so that their completion is not missed.
+	for id, status := range w.known {
+		if _, ok := seen[id]; ok || status != ActionInProgress {
+			continue
+		}
+		a, _, err := w.actions.Get(ctx, id)
+		if errors.Is(err, ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		seen[id] = *a
+	}
+
+	ids := make([]int, 0, len(seen))
+	for id := range seen {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+
+	known := make(map[int]string, len(seen))
+	for _, id := range ids {
+		a := seen[id]
+		known[id] = a.Status
+		if id > w.latest {
+			w.latest = id
+		}
+
+		prev, ok := w.known[id]
+		if !emit || ok && prev == a.Status || !w.opts.Filter.Match(&a) {
+			continue
+		}
+		select {
+		case w.events <- ActionEvent{Action: a, PreviousStatus: prev}:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	w.known = known
+	return nil
+}
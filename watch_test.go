@@ -0,0 +1,251 @@
+package godo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+// actionFeed serves an action feed whose content depends on the number of
+// times it was listed.
+type actionFeed struct {
+	mu    sync.Mutex
+	lists int
+
+	// pages returns the actions listed the nth time, most recent first.
+	pages func(n int) []Action
+}
+
+func (f *actionFeed) handle(t *testing.T) {
+	mux.HandleFunc("/v2/actions", func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		if r.FormValue("page") == "1" {
+			f.lists++
+		}
+		actions := f.pages(f.lists)
+		f.mu.Unlock()
+
+		page, _ := strconv.Atoi(r.FormValue("page"))
+		perPage, _ := strconv.Atoi(r.FormValue("per_page"))
+		start, end := (page-1)*perPage, page*perPage
+		root := actionsRoot{Actions: []Action{}, Links: &Links{Pages: &Pages{}}}
+		if start < len(actions) {
+			if end >= len(actions) {
+				end = len(actions)
+			} else {
+				root.Links.Pages.Next = fmt.Sprintf("%s/v2/actions?page=%d", server.URL, page+1)
+				root.Links.Pages.Last = fmt.Sprintf("%s/v2/actions?page=%d", server.URL, (len(actions)+perPage-1)/perPage)
+			}
+			root.Actions = actions[start:end]
+		}
+		if err := json.NewEncoder(w).Encode(root); err != nil {
+			t.Error(err)
+		}
+	})
+}
+
+func nextEvents(t *testing.T, events <-chan ActionEvent, n int) []ActionEvent {
+	t.Helper()
+
+	var got []ActionEvent
+	for len(got) < n {
+		select {
+		case e, ok := <-events:
+			if !ok {
+				t.Fatalf("events closed after %v", got)
+			}
+			got = append(got, e)
+		case <-time.After(time.Second):
+			t.Fatalf("timed out after events %v", got)
+		}
+	}
+	return got
+}
+
+func eventsString(events []ActionEvent) string {
+	s := ""
+	for _, e := range events {
+		s += fmt.Sprintf("%d:%s->%s ", e.Action.ID, e.PreviousStatus, e.Action.Status)
+	}
+	return s
+}
+
+func TestWatchActions(t *testing.T) {
+	setup()
+	defer teardown()
+
+	feed := &actionFeed{pages: func(n int) []Action {
+		if n == 1 {
+			return []Action{
+				{ID: 2, Status: ActionInProgress, Type: "power_off"},
+				{ID: 1, Status: ActionCompleted, Type: "resize"},
+			}
+		}
+		return []Action{
+			{ID: 4, Status: ActionInProgress, Type: "snapshot"},
+			{ID: 3, Status: ActionInProgress, Type: "resize", ResourceType: "droplet", RegionSlug: "nyc3"},
+			{ID: 2, Status: ActionCompleted, Type: "power_off"},
+			{ID: 1, Status: ActionCompleted, Type: "resize"},
+		}
+	}}
+	feed.handle(t)
+
+	watchCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	events, err := WatchActions(watchCtx, client.Actions, WatchOptions{
+		Interval: time.Millisecond,
+		Filter:   ActionFilter{Types: []string{"resize", "power_off"}},
+	})
+	if err != nil {
+		t.Fatalf("WatchActions() error = %v", err)
+	}
+
+	got := nextEvents(t, events, 2)
+	if s, expected := eventsString(got), "2:in-progress->completed 3:->in-progress "; s != expected {
+		t.Errorf("events = %s, expected %s", s, expected)
+	}
+	if got[1].Action.ResourceType != "droplet" || got[1].Action.RegionSlug != "nyc3" {
+		t.Errorf("event = %+v", got[1])
+	}
+
+	cancel()
+	for e := range events {
+		t.Errorf("unexpected event %+v", e)
+	}
+}
+
+func TestWatchActions_pages(t *testing.T) {
+	setup()
+	defer teardown()
+
+	feed := &actionFeed{pages: func(n int) []Action {
+		if n == 1 {
+			return []Action{
+				{ID: 2, Status: ActionCompleted},
+				{ID: 1, Status: ActionInProgress},
+			}
+		}
+		return []Action{
+			{ID: 5, Status: ActionCompleted},
+			{ID: 4, Status: ActionCompleted},
+			{ID: 3, Status: ActionCompleted},
+			{ID: 2, Status: ActionCompleted},
+			{ID: 1, Status: ActionCompleted},
+		}
+	}}
+	feed.handle(t)
+	mux.HandleFunc("/v2/actions/1", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"action": {"id": 1, "status": "completed"}}`)
+	})
+
+	watchCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	events, err := WatchActions(watchCtx, client.Actions, WatchOptions{Interval: time.Millisecond, PerPage: 2})
+	if err != nil {
+		t.Fatalf("WatchActions() error = %v", err)
+	}
+
+	got := nextEvents(t, events, 4)
+	if s, expected := eventsString(got), "1:in-progress->completed 3:->completed 4:->completed 5:->completed "; s != expected {
+		t.Errorf("events = %s, expected %s", s, expected)
+	}
+}
+
+func TestWatchActions_includeExisting(t *testing.T) {
+	setup()
+	defer teardown()
+
+	failed := false
+	mux.HandleFunc("/v2/actions", func(w http.ResponseWriter, r *http.Request) {
+		if !failed {
+			failed = true
+			w.WriteHeader(http.StatusInternalServerError)
+			fmt.Fprint(w, `{"id": "server_error", "message": "boom"}`)
+			return
+		}
+		fmt.Fprint(w, `{"actions": [{"id": 1, "status": "completed"}]}`)
+	})
+
+	errs := make(chan error, 1)
+	watchCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	events, err := WatchActions(watchCtx, client.Actions, WatchOptions{
+		Interval:        time.Millisecond,
+		IncludeExisting: true,
+		OnError:         func(err error) { errs <- err },
+	})
+	if err != nil {
+		t.Fatalf("WatchActions() error = %v", err)
+	}
+
+	got := nextEvents(t, events, 1)
+	if s := eventsString(got); s != "1:->completed " {
+		t.Errorf("events = %s", s)
+	}
+	if err := <-errs; err == nil {
+		t.Error("expected the failed poll to be reported")
+	}
+}
+
+func TestActionFilter_Match(t *testing.T) {
+	a := &Action{Type: "rebuild", ResourceType: "droplet", Region: &Region{Slug: "ams3"}}
+
+	tests := []struct {
+		filter   ActionFilter
+		expected bool
+	}{
+		{ActionFilter{}, true},
+		{ActionFilter{Types: []string{"resize", "rebuild"}}, true},
+		{ActionFilter{Types: []string{"resize"}}, false},
+		{ActionFilter{ResourceTypes: []string{"volume"}}, false},
+		{ActionFilter{ResourceTypes: []string{"droplet"}, Regions: []string{"ams3"}}, true},
+		{ActionFilter{Regions: []string{"nyc3"}}, false},
+	}
+	for _, tt := range tests {
+		if got := tt.filter.Match(a); got != tt.expected {
+			t.Errorf("%+v.Match() = %v, expected %v", tt.filter, got, tt.expected)
+		}
+	}
+}
+
+// stubActions lists actions without a response, as mocks of ActionsService
+// usually do.
+type stubActions struct {
+	mu    sync.Mutex
+	lists int
+}
+
+func (s *stubActions) List(ctx context.Context, opt *ListOptions) ([]Action, *Response, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.lists++
+	if s.lists == 1 {
+		return []Action{{ID: 1, Status: ActionCompleted}}, nil, nil
+	}
+	return []Action{{ID: 3, Status: ActionCompleted}, {ID: 2, Status: ActionCompleted}}, nil, nil
+}
+
+func (s *stubActions) Get(ctx context.Context, id int) (*Action, *Response, error) {
+	return nil, nil, ErrNotFound
+}
+
+func TestWatchActions_withoutResponse(t *testing.T) {
+	watchCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	events, err := WatchActions(watchCtx, &stubActions{}, WatchOptions{Interval: time.Millisecond})
+	if err != nil {
+		t.Fatalf("WatchActions() error = %v", err)
+	}
+
+	got := nextEvents(t, events, 2)
+	if s, expected := eventsString(got), "2:->completed 3:->completed "; s != expected {
+		t.Errorf("events = %s, expected %s", s, expected)
+	}
+}